@@ -0,0 +1,649 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/parser"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/core/rpcreplay"
+	"github.com/Tsisar/solana-indexer/internal/core/source/geyser"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/archive"
+	"github.com/Tsisar/solana-indexer/internal/storage/migrations"
+	"github.com/Tsisar/solana-indexer/internal/subgraph"
+	"github.com/gagliardetto/solana-go"
+	"io"
+	"math"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// exportBatch is the number of rows loaded per query while exporting.
+const exportBatch = 500
+
+// withDB opens the database for a one-off command and runs fn with a context
+// that is cancelled on SIGINT or SIGTERM.
+func withDB(fn func(ctx context.Context, db *storage.Gorm) int) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := storage.InitGorm()
+	if err != nil {
+		log.Errorf("[main] Failed to init Gorm DB: %v", err)
+		return exitFailed
+	}
+	defer db.Close()
+	return fn(ctx, db)
+}
+
+// parseFlags parses the flags of a command, returning false on invalid usage.
+func parseFlags(fs *flag.FlagSet, args []string) bool {
+	if err := fs.Parse(args); err != nil {
+		return false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %v\n", fs.Args())
+		return false
+	}
+	return true
+}
+
+func backfill(args []string) int {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	program := fs.String("program", "", "program address (required)")
+	fromSlot := fs.Uint64("from-slot", 0, "first slot to backfill")
+	toSlot := fs.Uint64("to-slot", math.MaxInt64, "last slot to backfill")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if _, err := solana.PublicKeyFromBase58(*program); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -program %q: %v\n", *program, err)
+		return exitUsage
+	}
+	if *fromSlot > *toSlot {
+		fmt.Fprintln(os.Stderr, "-from-slot must not be greater than -to-slot")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		// Loaded first, so an indexed program is backfilled with its fetch mode
+		if err := programs.Load(ctx, db); err != nil {
+			log.Errorf("[main] Failed to load programs: %v", err)
+			return exitFailed
+		}
+		if _, err := fetcher.Backfill(ctx, db, *program, *fromSlot, *toSlot); err != nil {
+			log.Errorf("[main] Backfill failed: %v", err)
+			return exitFailed
+		}
+		// Reloaded, a program that wasn't indexed yet was saved by the backfill
+		if err := programs.Load(ctx, db); err != nil {
+			log.Errorf("[main] Failed to load programs: %v", err)
+			return exitFailed
+		}
+		if err := parser.MergeProgram(ctx, db, *program); err != nil {
+			log.Errorf("[main] Parsing backfilled transactions failed: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+func reparse(args []string) int {
+	fs := flag.NewFlagSet("reparse", flag.ContinueOnError)
+	fromSlot := fs.Uint64("from-slot", 0, "first slot to re-parse")
+	toSlot := fs.Uint64("to-slot", math.MaxInt64, "last slot to re-parse")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *fromSlot > *toSlot {
+		fmt.Fprintln(os.Stderr, "-from-slot must not be greater than -to-slot")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		if err := programs.Load(ctx, db); err != nil {
+			log.Errorf("[main] Failed to load programs: %v", err)
+			return exitFailed
+		}
+		if err := parser.Reparse(ctx, db, *fromSlot, *toSlot); err != nil {
+			log.Errorf("[main] Reparse failed: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+func rebuildSubgraph(args []string) int {
+	fs := flag.NewFlagSet("rebuild-subgraph", flag.ContinueOnError)
+	fromSlot := fs.Uint64("from-slot", 0, "replay events from this slot instead of rebuilding everything")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		var err error
+		if *fromSlot > 0 {
+			err = subgraph.RebuildFrom(ctx, db, *fromSlot)
+		} else {
+			err = subgraph.Rebuild(ctx, db)
+		}
+		if err != nil {
+			log.Errorf("[main] Subgraph rebuild failed: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+func verify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		report, err := db.CheckConsistency(ctx)
+		if err != nil {
+			log.Errorf("[main] Verify failed: %v", err)
+			return exitFailed
+		}
+		if err := printJSON(os.Stdout, report); err != nil {
+			log.Errorf("[main] Failed to print report: %v", err)
+			return exitFailed
+		}
+		if !report.OK() {
+			return exitIssues
+		}
+		return exitOK
+	})
+}
+
+func export(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	table := fs.String("table", "transactions", "what to export: transactions or events")
+	fromSlot := fs.Uint64("from-slot", 0, "first slot to export")
+	toSlot := fs.Uint64("to-slot", math.MaxInt64, "last slot to export")
+	out := fs.String("out", "-", "output file, - for stdout")
+	compress := fs.Bool("gzip", false, "gzip the output; implied by an -out ending in .gz")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *table != "transactions" && *table != "events" {
+		fmt.Fprintf(os.Stderr, "unknown -table %q\n", *table)
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		var w io.Writer = os.Stdout
+		if *out != "-" {
+			f, err := os.Create(*out)
+			if err != nil {
+				log.Errorf("[main] Failed to create %s: %v", *out, err)
+				return exitFailed
+			}
+			defer f.Close()
+			w = f
+		}
+
+		var err error
+		if *table == "events" {
+			buf := bufio.NewWriter(w)
+			if err = exportEvents(ctx, db, buf, *fromSlot, *toSlot); err == nil {
+				err = buf.Flush()
+			}
+		} else {
+			// Transactions are written as an archive the import command reads
+			aw := archive.NewWriter(w, *compress || strings.HasSuffix(*out, ".gz"))
+			var n int
+			if n, err = archive.Export(ctx, db, aw, *fromSlot, *toSlot); err == nil {
+				err = aw.Close()
+				log.Infof("[main] Exported %d transactions", n)
+			}
+		}
+		if err != nil {
+			log.Errorf("[main] Export failed: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+// importArchive loads transactions exported by another deployment. Started with
+// resume_from_last_signature, the indexer then parses them and only fetches newer ones from RPC.
+func importArchive(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	in := fs.String("in", "", "archive written by export -table transactions, - for stdin (required)")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "-in is required")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		var r io.Reader = os.Stdin
+		if *in != "-" {
+			f, err := os.Open(*in)
+			if err != nil {
+				log.Errorf("[main] Failed to open %s: %v", *in, err)
+				return exitFailed
+			}
+			defer f.Close()
+			r = f
+		}
+		ar, err := archive.NewReader(r)
+		if err != nil {
+			log.Errorf("[main] %v", err)
+			return exitFailed
+		}
+
+		// The schema and the configured programs must exist to link the transactions
+		if _, err := storage.MigrateSchema(ctx, db); err != nil {
+			log.Errorf("[main] Failed to migrate DB: %v", err)
+			return exitFailed
+		}
+		if err := storage.InitCoreModels(ctx, db, true); err != nil {
+			log.Errorf("[main] Failed to init core models: %v", err)
+			return exitFailed
+		}
+		if err := programs.Load(ctx, db); err != nil {
+			log.Errorf("[main] Failed to load programs: %v", err)
+			return exitFailed
+		}
+		var addresses []string
+		for _, program := range programs.All() {
+			addresses = append(addresses, program.Address)
+		}
+
+		read, imported, err := archive.Import(ctx, db, ar, addresses)
+		if err != nil {
+			log.Errorf("[main] Import failed after %d transactions: %v", read, err)
+			return exitFailed
+		}
+		log.Infof("[main] Imported %d of %d transactions, the others were stored already or belong to programs not indexed here", imported, read)
+		return exitOK
+	})
+}
+
+// exportEvents writes one decoded event per line in canonical order.
+func exportEvents(ctx context.Context, db *storage.Gorm, w io.Writer, fromSlot, toSlot uint64) error {
+	filter := storage.EventFilter{FromSlot: &fromSlot, ToSlot: &toSlot, Limit: exportBatch}
+	enc := json.NewEncoder(w)
+	for {
+		events, err := db.ListEvents(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+		if len(events) < exportBatch {
+			return nil
+		}
+		last := events[len(events)-1]
+		filter.After = &storage.EventCursor{Slot: last.Slot, Signature: last.TransactionSignature, LogIndex: last.LogIndex}
+	}
+}
+
+func decode(args []string) int {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: decode <signature>")
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	sig := fs.Arg(0)
+	if _, err := solana.SignatureFromBase58(sig); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid signature %q: %v\n", sig, err)
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		raw, err := db.GetRawTransaction(ctx, sig)
+		if err != nil {
+			log.Errorf("[main] Failed to load transaction %s: %v", sig, err)
+			return exitFailed
+		}
+		if len(raw) == 0 {
+			log.Infof("[main] Transaction %s is not stored, fetching it from RPC", sig)
+			txRes, err := fetcher.FetchRawTransaction(ctx, sig)
+			if err != nil {
+				log.Errorf("[main] Failed to fetch transaction %s: %v", sig, err)
+				return exitFailed
+			}
+			if txRes == nil {
+				log.Errorf("[main] Transaction %s not found on chain", sig)
+				return exitFailed
+			}
+			if raw, err = json.Marshal(txRes); err != nil {
+				log.Errorf("[main] Failed to marshal transaction %s: %v", sig, err)
+				return exitFailed
+			}
+		}
+
+		if err := programs.Load(ctx, db); err != nil {
+			log.Errorf("[main] Failed to load programs: %v", err)
+			return exitFailed
+		}
+		events, err := parser.Decode(ctx, db, raw, sig)
+		if err != nil {
+			log.Errorf("[main] Decode failed: %v", err)
+			return exitFailed
+		}
+		if err := printJSON(os.Stdout, events); err != nil {
+			log.Errorf("[main] Failed to print events: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+func addProgram(args []string) int {
+	fs := flag.NewFlagSet("add-program", flag.ContinueOnError)
+	defaults := config.NewProgram("")
+	address := fs.String("address", "", "program address (required)")
+	idl := fs.String("idl", defaults.IDL, "IDL the event decoders were generated from")
+	startSlot := fs.Uint64("start-slot", defaults.StartSlot, "history before this slot is not fetched")
+	commitment := fs.String("commitment", defaults.Commitment, "confirmed or finalized")
+	decodeInstructions := fs.Bool("decode-instructions", defaults.DecodeInstructions, "decode SPL token instructions")
+	mapper := fs.String("mapper", defaults.Mapper, "vaults or none")
+	fetchMode := fs.String("fetch-mode", defaults.FetchMode, "signatures or blocks")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	program := config.Program{
+		Address:            *address,
+		IDL:                *idl,
+		StartSlot:          *startSlot,
+		Commitment:         *commitment,
+		DecodeInstructions: *decodeInstructions,
+		Mapper:             *mapper,
+		FetchMode:          *fetchMode,
+	}
+	if err := program.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid program: %v\n", err)
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		// A running indexer picks the program up, backfills and merges it
+		if err := programs.Add(ctx, db, program); err != nil {
+			log.Errorf("[main] Failed to add program: %v", err)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+func removeProgram(args []string) int {
+	fs := flag.NewFlagSet("remove-program", flag.ContinueOnError)
+	address := fs.String("address", "", "program address (required)")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *address == "" {
+		fmt.Fprintln(os.Stderr, "-address is required")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		found, err := programs.Remove(ctx, db, *address)
+		if err != nil {
+			log.Errorf("[main] Failed to remove program: %v", err)
+			return exitFailed
+		}
+		if !found {
+			log.Errorf("[main] Program %s is not indexed", *address)
+			return exitFailed
+		}
+		return exitOK
+	})
+}
+
+// migrate runs "migrate [up|down|status] [flags]"; the action defaults to up.
+func migrate(args []string) int {
+	action := "up"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	var to, steps *int
+	switch action {
+	case "up":
+		to = fs.Int("to", 0, "version to migrate up to (default latest)")
+	case "down":
+		steps = fs.Int("steps", 1, "number of migrations to revert")
+	case "status":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate action %q, expected up, down or status\n", action)
+		return exitUsage
+	}
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if steps != nil && *steps < 1 {
+		fmt.Fprintln(os.Stderr, "-steps must be at least 1")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		switch action {
+		case "up":
+			applied, err := migrations.Up(ctx, db.DB, *to)
+			for _, m := range applied {
+				log.Infof("[main] Applied migration %04d_%s", m.Version, m.Name)
+			}
+			if err != nil {
+				log.Errorf("[main] Migration failed: %v", err)
+				return exitFailed
+			}
+			if len(applied) == 0 {
+				log.Info("[main] The schema is up to date")
+			}
+		case "down":
+			reverted, err := migrations.Down(ctx, db.DB, *steps)
+			for _, m := range reverted {
+				log.Infof("[main] Reverted migration %04d_%s", m.Version, m.Name)
+			}
+			if err != nil {
+				log.Errorf("[main] Migration failed: %v", err)
+				return exitFailed
+			}
+		case "status":
+			statuses, err := migrations.Statuses(ctx, db.DB)
+			if err != nil {
+				log.Errorf("[main] Failed to load migrations: %v", err)
+				return exitFailed
+			}
+			for _, status := range statuses {
+				applied := "pending"
+				if status.AppliedAt != nil {
+					applied = status.AppliedAt.UTC().Format(time.RFC3339)
+				}
+				fmt.Printf("%04d  %-32s %s\n", status.Version, status.Name, applied)
+			}
+		}
+		return exitOK
+	})
+}
+
+func replayGeyser(args []string) int {
+	fs := flag.NewFlagSet("replay-geyser", flag.ContinueOnError)
+	in := fs.String("in", "", "archive written by export -table transactions (required)")
+	addr := fs.String("addr", "localhost:10000", "address to listen on")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "-in is required")
+		return exitUsage
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Errorf("[main] Failed to open %s: %v", *in, err)
+		return exitFailed
+	}
+	results, err := geyser.LoadRecording(f)
+	f.Close()
+	if err != nil {
+		log.Errorf("[main] Failed to load recording: %v", err)
+		return exitFailed
+	}
+	server, err := geyser.NewReplayServer(results)
+	if err != nil {
+		log.Errorf("[main] Failed to prepare replay: %v", err)
+		return exitFailed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Errorf("[main] Failed to listen on %s: %v", *addr, err)
+		return exitFailed
+	}
+	log.Infof("[main] Replaying %d transactions over Yellowstone gRPC on http://%s", len(results), l.Addr())
+	if err := server.Serve(ctx, l); err != nil {
+		log.Errorf("[main] %v", err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+func recordRPC(args []string) int {
+	fs := flag.NewFlagSet("record-rpc", flag.ContinueOnError)
+	upstream := fs.String("upstream", "", "HTTP endpoint to record (required)")
+	wsUpstream := fs.String("ws-upstream", "", "WebSocket endpoint to record (required)")
+	addr := fs.String("addr", "localhost:8899", "address to listen on")
+	out := fs.String("out", "", "fixture directory to write on exit (required)")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *upstream == "" || *wsUpstream == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "-upstream, -ws-upstream and -out are required")
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Errorf("[main] Failed to listen on %s: %v", *addr, err)
+		return exitFailed
+	}
+	recorder := rpcreplay.NewRecorder(*upstream, *wsUpstream)
+	log.Infof("[main] Recording %s on http://%s until interrupted", *upstream, l.Addr())
+	if err := recorder.Serve(ctx, l); err != nil {
+		log.Errorf("[main] %v", err)
+		return exitFailed
+	}
+
+	fixtures := recorder.Fixtures()
+	if err := fixtures.Save(*out); err != nil {
+		log.Errorf("[main] %v", err)
+		return exitFailed
+	}
+	log.Infof("[main] Recorded %d calls and %d notifications into %s", len(fixtures.Calls), len(fixtures.Notifications), *out)
+	return exitOK
+}
+
+func replayRPC(args []string) int {
+	fs := flag.NewFlagSet("replay-rpc", flag.ContinueOnError)
+	dir := fs.String("fixtures", "", "fixture directory written by record-rpc (required)")
+	addr := fs.String("addr", "localhost:8899", "address to listen on")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *dir == "" {
+		fmt.Fprintln(os.Stderr, "-fixtures is required")
+		return exitUsage
+	}
+
+	fixtures, err := rpcreplay.Load(*dir)
+	if err != nil {
+		log.Errorf("[main] Failed to load fixtures: %v", err)
+		return exitFailed
+	}
+	server, err := rpcreplay.NewServer(fixtures)
+	if err != nil {
+		log.Errorf("[main] Failed to prepare replay: %v", err)
+		return exitFailed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Errorf("[main] Failed to listen on %s: %v", *addr, err)
+		return exitFailed
+	}
+	log.Infof("[main] Replaying %d calls and %d notifications on http://%s", len(fixtures.Calls), len(fixtures.Notifications), l.Addr())
+	if err := server.Serve(ctx, l); err != nil {
+		log.Errorf("[main] %v", err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+func snapshot(args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	golden := fs.String("golden", "", "golden JSON file (required)")
+	update := fs.Bool("update", false, "write the current state to the golden file instead")
+	if !parseFlags(fs, args) {
+		return exitUsage
+	}
+	if *golden == "" {
+		fmt.Fprintln(os.Stderr, "-golden is required")
+		return exitUsage
+	}
+
+	return withDB(func(ctx context.Context, db *storage.Gorm) int {
+		state, err := rpcreplay.Snapshot(ctx, db)
+		if err != nil {
+			log.Errorf("[main] %v", err)
+			return exitFailed
+		}
+		diff, err := rpcreplay.CompareGolden(*golden, state, *update)
+		if err != nil {
+			log.Errorf("[main] %v", err)
+			return exitFailed
+		}
+		if diff != "" {
+			fmt.Fprintf(os.Stderr, "state differs from %s at %s\n", *golden, diff)
+			return exitIssues
+		}
+		if *update {
+			log.Infof("[main] Wrote %s", *golden)
+		} else {
+			log.Infof("[main] State matches %s", *golden)
+		}
+		return exitOK
+	})
+}
+
+// printJSON writes v as indented JSON.
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
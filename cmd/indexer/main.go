@@ -1,171 +1,67 @@
 package main
 
 import (
-	"context"
-	"github.com/Tsisar/extended-log-go/log"
-	"github.com/Tsisar/solana-indexer/internal/config"
-	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
-	"github.com/Tsisar/solana-indexer/internal/core/healthchecker"
-	"github.com/Tsisar/solana-indexer/internal/core/listener"
-	"github.com/Tsisar/solana-indexer/internal/core/parser"
-	"github.com/Tsisar/solana-indexer/internal/storage"
-	"github.com/Tsisar/solana-indexer/internal/subgraph"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"net/http"
-	"sync/atomic"
-	"time"
+	"fmt"
+	"os"
 )
 
-var ready atomic.Bool
-var healthy atomic.Bool
-
-func main() {
-	log.Debug("[main] Starting Solana Indexer...")
-	healthy.Store(true)
-	appCtx := context.Background()
-	resumeFromLastSignature := config.App.ResumeFromLastSignature
-	if resumeFromLastSignature {
-		log.Info("Main] Resuming from last saved signature...")
-	}
-
-	gorm, err := storage.InitGorm()
-	if err != nil {
-		healthy.Store(false)
-		log.Fatalf("[main] Failed to init Gorm DB: %v", err)
-	}
-	defer gorm.Close()
+// Exit codes of the indexer binary.
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+	exitIssues = 3 // verify found inconsistencies, or snapshot differences
+)
 
-	// readiness and liveness probe server
-	go func() {
-		http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-			if ready.Load() {
-				w.WriteHeader(http.StatusOK)
-				w.Write([]byte("ok"))
-			} else {
-				http.Error(w, "not ready", http.StatusServiceUnavailable)
-			}
-		})
+// command is a subcommand of the indexer binary; run receives the arguments after its name.
+type command struct {
+	usage string
+	run   func(args []string) int
+}
 
-		http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-			if healthy.Load() {
-				w.WriteHeader(http.StatusOK)
-				w.Write([]byte("alive"))
-			} else {
-				http.Error(w, "not alive", http.StatusServiceUnavailable)
-			}
-		})
+var commands = map[string]command{
+	"run":              {"run the indexer service (default)", run},
+	"backfill":         {"fetch and parse a program's transactions in a slot range", backfill},
+	"reparse":          {"re-parse stored transactions and replay the subgraph", reparse},
+	"rebuild-subgraph": {"rebuild the subgraph from stored events", rebuildSubgraph},
+	"verify":           {"check the database for unprocessed or inconsistent data", verify},
+	"export":           {"export stored transactions or events as JSON lines", export},
+	"import":           {"import transactions exported by another deployment", importArchive},
+	"decode":           {"print the decoded events of a transaction without writing", decode},
+	"add-program":      {"index a program; a running indexer backfills it", addProgram},
+	"remove-program":   {"stop indexing a program, keeping its data", removeProgram},
+	"migrate":          {"apply, revert or list the schema migrations (up, down, status)", migrate},
+	"replay-geyser":    {"serve exported transactions as a local Yellowstone gRPC endpoint", replayGeyser},
+	"record-rpc":       {"proxy a Solana RPC endpoint and record its responses as fixtures", recordRPC},
+	"replay-rpc":       {"serve recorded fixtures as a local Solana RPC endpoint", replayRPC},
+	"snapshot":         {"compare the vaults, strategies and positions with a golden file", snapshot},
+}
 
-		log.Infof("[main] Health probe server listening on :8080")
-		if err := http.ListenAndServe(":8080", nil); err != nil {
-			log.Errorf("[main] Probe server error: %v", err)
-		}
-	}()
+var order = []string{"run", "backfill", "reparse", "rebuild-subgraph", "verify", "export", "import", "decode", "add-program", "remove-program", "migrate", "replay-geyser", "record-rpc", "replay-rpc", "snapshot"}
 
-	if config.App.Metrics.Enabled {
-		go func() {
-			addr := ":" + config.App.Metrics.Port
-			http.Handle("/metrics", promhttp.Handler())
-			log.Infof("[main] Metrics available on %s/metrics", addr)
-			if err := http.ListenAndServe(addr, nil); err != nil {
-				log.Errorf("[main] Prometheus server error: %v", err)
-			}
-		}()
+func main() {
+	name, args := "run", os.Args[1:]
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		name, args = args[0], args[1:]
 	}
-
-	if err := storage.InitCoreModels(appCtx, gorm, resumeFromLastSignature); err != nil {
-		healthy.Store(false)
-		log.Fatalf("[main] Failed to init DB: %v", err)
+	if name == "help" {
+		printUsage()
+		os.Exit(exitOK)
 	}
 
-	if err := storage.InitSubgraphModels(appCtx, gorm, resumeFromLastSignature); err != nil {
-		healthy.Store(false)
-		log.Fatalf("[main] Failed to init subgraph DB: %v", err)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		os.Exit(exitUsage)
 	}
+	os.Exit(cmd.run(args))
+}
 
-	go func() {
-		if err := healthchecker.Start(appCtx, gorm); err != nil {
-			subgraph.MapError(appCtx, gorm, err)
-			healthy.Store(false)
-			log.Fatalf("[main] DB health check failed: %v", err)
-		}
-	}()
-
-	for {
-		ctx, cancel := context.WithCancel(context.Background())
-		ready.Store(false)
-		errChan := make(chan error, 1)
-		wsReady := make(chan struct{}, 1)
-		fetchDone := make(chan struct{}, 1)
-		parseDone := make(chan struct{}, 1)
-		realtimeStream := make(chan string, 1000)
-
-		go func() {
-			log.Debug("[main] Starting WebSocket listener...")
-			if err := listener.Start(ctx, gorm, wsReady, realtimeStream, errChan); err != nil {
-				errChan <- err
-			}
-		}()
-
-		select {
-		case err := <-errChan:
-			subgraph.MapError(appCtx, gorm, err)
-			log.Errorf("[main] Listener error: %v", err)
-			cancel()
-			goto waitAndRestart
-		case <-wsReady:
-			log.Info("[main] WS ready, starting fetcher...")
-		case <-ctx.Done():
-			goto waitAndRestart
-		}
-
-		go func() {
-			if err := fetcher.Start(ctx, gorm, resumeFromLastSignature, fetchDone); err != nil {
-				errChan <- err
-			}
-		}()
-		select {
-		case err := <-errChan:
-			subgraph.MapError(appCtx, gorm, err)
-			log.Errorf("[main] Fetcher error: %v", err)
-			cancel()
-			goto waitAndRestart
-		case <-fetchDone:
-			log.Info("[main] Fetcher done, starting parser for historical data...")
-		case <-ctx.Done():
-			goto waitAndRestart
-		}
-
-		go func() {
-			if err := parser.Start(ctx, gorm, resumeFromLastSignature, parseDone, realtimeStream); err != nil {
-				errChan <- err
-			}
-		}()
-		select {
-		case err := <-errChan:
-			subgraph.MapError(appCtx, gorm, err)
-			log.Errorf("[main] Parser error: %v", err)
-			cancel()
-			goto waitAndRestart
-		case <-parseDone:
-			log.Info("[main] Historical parsing complete, run aggregator, entering streaming mode")
-			ready.Store(true)
-			subgraph.RunAggregator(appCtx, gorm)
-			resumeFromLastSignature = true
-		case <-ctx.Done():
-			goto waitAndRestart
-		}
-
-		select {
-		case err := <-errChan:
-			subgraph.MapError(appCtx, gorm, err)
-			log.Errorf("[main] Runtime error: %v", err)
-			cancel()
-		case <-ctx.Done():
-			cancel()
-		}
-
-	waitAndRestart:
-		log.Info("[main] Restarting full cycle in 5 seconds...")
-		time.Sleep(5 * time.Second)
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, name := range order {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", name, commands[name].usage)
 	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", os.Args[0])
 }
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/api/admin"
+	"github.com/Tsisar/solana-indexer/internal/api/graphql"
+	"github.com/Tsisar/solana-indexer/internal/api/rest"
+	"github.com/Tsisar/solana-indexer/internal/api/webhook"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/healthchecker"
+	"github.com/Tsisar/solana-indexer/internal/core/listener"
+	"github.com/Tsisar/solana-indexer/internal/core/parser"
+	"github.com/Tsisar/solana-indexer/internal/core/partitions"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/core/retention"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/subgraph"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+var ready atomic.Bool
+var healthy atomic.Bool
+
+// run starts the indexer service: APIs, listener, fetcher and parser, restarting the cycle on errors.
+func run(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	log.Debug("[main] Starting Solana Indexer...")
+	healthy.Store(true)
+
+	// SIGTERM stops the pipeline: no new notifications are accepted and queued work is drained
+	appCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	resumeFromLastSignature := config.App.ResumeFromLastSignature
+	if resumeFromLastSignature {
+		log.Info("Main] Resuming from last saved signature...")
+	}
+
+	gorm, err := storage.InitGorm()
+	if err != nil {
+		healthy.Store(false)
+		log.Fatalf("[main] Failed to init Gorm DB: %v", err)
+	}
+	defer gorm.Close()
+
+	// readiness and liveness probe server, which also serves the REST, admin and webhook APIs
+	go func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+			if ready.Load() {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("ok"))
+			} else {
+				http.Error(w, "not ready", http.StatusServiceUnavailable)
+			}
+		})
+
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			if healthy.Load() {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("alive"))
+			} else {
+				http.Error(w, "not alive", http.StatusServiceUnavailable)
+			}
+		})
+
+		rest.Register(mux, gorm)
+		admin.Register(mux, gorm, config.App.AdminToken)
+		webhook.Register(mux, gorm, config.App.WebhookToken)
+
+		log.Infof("[main] Health probe server listening on :8080")
+		if err := http.ListenAndServe(":8080", mux); err != nil {
+			log.Errorf("[main] Probe server error: %v", err)
+		}
+	}()
+
+	if config.App.Metrics.Enabled {
+		go func() {
+			addr := ":" + config.App.Metrics.Port
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			log.Infof("[main] Metrics available on %s/metrics", addr)
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.Errorf("[main] Prometheus server error: %v", err)
+			}
+		}()
+	}
+
+	if config.App.GraphQL.Enabled {
+		go func() {
+			handler, err := graphql.NewHandler(gorm.DB)
+			if err != nil {
+				log.Errorf("[main] Failed to build GraphQL schema: %v", err)
+				return
+			}
+			addr := ":" + config.App.GraphQL.Port
+			log.Infof("[main] GraphQL API listening on %s", addr)
+			if err := http.ListenAndServe(addr, handler); err != nil {
+				log.Errorf("[main] GraphQL server error: %v", err)
+			}
+		}()
+	}
+
+	if config.App.Postgres.AutoMigrate {
+		log.Warn("[main] Updating the subgraph tables with AutoMigrate, which is meant for development only")
+	}
+	applied, err := storage.MigrateSchema(appCtx, gorm)
+	if err != nil {
+		healthy.Store(false)
+		log.Fatalf("[main] Failed to migrate DB: %v", err)
+	}
+	for _, m := range applied {
+		log.Infof("[main] Applied migration %04d_%s", m.Version, m.Name)
+	}
+
+	if err := storage.InitCoreModels(appCtx, gorm, resumeFromLastSignature); err != nil {
+		healthy.Store(false)
+		log.Fatalf("[main] Failed to init DB: %v", err)
+	}
+
+	if err := storage.InitSubgraphModels(appCtx, gorm, resumeFromLastSignature); err != nil {
+		healthy.Store(false)
+		log.Fatalf("[main] Failed to init subgraph DB: %v", err)
+	}
+
+	if err := programs.Load(appCtx, gorm); err != nil {
+		healthy.Store(false)
+		log.Fatalf("[main] Failed to load programs: %v", err)
+	}
+
+	// Programs added at runtime are backfilled and merged while the others keep streaming
+	go programs.Watch(appCtx, gorm, func(program config.Program) {
+		go func() {
+			if err := parser.Backfill(appCtx, gorm, program); err != nil {
+				log.Errorf("[main] Backfill of program %s failed: %v", program.Address, err)
+			}
+		}()
+	})
+
+	go retention.Start(appCtx, gorm)
+	go partitions.Start(appCtx, gorm)
+
+	go func() {
+		if err := healthchecker.Start(appCtx, gorm); err != nil {
+			subgraph.MapError(appCtx, gorm, err)
+			healthy.Store(false)
+			log.Fatalf("[main] DB health check failed: %v", err)
+		}
+	}()
+
+	for {
+		ctx, cancel := context.WithCancel(appCtx)
+		ready.Store(false)
+		errChan := make(chan error, 1)
+		wsReady := make(chan struct{}, 1)
+		fetchDone := make(chan struct{}, 1)
+		parseDone := make(chan struct{}, 1)
+		realtime := make(chan struct{}, 1) // signals fetched transactions in the real-time queue
+
+		var stages sync.WaitGroup
+		stage := func(run func() error) {
+			stages.Add(1)
+			go func() {
+				defer stages.Done()
+				if err := run(); err != nil {
+					select {
+					case errChan <- err:
+					default:
+						log.Errorf("[main] %v", err)
+					}
+				}
+			}()
+		}
+
+		log.Debug("[main] Starting WebSocket listener...")
+		stage(func() error {
+			return listener.Start(ctx, gorm, wsReady, realtime, errChan)
+		})
+
+		select {
+		case err := <-errChan:
+			subgraph.MapError(appCtx, gorm, err)
+			log.Errorf("[main] Listener error: %v", err)
+			cancel()
+			goto waitAndRestart
+		case <-wsReady:
+			log.Info("[main] WS ready, starting fetcher...")
+		case <-ctx.Done():
+			goto waitAndRestart
+		}
+
+		stage(func() error {
+			return fetcher.Start(ctx, gorm, resumeFromLastSignature, fetchDone)
+		})
+		select {
+		case err := <-errChan:
+			subgraph.MapError(appCtx, gorm, err)
+			log.Errorf("[main] Fetcher error: %v", err)
+			cancel()
+			goto waitAndRestart
+		case <-fetchDone:
+			log.Info("[main] Fetcher done, starting parser for historical data...")
+		case <-ctx.Done():
+			goto waitAndRestart
+		}
+
+		stage(func() error {
+			return parser.Start(ctx, gorm, resumeFromLastSignature, parseDone, realtime)
+		})
+		select {
+		case err := <-errChan:
+			subgraph.MapError(appCtx, gorm, err)
+			log.Errorf("[main] Parser error: %v", err)
+			cancel()
+			goto waitAndRestart
+		case <-parseDone:
+			log.Info("[main] Historical parsing complete, run aggregator, entering streaming mode")
+			ready.Store(true)
+			subgraph.RunAggregator(appCtx, gorm)
+			resumeFromLastSignature = true
+		case <-ctx.Done():
+			goto waitAndRestart
+		}
+
+		select {
+		case err := <-errChan:
+			subgraph.MapError(appCtx, gorm, err)
+			log.Errorf("[main] Runtime error: %v", err)
+			cancel()
+		case <-ctx.Done():
+			cancel()
+		}
+
+	waitAndRestart:
+		cancel()
+		ready.Store(false)
+		awaitStages(&stages)
+		if appCtx.Err() != nil {
+			log.Info("[main] Shutdown complete")
+			return exitOK
+		}
+
+		log.Info("[main] Restarting full cycle in 5 seconds...")
+		select {
+		case <-time.After(5 * time.Second):
+		case <-appCtx.Done():
+			log.Info("[main] Shutdown complete")
+			return exitOK
+		}
+	}
+}
+
+// awaitStages waits for the stopped stages of a cycle to drain their queues. The stages bound
+// the drain by the shutdown timeout themselves, the margin only guards against a stuck one.
+func awaitStages(stages *sync.WaitGroup) {
+	done := make(chan struct{})
+	go func() {
+		stages.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(config.App.ShutdownTimeout + 10*time.Second):
+		log.Warn("[main] Stages did not stop within the shutdown timeout")
+	}
+}
@@ -0,0 +1,235 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/api"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/control"
+	"github.com/Tsisar/solana-indexer/internal/core/parser"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/subgraph"
+	"github.com/gagliardetto/solana-go"
+	"io"
+	"net/http"
+)
+
+type handler struct {
+	db    *storage.Gorm
+	token string
+}
+
+// Register adds the admin endpoints to the mux. They require `Authorization: Bearer <token>`;
+// with an empty token the admin API is disabled.
+func Register(mux *http.ServeMux, db *storage.Gorm, token string) {
+	if token == "" {
+		log.Warn("[admin] ADMIN_TOKEN is not set, admin API disabled")
+		return
+	}
+
+	h := &handler{db: db, token: token}
+	mux.HandleFunc("GET /admin/status", api.BearerAuth(h.token, h.status))
+	mux.HandleFunc("POST /admin/pause", api.BearerAuth(h.token, h.pause))
+	mux.HandleFunc("POST /admin/resume", api.BearerAuth(h.token, h.resume))
+	mux.HandleFunc("POST /admin/refetch", api.BearerAuth(h.token, h.refetch))
+	mux.HandleFunc("POST /admin/reparse", api.BearerAuth(h.token, h.reparse))
+	mux.HandleFunc("POST /admin/rebuild", api.BearerAuth(h.token, h.rebuild))
+	mux.HandleFunc("GET /admin/jobs/{id}", api.BearerAuth(h.token, h.job))
+	mux.HandleFunc("POST /admin/clear-error", api.BearerAuth(h.token, h.clearError))
+	mux.HandleFunc("GET /admin/programs", api.BearerAuth(h.token, h.listPrograms))
+	mux.HandleFunc("POST /admin/programs", api.BearerAuth(h.token, h.addProgram))
+	mux.HandleFunc("DELETE /admin/programs/{address}", api.BearerAuth(h.token, h.removeProgram))
+}
+
+// slotRange selects stored transactions by slot, both bounds inclusive.
+type slotRange struct {
+	FromSlot *uint64 `json:"from_slot"`
+	ToSlot   *uint64 `json:"to_slot"`
+}
+
+func (r slotRange) validate() error {
+	if r.FromSlot == nil || r.ToSlot == nil {
+		return errors.New("from_slot and to_slot are required")
+	}
+	if *r.FromSlot > *r.ToSlot {
+		return errors.New("from_slot must not be greater than to_slot")
+	}
+	return nil
+}
+
+type refetchRequest struct {
+	Signature string `json:"signature"`
+	Program   string `json:"program"`
+	slotRange
+}
+
+type rebuildRequest struct {
+	FromSlot *uint64 `json:"from_slot"`
+}
+
+// program mirrors config.Program for the API; omitted settings keep their defaults.
+type program struct {
+	Address            string `json:"address"`
+	IDL                string `json:"idl"`
+	StartSlot          uint64 `json:"start_slot"`
+	Commitment         string `json:"commitment"`
+	DecodeInstructions bool   `json:"decode_instructions"`
+	Mapper             string `json:"mapper"`
+	FetchMode          string `json:"fetch_mode"`
+}
+
+func (h *handler) status(w http.ResponseWriter, r *http.Request) {
+	paused, _ := control.State()
+	api.WriteJSON(w, http.StatusOK, map[string]bool{"paused": paused})
+}
+
+func (h *handler) pause(w http.ResponseWriter, r *http.Request) {
+	control.Pause()
+	api.WriteJSON(w, http.StatusOK, map[string]bool{"paused": true})
+}
+
+func (h *handler) resume(w http.ResponseWriter, r *http.Request) {
+	control.Resume()
+	api.WriteJSON(w, http.StatusOK, map[string]bool{"paused": false})
+}
+
+func (h *handler) refetch(w http.ResponseWriter, r *http.Request) {
+	var req refetchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if req.Signature != "" {
+		if _, err := solana.SignatureFromBase58(req.Signature); err != nil {
+			api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid signature: %w", err))
+			return
+		}
+		if req.Program != "" {
+			if _, err := solana.PublicKeyFromBase58(req.Program); err != nil {
+				api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid program: %w", err))
+				return
+			}
+		}
+		h.run(w, "refetch "+req.Signature, func(ctx context.Context) error {
+			return parser.Refetch(ctx, h.db, []string{req.Signature}, req.Program)
+		})
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("signature or slot range required: %w", err))
+		return
+	}
+	from, to := *req.FromSlot, *req.ToSlot
+	h.run(w, fmt.Sprintf("refetch slots %d-%d", from, to), func(ctx context.Context) error {
+		return parser.RefetchRange(ctx, h.db, from, to)
+	})
+}
+
+func (h *handler) reparse(w http.ResponseWriter, r *http.Request) {
+	var req slotRange
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	from, to := *req.FromSlot, *req.ToSlot
+	h.run(w, fmt.Sprintf("reparse slots %d-%d", from, to), func(ctx context.Context) error {
+		return parser.Reparse(ctx, h.db, from, to)
+	})
+}
+
+func (h *handler) rebuild(w http.ResponseWriter, r *http.Request) {
+	// The body is optional, without it the whole subgraph is rebuilt
+	var req rebuildRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if req.FromSlot == nil {
+		h.run(w, "rebuild subgraph", func(ctx context.Context) error {
+			return subgraph.Rebuild(ctx, h.db)
+		})
+		return
+	}
+	from := *req.FromSlot
+	h.run(w, fmt.Sprintf("rebuild subgraph from slot %d", from), func(ctx context.Context) error {
+		return subgraph.RebuildFrom(ctx, h.db, from)
+	})
+}
+
+func (h *handler) clearError(w http.ResponseWriter, r *http.Request) {
+	if err := subgraph.ClearError(r.Context(), h.db); err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, map[string]bool{"has_indexing_errors": false})
+}
+
+func (h *handler) listPrograms(w http.ResponseWriter, r *http.Request) {
+	items := make([]program, 0)
+	for _, p := range programs.All() {
+		items = append(items, program(p))
+	}
+	api.WriteJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// addProgram registers a program; the indexer then subscribes to it, backfills its
+// history in the background and merges it into the subgraph.
+func (h *handler) addProgram(w http.ResponseWriter, r *http.Request) {
+	req := program(config.NewProgram(""))
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	p := config.Program(req)
+	if err := p.Validate(); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := programs.Add(r.Context(), h.db, p); err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	api.WriteJSON(w, http.StatusAccepted, map[string]interface{}{"status": "added", "program": req})
+}
+
+func (h *handler) removeProgram(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	found, err := programs.Remove(r.Context(), h.db, address)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if !found {
+		api.WriteError(w, http.StatusNotFound, fmt.Errorf("program %s is not indexed", address))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, map[string]string{"status": "removed", "address": address})
+}
+
+func (h *handler) job(w http.ResponseWriter, r *http.Request) {
+	status, ok := control.Status(r.PathValue("id"))
+	if !ok {
+		api.WriteError(w, http.StatusNotFound, fmt.Errorf("job %s not found", r.PathValue("id")))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, status)
+}
+
+// run queues the operation for the parser loop, so it never interleaves with real-time parsing,
+// and answers with the job id; GET /admin/jobs/{id} reports its progress.
+func (h *handler) run(w http.ResponseWriter, name string, op func(ctx context.Context) error) {
+	log.Infof("[admin] Submitting %s", name)
+	id := control.Start(name, op)
+	api.WriteJSON(w, http.StatusAccepted, map[string]string{"status": control.JobQueued, "job": id, "name": name})
+}
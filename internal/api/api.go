@@ -0,0 +1,40 @@
+// Package api holds what the HTTP APIs of the indexer share.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"github.com/Tsisar/extended-log-go/log"
+	"net/http"
+	"strings"
+)
+
+// BearerAuth only passes requests carrying `Authorization: Bearer <token>` to next.
+func BearerAuth(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			WriteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// WriteJSON answers with v encoded as JSON.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("[api] failed to write response: %v", err)
+	}
+}
+
+// WriteError answers with `{"error": "..."}`; server errors are logged.
+func WriteError(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		log.Errorf("[api] %v", err)
+	}
+	WriteJSON(w, status, map[string]string{"error": err.Error()})
+}
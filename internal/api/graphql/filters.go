@@ -0,0 +1,140 @@
+package graphql
+
+import (
+	"fmt"
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
+)
+
+// filter is one `where` argument field, e.g. totalDebt_gt.
+type filter struct {
+	column string
+	op     string
+	input  gql.Input
+}
+
+// addFilters registers the Graph style filter suffixes supported by the column kind.
+func addFilters(filters map[string]filter, name string, c column) {
+	add := func(op string, input gql.Input) {
+		key := name
+		if op != "" {
+			key = name + "_" + op
+		}
+		filters[key] = filter{column: c.name, op: op, input: input}
+	}
+
+	list := gql.NewList(gql.NewNonNull(c.input))
+	add("", c.input)
+	add("not", c.input)
+	add("in", list)
+	add("not_in", list)
+	if c.kind == kindBool {
+		return
+	}
+
+	add("gt", c.input)
+	add("lt", c.input)
+	add("gte", c.input)
+	add("lte", c.input)
+	if c.kind != kindString {
+		return
+	}
+
+	add("contains", gql.String)
+	add("not_contains", gql.String)
+	add("starts_with", gql.String)
+	add("not_starts_with", gql.String)
+	add("ends_with", gql.String)
+	add("not_ends_with", gql.String)
+}
+
+// where converts a `where` argument into a SQL condition, nil if it has no conditions.
+func (e *entity) where(args map[string]interface{}) (clause.Expression, error) {
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var exprs []clause.Expression
+	for _, key := range keys {
+		value := args[key]
+		if key == "and" || key == "or" {
+			nested, _ := value.([]interface{})
+			var sub []clause.Expression
+			for _, n := range nested {
+				m, _ := n.(map[string]interface{})
+				expr, err := e.where(m)
+				if err != nil {
+					return nil, err
+				}
+				if expr != nil {
+					sub = append(sub, expr)
+				}
+			}
+			if len(sub) == 0 {
+				continue
+			}
+			if key == "and" {
+				exprs = append(exprs, clause.And(sub...))
+			} else {
+				exprs = append(exprs, clause.Or(sub...))
+			}
+			continue
+		}
+
+		f, ok := e.filters[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter %s on %s", key, e.name)
+		}
+		exprs = append(exprs, f.expression(value))
+	}
+	return clause.And(exprs...), nil
+}
+
+func (f filter) expression(value interface{}) clause.Expression {
+	col := clause.Column{Name: f.column}
+	switch f.op {
+	case "not":
+		return clause.Neq{Column: col, Value: value}
+	case "in":
+		return clause.IN{Column: col, Values: values(value)}
+	case "not_in":
+		return clause.Not(clause.IN{Column: col, Values: values(value)})
+	case "gt":
+		return clause.Gt{Column: col, Value: value}
+	case "lt":
+		return clause.Lt{Column: col, Value: value}
+	case "gte":
+		return clause.Gte{Column: col, Value: value}
+	case "lte":
+		return clause.Lte{Column: col, Value: value}
+	case "contains":
+		return clause.Like{Column: col, Value: "%" + escapeLike(value) + "%"}
+	case "not_contains":
+		return clause.Not(clause.Like{Column: col, Value: "%" + escapeLike(value) + "%"})
+	case "starts_with":
+		return clause.Like{Column: col, Value: escapeLike(value) + "%"}
+	case "not_starts_with":
+		return clause.Not(clause.Like{Column: col, Value: escapeLike(value) + "%"})
+	case "ends_with":
+		return clause.Like{Column: col, Value: "%" + escapeLike(value)}
+	case "not_ends_with":
+		return clause.Not(clause.Like{Column: col, Value: "%" + escapeLike(value)})
+	}
+	return clause.Eq{Column: col, Value: value}
+}
+
+func values(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value interface{}) string {
+	s, _ := value.(string)
+	return likeEscaper.Replace(s)
+}
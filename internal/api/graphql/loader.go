@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm/schema"
+	"sync"
+)
+
+// Relations are resolved in batches: each resolver adds its key to the pending batch of the
+// relation and returns a thunk. graphql-go calls the thunks once the whole level of the query is
+// resolved, so the first one loads the keys of every parent with a single query.
+
+type loaderKey struct{}
+
+// loader holds the pending batches of one request.
+type loader struct {
+	mu      sync.Mutex
+	pending map[string]*batch
+}
+
+// batch is a set of keys loaded with one query.
+type batch struct {
+	keys    []interface{}
+	seen    map[string]bool
+	load    func(keys []interface{}) (map[string]interface{}, error)
+	once    sync.Once
+	results map[string]interface{}
+	err     error
+}
+
+func newLoader() *loader {
+	return &loader{pending: make(map[string]*batch)}
+}
+
+// withLoader returns a context batching the relations of one request.
+func withLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, newLoader())
+}
+
+// enqueue adds the key to the pending batch with the name and returns a thunk resolving to its
+// result. Without a loader in the context the key is loaded on its own.
+func enqueue(ctx context.Context, name string, key interface{}, load func(keys []interface{}) (map[string]interface{}, error)) func() (interface{}, error) {
+	l, ok := ctx.Value(loaderKey{}).(*loader)
+	if !ok {
+		l = newLoader()
+	}
+	k := fmt.Sprint(key)
+
+	l.mu.Lock()
+	b, ok := l.pending[name]
+	if !ok {
+		b = &batch{seen: make(map[string]bool), load: load}
+		l.pending[name] = b
+	}
+	if !b.seen[k] {
+		b.seen[k] = true
+		b.keys = append(b.keys, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.once.Do(func() {
+			l.mu.Lock()
+			if l.pending[name] == b {
+				delete(l.pending, name)
+			}
+			l.mu.Unlock()
+			b.results, b.err = b.load(b.keys)
+		})
+		if b.err != nil {
+			return nil, b.err
+		}
+		return b.results[k], nil
+	}
+}
+
+// loadOne resolves to the entity whose column equals the key, or nil.
+func (b *builder) loadOne(ctx context.Context, e *entity, column *schema.Field, key interface{}, block *uint64) func() (interface{}, error) {
+	name := fmt.Sprintf("%s.%s@%s", e.name, column.DBName, blockName(block))
+	return enqueue(ctx, name, key, func(keys []interface{}) (map[string]interface{}, error) {
+		rows, err := b.findIn(ctx, e, column, keys, block)
+		if err != nil {
+			return nil, err
+		}
+		results := make(map[string]interface{}, len(rows))
+		for _, r := range rows {
+			results[fmt.Sprint(fieldValue(ctx, column, r.entity))] = r
+		}
+		return results, nil
+	})
+}
+
+// loadMany resolves to a page of the entities whose column equals the key; parents asking for
+// the same page share a batch.
+func (b *builder) loadMany(ctx context.Context, e *entity, column *schema.Field, key interface{}, args map[string]interface{}, block *uint64) func() (interface{}, error) {
+	name := fmt.Sprintf("%s.%s@%s%v", e.name, column.DBName, blockName(block), args)
+	return enqueue(ctx, name, key, func(keys []interface{}) (map[string]interface{}, error) {
+		grouped, err := b.findMany(ctx, e, column, keys, args, block)
+		if err != nil {
+			return nil, err
+		}
+		results := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			k := fmt.Sprint(key)
+			results[k] = append([]row{}, grouped[k]...)
+		}
+		return results, nil
+	})
+}
+
+func blockName(block *uint64) string {
+	if block == nil {
+		return "latest"
+	}
+	return fmt.Sprint(*block)
+}
@@ -0,0 +1,329 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+)
+
+const (
+	defaultFirst = 100
+	maxFirst     = 1000
+	maxSkip      = 5000
+)
+
+var blockType = gql.NewObject(gql.ObjectConfig{
+	Name: "_Block_",
+	Fields: gql.Fields{
+		"number":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"hash":      &gql.Field{Type: gql.String},
+		"timestamp": &gql.Field{Type: gql.Int},
+	},
+})
+
+// blockHeight is the `block` argument of the entity queries; block numbers are slots.
+var blockHeight = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "Block_height",
+	Fields: gql.InputObjectConfigFieldMap{
+		"number":     &gql.InputObjectFieldConfig{Type: gql.Int},
+		"number_gte": &gql.InputObjectFieldConfig{Type: gql.Int},
+	},
+})
+
+var metaType = gql.NewObject(gql.ObjectConfig{
+	Name: "_Meta_",
+	Fields: gql.Fields{
+		"block":             &gql.Field{Type: gql.NewNonNull(blockType)},
+		"deployment":        &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"hasIndexingErrors": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+	},
+})
+
+// row is a resolved entity with the block it was read at; its relations are read at the same
+// block. A nil block is the current state.
+type row struct {
+	entity interface{}
+	block  *uint64
+}
+
+func (b *builder) resolveMeta(p gql.ResolveParams) (interface{}, error) {
+	meta, err := b.meta(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	block := map[string]interface{}{"number": uint64(0)}
+	if meta.Block != nil {
+		block["number"] = meta.Block.Number
+		block["hash"] = meta.Block.Hash
+		block["timestamp"] = meta.Block.Timestamp
+	}
+	return map[string]interface{}{
+		"block":             block,
+		"deployment":        meta.Deployment,
+		"hasIndexingErrors": meta.HasIndexingErrors,
+	}, nil
+}
+
+func (b *builder) meta(ctx context.Context) (subgraph.Meta, error) {
+	var meta subgraph.Meta
+	err := b.db.WithContext(ctx).Preload("Block").First(&meta, 1).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return meta, fmt.Errorf("failed to load _meta: %w", err)
+	}
+	return meta, nil
+}
+
+// block returns the slot the `block` argument pins the query to, or nil for the current state.
+// Entities are read at an older slot from their versions, so `number` needs versioned entities.
+func (b *builder) block(ctx context.Context, args map[string]interface{}) (*uint64, error) {
+	arg, ok := args["block"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	number, pinned := arg["number"].(int)
+	gte, _ := arg["number_gte"].(int)
+	if number < 0 || gte < 0 {
+		return nil, errors.New("the `block` argument must not be negative")
+	}
+
+	meta, err := b.meta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var head uint64
+	if meta.Block != nil {
+		head = meta.Block.Number
+	}
+	for _, n := range []int{number, gte} {
+		if uint64(n) > head {
+			return nil, fmt.Errorf("the subgraph has only indexed up to block number %d and data for block number %d is therefore not yet available", head, n)
+		}
+	}
+	if !pinned {
+		return nil, nil
+	}
+	if !generic.VersioningEnabled() {
+		return nil, errors.New("querying at a block number requires versioned entities, enable versioned_entities")
+	}
+	slot := uint64(number)
+	return &slot, nil
+}
+
+func (b *builder) resolveSingle(e *entity) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		block, err := b.block(p.Context, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := b.findIn(p.Context, e, e.schema.PrioritizedPrimaryField, []interface{}{p.Args["id"]}, block)
+		if err != nil || len(rows) == 0 {
+			return nil, err
+		}
+		return rows[0], nil
+	}
+}
+
+func (b *builder) resolvePlural(e *entity) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		block, err := b.block(p.Context, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		return b.find(p.Context, e, p.Args, block)
+	}
+}
+
+func (b *builder) resolveBelongsTo(rel *schema.Relationship, target *entity) gql.FieldResolveFn {
+	ref := rel.References[0]
+	return func(p gql.ResolveParams) (interface{}, error) {
+		source := p.Source.(row)
+		key := fieldValue(p.Context, ref.ForeignKey, source.entity)
+		if key == nil || reflect.ValueOf(key).IsZero() {
+			return nil, nil
+		}
+		return b.loadOne(p.Context, target, ref.PrimaryKey, key, source.block), nil
+	}
+}
+
+func (b *builder) resolveHasOne(rel *schema.Relationship, target *entity) gql.FieldResolveFn {
+	ref := rel.References[0]
+	return func(p gql.ResolveParams) (interface{}, error) {
+		source := p.Source.(row)
+		key := fieldValue(p.Context, ref.PrimaryKey, source.entity)
+		return b.loadOne(p.Context, target, ref.ForeignKey, key, source.block), nil
+	}
+}
+
+func (b *builder) resolveHasMany(rel *schema.Relationship, target *entity) gql.FieldResolveFn {
+	ref := rel.References[0]
+	return func(p gql.ResolveParams) (interface{}, error) {
+		source := p.Source.(row)
+		key := fieldValue(p.Context, ref.PrimaryKey, source.entity)
+		return b.loadMany(p.Context, target, ref.ForeignKey, key, p.Args, source.block), nil
+	}
+}
+
+func resolveField(f *schema.Field) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return fieldValue(p.Context, f, p.Source.(row).entity), nil
+	}
+}
+
+// fieldValue reads a model field from a resolved entity, dereferencing pointers.
+func fieldValue(ctx context.Context, f *schema.Field, source interface{}) interface{} {
+	v := f.ReflectValueOf(ctx, reflect.ValueOf(source))
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// source returns the query reading the entities: their table, or the versions that were current
+// at the block.
+func (b *builder) source(ctx context.Context, e *entity, block *uint64) *gorm.DB {
+	db := b.db.WithContext(ctx)
+	if block == nil {
+		return db.Table(e.schema.Table)
+	}
+	return db.Table("(?) AS "+db.Statement.Quote(e.schema.Table), generic.VersionsAt(b.db, e.schema.Table, *block))
+}
+
+// findIn loads the entities whose column is one of the keys.
+func (b *builder) findIn(ctx context.Context, e *entity, column *schema.Field, keys []interface{}, block *uint64) ([]row, error) {
+	dest := reflect.New(reflect.SliceOf(reflect.PointerTo(e.typ)))
+	err := b.source(ctx, e, block).
+		Where(clause.IN{Column: clause.Column{Name: column.DBName}, Values: keys}).
+		Find(dest.Interface()).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", e.name, err)
+	}
+	return rows(dest.Elem(), block), nil
+}
+
+// find loads a page of entities matching the list arguments.
+func (b *builder) find(ctx context.Context, e *entity, args map[string]interface{}, block *uint64) ([]row, error) {
+	first, skip, err := page(args)
+	if err != nil {
+		return nil, err
+	}
+	q, err := e.filtered(b.source(ctx, e, block), args)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := reflect.New(reflect.SliceOf(reflect.PointerTo(e.typ)))
+	if err := q.Order(e.order(args)).Limit(first).Offset(skip).Find(dest.Interface()).Error; err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", e.plural, err)
+	}
+	return rows(dest.Elem(), block), nil
+}
+
+// findMany loads a page of entities matching the list arguments for each key the column equals,
+// with one query ranking the entities of each key.
+func (b *builder) findMany(ctx context.Context, e *entity, column *schema.Field, keys []interface{}, args map[string]interface{}, block *uint64) (map[string][]row, error) {
+	first, skip, err := page(args)
+	if err != nil {
+		return nil, err
+	}
+	q, err := e.filtered(b.source(ctx, e, block), args)
+	if err != nil {
+		return nil, err
+	}
+	order := e.order(args)
+	ranked := q.Where(clause.IN{Column: clause.Column{Name: column.DBName}, Values: keys}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY ? ORDER BY ?) AS _rank", clause.Column{Name: column.DBName}, orderList(order))
+
+	dest := reflect.New(reflect.SliceOf(reflect.PointerTo(e.typ)))
+	err = b.db.WithContext(ctx).
+		Table("(?) AS "+b.db.Statement.Quote(e.schema.Table), ranked).
+		Where("_rank > ? AND _rank <= ?", skip, skip+first).
+		Order(order).
+		Find(dest.Interface()).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", e.plural, err)
+	}
+
+	grouped := make(map[string][]row, len(keys))
+	for _, r := range rows(dest.Elem(), block) {
+		k := fmt.Sprint(fieldValue(ctx, column, r.entity))
+		grouped[k] = append(grouped[k], r)
+	}
+	return grouped, nil
+}
+
+// filtered applies the `where` argument to the query.
+func (e *entity) filtered(q *gorm.DB, args map[string]interface{}) (*gorm.DB, error) {
+	where, ok := args["where"].(map[string]interface{})
+	if !ok {
+		return q, nil
+	}
+	expr, err := e.where(where)
+	if err != nil {
+		return nil, err
+	}
+	if expr != nil {
+		q = q.Where(expr)
+	}
+	return q, nil
+}
+
+// order returns the ordering of the list arguments, ending with the primary key so pages are stable.
+func (e *entity) order(args map[string]interface{}) clause.OrderBy {
+	desc := args["orderDirection"] == "desc"
+	var order clause.OrderBy
+	if name, ok := args["orderBy"].(string); ok {
+		if c, ok := e.columns[name]; ok {
+			order.Columns = append(order.Columns, clause.OrderByColumn{Column: clause.Column{Name: c.name}, Desc: desc})
+		}
+	}
+	order.Columns = append(order.Columns, clause.OrderByColumn{Column: clause.Column{Name: e.schema.PrioritizedPrimaryField.DBName}, Desc: desc})
+	return order
+}
+
+// orderList renders the ordering without the ORDER BY keyword, for use in a window function.
+func orderList(order clause.OrderBy) clause.Expr {
+	list := make([]string, len(order.Columns))
+	vars := make([]interface{}, len(order.Columns))
+	for i, c := range order.Columns {
+		list[i] = "?"
+		if c.Desc {
+			list[i] += " DESC"
+		}
+		vars[i] = c.Column
+	}
+	return clause.Expr{SQL: strings.Join(list, ","), Vars: vars}
+}
+
+// page returns the validated `first` and `skip` arguments.
+func page(args map[string]interface{}) (int, int, error) {
+	first, _ := args["first"].(int)
+	skip, _ := args["skip"].(int)
+	if first < 0 || first > maxFirst {
+		return 0, 0, fmt.Errorf("the `first` argument must be between 0 and %d", maxFirst)
+	}
+	if skip < 0 || skip > maxSkip {
+		return 0, 0, fmt.Errorf("the `skip` argument must be between 0 and %d", maxSkip)
+	}
+	return first, skip, nil
+}
+
+// rows wraps the loaded entities with the block they were read at.
+func rows(entities reflect.Value, block *uint64) []row {
+	out := make([]row, entities.Len())
+	for i := range out {
+		out[i] = row{entity: entities.Index(i).Interface(), block: block}
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fixtureDB returns a dry run database answering every query with the fixture rows of the
+// queried table, and the SQL of the queries it was sent.
+func fixtureDB(t *testing.T, fixtures map[string][]interface{}) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Before("gorm:preload").Register("test:fixtures", func(tx *gorm.DB) {
+		stmt := tx.Statement
+		if stmt.Schema == nil {
+			// a subquery being built
+			return
+		}
+		queries = append(queries, tx.Dialector.Explain(stmt.SQL.String(), stmt.Vars...))
+
+		dest := stmt.ReflectValue
+		rows := fixtures[stmt.Schema.Table]
+		switch dest.Kind() {
+		case reflect.Slice:
+			for _, r := range rows {
+				v := reflect.ValueOf(r)
+				if dest.Type().Elem().Kind() != reflect.Ptr {
+					v = v.Elem()
+				}
+				dest.Set(reflect.Append(dest, v))
+			}
+		case reflect.Struct:
+			if len(rows) > 0 {
+				dest.Set(reflect.ValueOf(rows[0]).Elem())
+			}
+		}
+		tx.RowsAffected = int64(len(rows))
+	})
+	if err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+	return db, &queries
+}
+
+func execute(t *testing.T, db *gorm.DB, query string) *gql.Result {
+	s, err := NewSchema(db)
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+	return gql.Do(gql.Params{Schema: s, RequestString: query, Context: withLoader(context.Background())})
+}
+
+func vaultFixtures() map[string][]interface{} {
+	return map[string][]interface{}{
+		"_meta":       {&subgraph.Meta{ID: 1, BlockID: 1}},
+		"_block_info": {&subgraph.BlockInfo{ID: 1, Number: 10}},
+		"vaults":      {&subgraph.Vault{ID: "v1", TokenID: "t1"}, &subgraph.Vault{ID: "v2", TokenID: "t2"}},
+		"tokens":      {&subgraph.Token{ID: "t1", Symbol: "USDC"}, &subgraph.Token{ID: "t2", Symbol: "SOL"}},
+		"strategies": {
+			&subgraph.Strategy{ID: "s1", VaultID: "v1"},
+			&subgraph.Strategy{ID: "s2", VaultID: "v1"},
+			&subgraph.Strategy{ID: "s3", VaultID: "v2"},
+		},
+	}
+}
+
+func TestRelationsAreBatched(t *testing.T) {
+	db, queries := fixtureDB(t, vaultFixtures())
+
+	res := execute(t, db, `{ vaults { id token { symbol } strategies { id vault { id } } } }`)
+	if len(res.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", res.Errors)
+	}
+
+	// vaults, their tokens, their strategies and the vaults of the strategies
+	if len(*queries) != 4 {
+		t.Fatalf("expected 4 queries, got %d: %v", len(*queries), *queries)
+	}
+
+	vaults := res.Data.(map[string]interface{})["vaults"].([]interface{})
+	expected := map[string]struct {
+		symbol     string
+		strategies []string
+	}{
+		"v1": {"USDC", []string{"s1", "s2"}},
+		"v2": {"SOL", []string{"s3"}},
+	}
+	for _, v := range vaults {
+		vault := v.(map[string]interface{})
+		id := vault["id"].(string)
+		if symbol := vault["token"].(map[string]interface{})["symbol"]; symbol != expected[id].symbol {
+			t.Errorf("expected token %s of vault %s, got %v", expected[id].symbol, id, symbol)
+		}
+		var strategies []string
+		for _, s := range vault["strategies"].([]interface{}) {
+			strategy := s.(map[string]interface{})
+			if parent := strategy["vault"].(map[string]interface{})["id"]; parent != id {
+				t.Errorf("expected vault %s of strategy %v, got %v", id, strategy["id"], parent)
+			}
+			strategies = append(strategies, strategy["id"].(string))
+		}
+		if !reflect.DeepEqual(strategies, expected[id].strategies) {
+			t.Errorf("expected strategies %v of vault %s, got %v", expected[id].strategies, id, strategies)
+		}
+	}
+}
+
+func TestBlockReadsVersions(t *testing.T) {
+	defer generic.EnableVersioning(generic.VersioningEnabled())
+	generic.EnableVersioning(true)
+	db, queries := fixtureDB(t, vaultFixtures())
+
+	res := execute(t, db, `{ vaults(block: {number: 5}) { id strategies { id } } }`)
+	if len(res.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", res.Errors)
+	}
+
+	for _, q := range *queries {
+		if strings.Contains(q, "ORDER BY ORDER BY") {
+			t.Errorf("expected a valid ordering, got %s", q)
+		}
+	}
+	var versioned int
+	for _, q := range *queries {
+		if strings.Contains(q, "entity_versions") {
+			versioned++
+			if !strings.Contains(q, "slot_from <= 5") {
+				t.Errorf("expected the versions at slot 5, got %s", q)
+			}
+		}
+	}
+	if versioned != 2 {
+		t.Errorf("expected the vaults and strategies to be read from their versions, got %v", *queries)
+	}
+
+	res = execute(t, db, `{ vault(id: "v1", block: {number: 11}) { id } }`)
+	if len(res.Errors) == 0 || !strings.Contains(res.Errors[0].Message, "only indexed up to block number 10") {
+		t.Errorf("expected an error for a block after the head, got %v", res.Errors)
+	}
+}
+
+func TestBlockRequiresVersioning(t *testing.T) {
+	defer generic.EnableVersioning(generic.VersioningEnabled())
+	generic.EnableVersioning(false)
+	db, _ := fixtureDB(t, vaultFixtures())
+
+	res := execute(t, db, `{ vaults(block: {number: 5}) { id } }`)
+	if len(res.Errors) == 0 || !strings.Contains(res.Errors[0].Message, "requires versioned entities") {
+		t.Errorf("expected an error without versioned entities, got %v", res.Errors)
+	}
+
+	res = execute(t, db, `{ vaults(block: {number_gte: 5}) { id } }`)
+	if len(res.Errors) > 0 {
+		t.Errorf("unexpected errors for number_gte: %v", res.Errors)
+	}
+}
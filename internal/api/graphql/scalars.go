@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"math/big"
+	"strconv"
+)
+
+// BigInt is serialized as a decimal string, like in Graph subgraphs.
+var BigInt = gql.NewScalar(gql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "Arbitrary precision integer, serialized as a string",
+	Serialize:   serializeBigInt,
+	ParseValue:  parseNumber,
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch v := value.(type) {
+		case *ast.StringValue:
+			return parseNumber(v.Value)
+		case *ast.IntValue:
+			return v.Value
+		}
+		return nil
+	},
+})
+
+// BigDecimal is serialized as a decimal string, like in Graph subgraphs.
+var BigDecimal = gql.NewScalar(gql.ScalarConfig{
+	Name:        "BigDecimal",
+	Description: "Arbitrary precision decimal, serialized as a string",
+	Serialize:   serializeBigDecimal,
+	ParseValue:  parseNumber,
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch v := value.(type) {
+		case *ast.StringValue:
+			return parseNumber(v.Value)
+		case *ast.IntValue:
+			return v.Value
+		case *ast.FloatValue:
+			return v.Value
+		}
+		return nil
+	},
+})
+
+func serializeBigInt(value interface{}) interface{} {
+	switch v := value.(type) {
+	case types.BigInt:
+		if v.Int == nil {
+			return nil
+		}
+		return v.Int.String()
+	case *types.BigInt:
+		if v == nil || v.Int == nil {
+			return nil
+		}
+		return v.Int.String()
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case string:
+		return v
+	}
+	return nil
+}
+
+func serializeBigDecimal(value interface{}) interface{} {
+	switch v := value.(type) {
+	case types.BigDecimal:
+		if v.Float == nil {
+			return nil
+		}
+		return v.String()
+	case *types.BigDecimal:
+		if v == nil || v.Float == nil {
+			return nil
+		}
+		return v.String()
+	case string:
+		return v
+	}
+	return nil
+}
+
+// parseNumber accepts numbers given as strings or JSON numbers and returns their
+// decimal string, which Postgres casts to numeric when comparing.
+func parseNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if _, ok := new(big.Float).SetString(v); !ok {
+			return nil
+		}
+		return v
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return nil
+}
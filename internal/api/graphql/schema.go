@@ -0,0 +1,344 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// entities are the subgraph models exposed by the API; _meta is served separately.
+var entities = []interface{}{
+	&subgraph.Account{},
+	&subgraph.AccountVaultPosition{},
+	&subgraph.Accountant{},
+	&subgraph.DeployFunds{},
+	&subgraph.Deposit{},
+	&subgraph.DTFReport{},
+	&subgraph.FreeFunds{},
+	&subgraph.ShareToken{},
+	&subgraph.ShareTokenData{},
+	&subgraph.ShareTokenTransfer{},
+	&subgraph.Strategy{},
+	&subgraph.StrategyDayData{},
+	&subgraph.StrategyHistoricalApr{},
+	&subgraph.StrategyReport{},
+	&subgraph.StrategyReportEvent{},
+	&subgraph.StrategyReportResult{},
+	&subgraph.Token{},
+	&subgraph.TokenAccount{},
+	&subgraph.TokenBurn{},
+	&subgraph.TokenMint{},
+	&subgraph.TokenStats{},
+	&subgraph.TokenWallet{},
+	&subgraph.Vault{},
+	&subgraph.VaultDayData{},
+	&subgraph.VaultHistoricalApr{},
+	&subgraph.VaultHourData{},
+	&subgraph.Withdrawal{},
+	&subgraph.WithdrawalRequest{},
+}
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindBool
+)
+
+// column is a filterable and sortable GraphQL field backed by a table column.
+type column struct {
+	name  string
+	kind  kind
+	input gql.Input
+}
+
+// entity holds the GraphQL types derived from one GORM model.
+type entity struct {
+	name    string
+	single  string
+	plural  string
+	typ     reflect.Type
+	schema  *schema.Schema
+	columns map[string]column
+	filters map[string]filter
+	object  *gql.Object
+	filter  *gql.InputObject
+	orderBy *gql.Enum
+}
+
+type builder struct {
+	db       *gorm.DB
+	entities []*entity
+	byType   map[reflect.Type]*entity
+}
+
+var orderDirection = gql.NewEnum(gql.EnumConfig{
+	Name: "OrderDirection",
+	Values: gql.EnumValueConfigMap{
+		"asc":  &gql.EnumValueConfig{Value: "asc"},
+		"desc": &gql.EnumValueConfig{Value: "desc"},
+	},
+})
+
+// NewSchema derives a Graph compatible GraphQL schema from the subgraph models.
+func NewSchema(db *gorm.DB) (gql.Schema, error) {
+	b := &builder{db: db, byType: make(map[reflect.Type]*entity)}
+	cache := &sync.Map{}
+
+	for _, model := range entities {
+		s, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return gql.Schema{}, fmt.Errorf("[graphql] failed to parse model %T: %w", model, err)
+		}
+		name := s.ModelType.Name()
+		e := &entity{
+			name:    name,
+			single:  fieldName(name),
+			plural:  pluralName(fieldName(name)),
+			typ:     s.ModelType,
+			schema:  s,
+			columns: make(map[string]column),
+			filters: make(map[string]filter),
+		}
+		b.entities = append(b.entities, e)
+		b.byType[s.ModelType] = e
+	}
+
+	for _, e := range b.entities {
+		b.buildColumns(e)
+		b.buildTypes(e)
+	}
+
+	query := gql.Fields{
+		"_meta": &gql.Field{Type: metaType, Resolve: b.resolveMeta},
+	}
+	for _, e := range b.entities {
+		query[e.single] = &gql.Field{
+			Type: e.object,
+			Args: gql.FieldConfigArgument{
+				"id":    &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				"block": &gql.ArgumentConfig{Type: blockHeight},
+			},
+			Resolve: b.resolveSingle(e),
+		}
+		args := e.listArgs()
+		args["block"] = &gql.ArgumentConfig{Type: blockHeight}
+		query[e.plural] = &gql.Field{
+			Type:    gql.NewNonNull(gql.NewList(gql.NewNonNull(e.object))),
+			Args:    args,
+			Resolve: b.resolvePlural(e),
+		}
+	}
+
+	s, err := gql.NewSchema(gql.SchemaConfig{
+		Query: gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: query}),
+	})
+	if err != nil {
+		return gql.Schema{}, fmt.Errorf("[graphql] failed to build schema: %w", err)
+	}
+	return s, nil
+}
+
+// buildColumns collects the scalar columns of the model and the foreign keys of its
+// belongs-to relations, which are exposed under the relation name like in Graph subgraphs.
+func (b *builder) buildColumns(e *entity) {
+	foreignKeys := b.foreignKeys(e)
+	for _, f := range e.schema.Fields {
+		if f.DBName == "" || foreignKeys[f.Name] {
+			continue
+		}
+		input, k, ok := scalarInput(f)
+		if !ok {
+			continue
+		}
+		e.columns[fieldName(f.Name)] = column{name: f.DBName, kind: k, input: input}
+	}
+	for _, rel := range e.schema.Relationships.BelongsTo {
+		if b.byType[rel.FieldSchema.ModelType] == nil || len(rel.References) == 0 {
+			continue
+		}
+		e.columns[fieldName(rel.Name)] = column{name: rel.References[0].ForeignKey.DBName, kind: kindString, input: gql.String}
+	}
+
+	for name, c := range e.columns {
+		addFilters(e.filters, name, c)
+	}
+}
+
+// buildTypes creates the object, filter and order types; fields are thunks so entities
+// can reference each other.
+func (b *builder) buildTypes(e *entity) {
+	names := make([]string, 0, len(e.columns))
+	for name := range e.columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := gql.EnumValueConfigMap{}
+	for _, name := range names {
+		values[name] = &gql.EnumValueConfig{Value: name}
+	}
+	e.orderBy = gql.NewEnum(gql.EnumConfig{Name: e.name + "_orderBy", Values: values})
+
+	e.filter = gql.NewInputObject(gql.InputObjectConfig{
+		Name: e.name + "_filter",
+		Fields: gql.InputObjectConfigFieldMapThunk(func() gql.InputObjectConfigFieldMap {
+			fields := gql.InputObjectConfigFieldMap{
+				"and": &gql.InputObjectFieldConfig{Type: gql.NewList(e.filter)},
+				"or":  &gql.InputObjectFieldConfig{Type: gql.NewList(e.filter)},
+			}
+			for name, f := range e.filters {
+				fields[name] = &gql.InputObjectFieldConfig{Type: f.input}
+			}
+			return fields
+		}),
+	})
+
+	e.object = gql.NewObject(gql.ObjectConfig{
+		Name:   e.name,
+		Fields: gql.FieldsThunk(func() gql.Fields { return b.objectFields(e) }),
+	})
+}
+
+func (b *builder) objectFields(e *entity) gql.Fields {
+	fields := gql.Fields{}
+	foreignKeys := b.foreignKeys(e)
+	for _, f := range e.schema.Fields {
+		if f.DBName == "" || foreignKeys[f.Name] {
+			continue
+		}
+		output, ok := scalarOutput(f)
+		if !ok {
+			continue
+		}
+		fields[fieldName(f.Name)] = &gql.Field{Type: output, Resolve: resolveField(f)}
+	}
+
+	for _, rel := range e.schema.Relationships.Relations {
+		target := b.byType[rel.FieldSchema.ModelType]
+		if target == nil || len(rel.References) == 0 {
+			continue
+		}
+		switch rel.Type {
+		case schema.BelongsTo:
+			fields[fieldName(rel.Name)] = &gql.Field{Type: target.object, Resolve: b.resolveBelongsTo(rel, target)}
+		case schema.HasOne:
+			fields[fieldName(rel.Name)] = &gql.Field{Type: target.object, Resolve: b.resolveHasOne(rel, target)}
+		case schema.HasMany:
+			fields[fieldName(rel.Name)] = &gql.Field{
+				Type:    gql.NewNonNull(gql.NewList(gql.NewNonNull(target.object))),
+				Args:    target.listArgs(),
+				Resolve: b.resolveHasMany(rel, target),
+			}
+		}
+	}
+	return fields
+}
+
+// foreignKeys returns the names of the struct fields holding belongs-to foreign keys.
+func (b *builder) foreignKeys(e *entity) map[string]bool {
+	keys := make(map[string]bool)
+	for _, rel := range e.schema.Relationships.BelongsTo {
+		if b.byType[rel.FieldSchema.ModelType] == nil {
+			continue
+		}
+		for _, ref := range rel.References {
+			keys[ref.ForeignKey.Name] = true
+		}
+	}
+	return keys
+}
+
+func (e *entity) listArgs() gql.FieldConfigArgument {
+	return gql.FieldConfigArgument{
+		"first":          &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultFirst},
+		"skip":           &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 0},
+		"orderBy":        &gql.ArgumentConfig{Type: e.orderBy},
+		"orderDirection": &gql.ArgumentConfig{Type: orderDirection},
+		"where":          &gql.ArgumentConfig{Type: e.filter},
+	}
+}
+
+var (
+	bigIntType     = reflect.TypeOf(types.BigInt{})
+	bigDecimalType = reflect.TypeOf(types.BigDecimal{})
+)
+
+func scalarOutput(f *schema.Field) (gql.Output, bool) {
+	if f.PrimaryKey && f.DBName == "id" {
+		return gql.NewNonNull(gql.ID), true
+	}
+	input, _, ok := scalarInput(f)
+	if !ok {
+		return nil, false
+	}
+	return input.(gql.Output), true
+}
+
+func scalarInput(f *schema.Field) (gql.Input, kind, bool) {
+	t := f.FieldType
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if f.PrimaryKey && f.DBName == "id" {
+		return gql.ID, kindString, true
+	}
+	switch {
+	case t == bigIntType:
+		return BigInt, kindNumber, true
+	case t == bigDecimalType:
+		return BigDecimal, kindNumber, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return gql.String, kindString, true
+	case reflect.Bool:
+		return gql.Boolean, kindBool, true
+	case reflect.Int, reflect.Int32, reflect.Uint, reflect.Uint32:
+		return gql.Int, kindNumber, true
+	case reflect.Int64, reflect.Uint64:
+		return BigInt, kindNumber, true
+	}
+	return nil, 0, false
+}
+
+// fieldName converts a Go field name to a GraphQL field name, e.g. ID -> id, TotalDebt -> totalDebt.
+func fieldName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	switch {
+	case upper == len(runes):
+		return strings.ToLower(name)
+	case upper > 1:
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// pluralName follows graph-node: names that are already plural get a _collection suffix.
+func pluralName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"):
+		return name + "_collection"
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"), strings.HasSuffix(name, "x"):
+		return name + "es"
+	}
+	return name + "s"
+}
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// NewHandler returns an HTTP handler serving GraphQL queries over the subgraph tables.
+// It answers on any path, so existing /subgraphs/name/<name> URLs keep working.
+func NewHandler(db *gorm.DB) (http.Handler, error) {
+	s, err := NewSchema(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+		var req request
+		switch r.Method {
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodGet:
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+			if v := r.URL.Query().Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					http.Error(w, fmt.Sprintf("invalid variables: %v", err), http.StatusBadRequest)
+					return
+				}
+			}
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		result := gql.Do(gql.Params{
+			Schema:         s,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        withLoader(r.Context()),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}), nil
+}
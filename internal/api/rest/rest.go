@@ -0,0 +1,264 @@
+package rest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/api"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type handler struct {
+	db *storage.Gorm
+}
+
+// Register adds the read-only endpoints over the core tables to the mux.
+func Register(mux *http.ServeMux, db *storage.Gorm) {
+	h := &handler{db: db}
+	mux.HandleFunc("GET /api/transactions", h.listTransactions)
+	mux.HandleFunc("GET /api/transactions/{signature}", h.getTransaction)
+	mux.HandleFunc("GET /api/transactions/{signature}/raw", h.getRawTransaction)
+	mux.HandleFunc("GET /api/events", h.listEvents)
+	mux.HandleFunc("GET /api/programs", h.listPrograms)
+}
+
+type transaction struct {
+	Signature string    `json:"signature"`
+	Slot      uint64    `json:"slot"`
+	BlockTime int64     `json:"block_time"`
+	Parsed    bool      `json:"parsed"`
+	Finalized bool      `json:"finalized"`
+	Programs  []string  `json:"programs,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type event struct {
+	TransactionSignature string          `json:"transaction_signature"`
+	LogIndex             int             `json:"log_index"`
+	Slot                 uint64          `json:"slot"`
+	BlockTime            int64           `json:"block_time"`
+	Name                 string          `json:"name"`
+	Kind                 string          `json:"kind"`
+	Mapped               bool            `json:"mapped"`
+	Data                 json.RawMessage `json:"data"`
+}
+
+type page struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+func (h *handler) listTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		api.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := storage.TransactionFilter{ProgramID: q.Get("program"), Limit: limit}
+	if filter.FromSlot, err = parseOptionalUint(q.Get("from_slot")); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid from_slot: %w", err))
+		return
+	}
+	if filter.ToSlot, err = parseOptionalUint(q.Get("to_slot")); err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid to_slot: %w", err))
+		return
+	}
+	if v := q.Get("parsed"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid parsed: %w", err))
+			return
+		}
+		filter.Parsed = &parsed
+	}
+	if v := q.Get("cursor"); v != "" {
+		parts, err := decodeCursor(v, 2)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+		slot, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid cursor"))
+			return
+		}
+		filter.After = &storage.TransactionCursor{Slot: slot, Signature: parts[1]}
+	}
+
+	txs, err := h.db.ListTransactions(r.Context(), filter)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]transaction, 0, len(txs))
+	for _, tx := range txs {
+		items = append(items, newTransaction(tx))
+	}
+	result := page{Items: items}
+	if len(txs) == limit {
+		last := txs[len(txs)-1]
+		result.NextCursor = encodeCursor(strconv.FormatUint(last.Slot, 10), last.Signature)
+	}
+	api.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *handler) getTransaction(w http.ResponseWriter, r *http.Request) {
+	tx, err := h.db.GetTransaction(r.Context(), r.PathValue("signature"))
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if tx == nil {
+		api.WriteError(w, http.StatusNotFound, fmt.Errorf("transaction not found"))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, newTransaction(*tx))
+}
+
+func (h *handler) getRawTransaction(w http.ResponseWriter, r *http.Request) {
+	raw, err := h.db.GetRawTransaction(r.Context(), r.PathValue("signature"))
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(raw) == 0 {
+		api.WriteError(w, http.StatusNotFound, fmt.Errorf("raw transaction not found"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(raw)
+}
+
+func (h *handler) listEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		api.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := storage.EventFilter{Signature: q.Get("signature"), Name: q.Get("name"), Limit: limit}
+	if v := q.Get("cursor"); v != "" {
+		parts, err := decodeCursor(v, 3)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+		slot, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid cursor"))
+			return
+		}
+		logIndex, err := strconv.Atoi(parts[2])
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid cursor"))
+			return
+		}
+		filter.After = &storage.EventCursor{Slot: slot, Signature: parts[1], LogIndex: logIndex}
+	}
+
+	events, err := h.db.ListEvents(r.Context(), filter)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	items := make([]event, 0, len(events))
+	for _, ev := range events {
+		data := json.RawMessage(ev.JsonEv)
+		if len(data) == 0 {
+			data = json.RawMessage("null")
+		}
+		items = append(items, event{
+			TransactionSignature: ev.TransactionSignature,
+			LogIndex:             ev.LogIndex,
+			Slot:                 ev.Slot,
+			BlockTime:            ev.BlockTime,
+			Name:                 ev.Name,
+			Kind:                 ev.Kind,
+			Mapped:               ev.Mapped,
+			Data:                 data,
+		})
+	}
+	result := page{Items: items}
+	if len(events) == limit {
+		last := events[len(events)-1]
+		result.NextCursor = encodeCursor(strconv.FormatUint(last.Slot, 10), last.TransactionSignature, strconv.Itoa(last.LogIndex))
+	}
+	api.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *handler) listPrograms(w http.ResponseWriter, r *http.Request) {
+	progress, err := h.db.GetProgramProgress(r.Context())
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, page{Items: progress})
+}
+
+func newTransaction(tx core.Transaction) transaction {
+	t := transaction{
+		Signature: tx.Signature,
+		Slot:      tx.Slot,
+		BlockTime: tx.BlockTime,
+		Parsed:    tx.Parsed,
+		Finalized: tx.Finalized,
+		CreatedAt: tx.CreatedAt,
+	}
+	for _, p := range tx.Programs {
+		t.Programs = append(t.Programs, p.ID)
+	}
+	return t
+}
+
+func parseLimit(v string) (int, error) {
+	if v == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > maxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+	return limit, nil
+}
+
+func parseOptionalUint(v string) (*uint64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// encodeCursor joins the position of the last returned row into an opaque token.
+func encodeCursor(parts ...string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(parts, ":")))
+}
+
+func decodeCursor(cursor string, n int) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), ":", n)
+	if len(parts) != n {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return parts, nil
+}
@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/api"
+	"github.com/Tsisar/solana-indexer/internal/core/parser"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"io"
+	"net/http"
+)
+
+// maxBody bounds the size of one webhook request.
+const maxBody = 32 << 20
+
+type handler struct {
+	db    *storage.Gorm
+	token string
+}
+
+// Register adds the webhook endpoint to the mux. It requires `Authorization: Bearer <token>`;
+// with an empty token the webhook is disabled.
+func Register(mux *http.ServeMux, db *storage.Gorm, token string) {
+	if token == "" {
+		log.Warn("[webhook] WEBHOOK_TOKEN is not set, webhook ingestion disabled")
+		return
+	}
+
+	h := &handler{db: db, token: token}
+	mux.HandleFunc("POST /webhook/transactions", api.BearerAuth(h.token, h.ingest))
+}
+
+// rejection explains why a payload of a request was not queued.
+type rejection struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+type ingestResponse struct {
+	Accepted   int         `json:"accepted"`
+	Duplicates int         `json:"duplicates"`
+	Rejected   []rejection `json:"rejected"`
+}
+
+// ingest queues pushed transactions for parsing. The body is one getTransaction result or an array
+// of them. Payloads are handled one by one, so a rejected one doesn't fail the others.
+func (h *handler) ingest(w http.ResponseWriter, r *http.Request) {
+	payloads, err := decodeBody(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		api.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	resp := ingestResponse{Rejected: make([]rejection, 0)}
+	for i, payload := range payloads {
+		duplicate, err := h.store(r.Context(), payload)
+		switch {
+		case errors.As(err, new(*invalidPayload)):
+			resp.Rejected = append(resp.Rejected, rejection{Index: i, Error: err.Error()})
+			monitoring.WebhookTransactionsTotal.WithLabelValues("rejected").Inc()
+		case err != nil:
+			api.WriteError(w, http.StatusInternalServerError, err)
+			return
+		case duplicate:
+			resp.Duplicates++
+			monitoring.WebhookTransactionsTotal.WithLabelValues("duplicate").Inc()
+		default:
+			resp.Accepted++
+			monitoring.WebhookTransactionsTotal.WithLabelValues("accepted").Inc()
+		}
+	}
+	if resp.Accepted > 0 {
+		parser.Wake()
+	}
+
+	status := http.StatusOK
+	if len(payloads) > 0 && len(resp.Rejected) == len(payloads) {
+		status = http.StatusUnprocessableEntity
+	}
+	api.WriteJSON(w, status, resp)
+}
+
+// invalidPayload is a payload that can't be ingested; retrying it won't help.
+type invalidPayload struct {
+	reason string
+}
+
+func (e *invalidPayload) Error() string {
+	return e.reason
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &invalidPayload{reason: fmt.Sprintf(format, args...)}
+}
+
+// store validates a payload and queues it as pending with its raw transaction. A transaction
+// already stored with its payload, e.g. delivered by the subscription and fetched, is a duplicate;
+// one queued without a payload yet gets it, so it isn't fetched anymore.
+func (h *handler) store(ctx context.Context, payload json.RawMessage) (bool, error) {
+	var result rpc.GetTransactionResult
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return false, invalid("invalid transaction: %v", err)
+	}
+	if result.Transaction == nil || result.Meta == nil {
+		return false, invalid("transaction and meta are required")
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil || tx == nil {
+		return false, invalid("unsupported transaction encoding: %v", err)
+	}
+	if len(tx.Signatures) == 0 {
+		return false, invalid("transaction has no signature")
+	}
+	signature := tx.Signatures[0].String()
+
+	mentioned := mentionedPrograms(tx, &result)
+	if len(mentioned) == 0 {
+		return false, invalid("transaction %s mentions no indexed program", signature)
+	}
+
+	existing, err := h.db.GetRawTransaction(ctx, signature)
+	if err != nil {
+		return false, fmt.Errorf("[webhook] %w", err)
+	}
+	if len(existing) > 0 {
+		log.Debugf("[webhook] Transaction %s is already stored", signature)
+		return true, nil
+	}
+
+	// The payload is stored as the listener stores fetched transactions
+	raw, err := json.Marshal(&result)
+	if err != nil {
+		return false, fmt.Errorf("[webhook] failed to marshal transaction %s: %w", signature, err)
+	}
+	blockTime := utils.BlockTime(result.BlockTime)
+	transaction := core.Transaction{
+		Signature: signature,
+		Slot:      result.Slot,
+		BlockTime: blockTime,
+		JsonTx:    raw,
+		Pending:   true,
+	}
+	for _, program := range mentioned {
+		if err := h.db.SaveTransaction(ctx, &transaction, program); err != nil {
+			return false, fmt.Errorf("[webhook] failed to save transaction %s: %w", signature, err)
+		}
+	}
+	if _, err := h.db.FillTransactionRaw(ctx, signature, result.Slot, blockTime, raw); err != nil {
+		return false, fmt.Errorf("[webhook] failed to save transaction %s: %w", signature, err)
+	}
+	log.Infof("[webhook] Queued transaction %s", signature)
+	return false, nil
+}
+
+// mentionedPrograms returns the indexed programs among the accounts of a transaction,
+// including those loaded from lookup tables, like a logs subscription would match them.
+func mentionedPrograms(tx *solana.Transaction, result *rpc.GetTransactionResult) []string {
+	accounts := append(solana.PublicKeySlice{}, tx.Message.AccountKeys...)
+	accounts = append(accounts, result.Meta.LoadedAddresses.Writable...)
+	accounts = append(accounts, result.Meta.LoadedAddresses.ReadOnly...)
+
+	var mentioned []string
+	for _, account := range accounts {
+		if _, ok := programs.Get(account.String()); ok && !utils.Contains(mentioned, account.String()) {
+			mentioned = append(mentioned, account.String())
+		}
+	}
+	return mentioned
+}
+
+// decodeBody returns the payloads of a request holding one object or an array.
+func decodeBody(body io.Reader) ([]json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		var payloads []json.RawMessage
+		if err := json.Unmarshal(raw, &payloads); err != nil {
+			return nil, err
+		}
+		return payloads, nil
+	}
+	return []json.RawMessage{raw}, nil
+}
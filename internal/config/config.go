@@ -1,35 +1,110 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"io"
 	"os"
+	"time"
 )
 
+// defaultConfigFile is loaded when CONFIG_FILE is not set and the file exists.
+const defaultConfigFile = "config.yaml"
+
 var App *config
 
 type config struct {
-	ResumeFromLastSignature bool
-	RPCEndpoint             string
-	RPCWSEndpoint           string
-	Version                 string
-	Programs                []string
-	Tokens   []string
-	Postgres postgres
-	Metrics  metrics
+	ResumeFromLastSignature bool          `yaml:"resume_from_last_signature"`
+	Version                 string        `yaml:"version"`
+	Programs                []Program     `yaml:"programs"`
+	ReconcileInterval       time.Duration `yaml:"reconcile_interval"` // how often confirmed transactions are checked against finalized slots, 0 disables
+	ShutdownTimeout         time.Duration `yaml:"shutdown_timeout"`   // time to drain queued work on SIGTERM
+	VersionedEntities       bool          `yaml:"versioned_entities"` // required by the reconciliation to revert dropped transactions
+	AdminToken              string        `yaml:"admin_token"`
+	WebhookToken            string        `yaml:"webhook_token"` // enables POST /webhook/transactions
+	Tokens                  []string      `yaml:"tokens"`
+	RPC                     rpcPool       `yaml:"rpc"`
+	Source                  source        `yaml:"source"`
+	RawStorage              rawStorage    `yaml:"raw_storage"`
+	Retry                   retry         `yaml:"retry"`
+	Postgres                postgres      `yaml:"postgres"`
+	Metrics                 metrics       `yaml:"metrics"`
+	Aggregator              aggregator    `yaml:"aggregator"`
+	GraphQL                 graphQL       `yaml:"graphql"`
+}
+
+type rpcPool struct {
+	Endpoints        []string      `yaml:"endpoints"` // tried in order, failing over on transport errors
+	WSEndpoint       string        `yaml:"ws_endpoint"`
+	Timeout          time.Duration `yaml:"timeout"`
+	KeepaliveTimeout time.Duration `yaml:"keepalive_timeout"` // reconnect after this long without a slot notification, 0 disables
+	WatchdogInterval time.Duration `yaml:"watchdog_interval"` // how often subscriptions are checked against polling, 0 disables
+}
+
+// Sources of real-time transactions.
+const (
+	SourceWebSocket   = "websocket"   // logs subscriptions of the RPC, transactions are fetched by signature
+	SourceYellowstone = "yellowstone" // Yellowstone gRPC, delivering full transactions
+)
+
+type source struct {
+	Kind        string      `yaml:"kind"`
+	Yellowstone yellowstone `yaml:"yellowstone"`
+}
+
+type yellowstone struct {
+	Endpoint string `yaml:"endpoint"` // http:// for plaintext HTTP/2, https:// for TLS
+	Token    string `yaml:"token"`    // sent as x-token
+}
+
+// Formats the raw transactions are stored in.
+const (
+	RawJSONB     = "jsonb"     // the json_tx column
+	RawZstd      = "zstd"      // zstd compressed in the raw_zstd column
+	RawDirectory = "directory" // zstd compressed files in a local object store directory
+)
+
+type rawStorage struct {
+	Format        string        `yaml:"format"`
+	Directory     string        `yaml:"directory"`      // object store of the directory format
+	Retention     time.Duration `yaml:"retention"`      // raw payloads of parsed, finalized transactions older than this are pruned, 0 keeps them
+	PruneInterval time.Duration `yaml:"prune_interval"` // how often the retention is applied
+}
+
+type retry struct {
+	Attempts int           `yaml:"attempts"`
+	Delay    time.Duration `yaml:"delay"`
 }
 
 type postgres struct {
-	User     string
-	Password string
-	DB       string
-	Host     string
-	Port     string
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	DB              string        `yaml:"db"`
+	Host            string        `yaml:"host"`
+	Port            string        `yaml:"port"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	// AutoMigrate updates the subgraph tables from the models after the SQL migrations; for development only
+	AutoMigrate bool `yaml:"auto_migrate"`
 }
 
 type metrics struct {
-	Enabled bool
-	Port    string
+	Enabled bool   `yaml:"enabled"`
+	Port    string `yaml:"port"`
+}
+
+type graphQL struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    string `yaml:"port"`
+}
+
+type aggregator struct {
+	Intervals []string      `yaml:"intervals"`
+	Interval  time.Duration `yaml:"interval"`
 }
 
 func init() {
@@ -46,24 +121,137 @@ func init() {
 	}
 }
 
+// loadConfig builds the configuration from the defaults, the optional config file
+// and the environment, in increasing order of precedence, and validates it.
 func loadConfig() (*config, error) {
+	cfg := defaults()
+
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		if _, err := os.Stat(defaultConfigFile); err == nil {
+			path = defaultConfigFile
+		}
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+		log.Infof("Config file %s successfully loaded", path)
+	}
+
+	cfg.applyEnv()
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func defaults() *config {
 	return &config{
-		ResumeFromLastSignature: getBool("RESUME_FROM_LAST_SIGNATURE", false),
-		RPCEndpoint:             getString("RPC_ENDPOINT", "https://api.mainnet-beta.solana.com"),
-		RPCWSEndpoint:           getString("RPC_WS_ENDPOINT", "wss://api.mainnet-beta.solana.com"),
-		Version:                 getString("VERSION", "v.unknown"),
-		Programs:                getStringSlice("PROGRAMS", []string{}),
-		Tokens:                  getStringSlice("TOKENS", []string{}),
+		Version:           "v.unknown",
+		ReconcileInterval: time.Minute,
+		ShutdownTimeout:   25 * time.Second, // within the default 30s termination grace period of Kubernetes
+		VersionedEntities: true,
+		RPC: rpcPool{
+			Endpoints:        []string{"https://api.mainnet-beta.solana.com"},
+			WSEndpoint:       "wss://api.mainnet-beta.solana.com",
+			Timeout:          5 * time.Minute,
+			KeepaliveTimeout: 30 * time.Second,
+			WatchdogInterval: time.Minute,
+		},
+		Source: source{
+			Kind: SourceWebSocket,
+		},
+		RawStorage: rawStorage{
+			Format:        RawJSONB,
+			Directory:     "data/raw",
+			PruneInterval: time.Hour,
+		},
+		Retry: retry{
+			Attempts: 5,
+			Delay:    time.Second,
+		},
 		Postgres: postgres{
-			User:     getString("POSTGRES_USER", "postgres"),
-			Password: getString("POSTGRES_PASSWORD", "postgres"),
-			DB:       getString("POSTGRES_DB", "indexer"),
-			Host:     getString("POSTGRES_HOST", "localhost"),
-			Port:     getString("POSTGRES_PORT", "5432"),
+			User:     "postgres",
+			Password: "postgres",
+			DB:       "indexer",
+			Host:     "localhost",
+			Port:     "5432",
 		},
 		Metrics: metrics{
-			Enabled: getBool("METRICS_ENABLED", true),
-			Port:    getString("METRICS_PORT", "8040"),
+			Enabled: true,
+			Port:    "8040",
 		},
-	}, nil
+		Aggregator: aggregator{
+			Intervals: []string{"hour", "day"},
+			Interval:  time.Hour,
+		},
+		GraphQL: graphQL{
+			Enabled: true,
+			Port:    "8000",
+		},
+	}
+}
+
+// loadFile decodes a YAML config file over the current values; unknown keys are rejected.
+func (c *config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides the loaded values with the environment variables that are set.
+func (c *config) applyEnv() {
+	c.ResumeFromLastSignature = getBool("RESUME_FROM_LAST_SIGNATURE", c.ResumeFromLastSignature)
+	c.RPC.Endpoints = getStringSlice("RPC_ENDPOINT", c.RPC.Endpoints)
+	c.RPC.WSEndpoint = getString("RPC_WS_ENDPOINT", c.RPC.WSEndpoint)
+	c.RPC.Timeout = getDuration("RPC_TIMEOUT", c.RPC.Timeout)
+	c.RPC.KeepaliveTimeout = getDuration("RPC_KEEPALIVE_TIMEOUT", c.RPC.KeepaliveTimeout)
+	c.RPC.WatchdogInterval = getDuration("RPC_WATCHDOG_INTERVAL", c.RPC.WatchdogInterval)
+	c.Source.Kind = getString("SOURCE", c.Source.Kind)
+	c.Source.Yellowstone.Endpoint = getString("YELLOWSTONE_ENDPOINT", c.Source.Yellowstone.Endpoint)
+	c.Source.Yellowstone.Token = getString("YELLOWSTONE_TOKEN", c.Source.Yellowstone.Token)
+	c.RawStorage.Format = getString("RAW_STORAGE_FORMAT", c.RawStorage.Format)
+	c.RawStorage.Directory = getString("RAW_STORAGE_DIRECTORY", c.RawStorage.Directory)
+	c.RawStorage.Retention = getDuration("RAW_RETENTION", c.RawStorage.Retention)
+	c.RawStorage.PruneInterval = getDuration("RAW_PRUNE_INTERVAL", c.RawStorage.PruneInterval)
+	c.Retry.Attempts = getInt("RETRY_ATTEMPTS", c.Retry.Attempts)
+	c.Retry.Delay = getDuration("RETRY_DELAY", c.Retry.Delay)
+	c.Version = getString("VERSION", c.Version)
+	c.Programs = programsFromEnv("PROGRAMS", c.Programs)
+	c.ReconcileInterval = getDuration("RECONCILE_INTERVAL", c.ReconcileInterval)
+	c.ShutdownTimeout = getDuration("SHUTDOWN_TIMEOUT", c.ShutdownTimeout)
+	c.VersionedEntities = getBool("VERSIONED_ENTITIES", c.VersionedEntities)
+	c.AdminToken = getString("ADMIN_TOKEN", c.AdminToken)
+	c.WebhookToken = getString("WEBHOOK_TOKEN", c.WebhookToken)
+	c.Tokens = getStringSlice("TOKENS", c.Tokens)
+
+	c.Postgres.User = getString("POSTGRES_USER", c.Postgres.User)
+	c.Postgres.Password = getString("POSTGRES_PASSWORD", c.Postgres.Password)
+	c.Postgres.DB = getString("POSTGRES_DB", c.Postgres.DB)
+	c.Postgres.Host = getString("POSTGRES_HOST", c.Postgres.Host)
+	c.Postgres.Port = getString("POSTGRES_PORT", c.Postgres.Port)
+	c.Postgres.MaxOpenConns = getInt("POSTGRES_MAX_OPEN_CONNS", c.Postgres.MaxOpenConns)
+	c.Postgres.MaxIdleConns = getInt("POSTGRES_MAX_IDLE_CONNS", c.Postgres.MaxIdleConns)
+	c.Postgres.ConnMaxLifetime = getDuration("POSTGRES_CONN_MAX_LIFETIME", c.Postgres.ConnMaxLifetime)
+	c.Postgres.AutoMigrate = getBool("POSTGRES_AUTO_MIGRATE", c.Postgres.AutoMigrate)
+
+	c.Metrics.Enabled = getBool("METRICS_ENABLED", c.Metrics.Enabled)
+	c.Metrics.Port = getString("METRICS_PORT", c.Metrics.Port)
+
+	c.Aggregator.Intervals = getStringSlice("AGGREGATOR_INTERVALS", c.Aggregator.Intervals)
+	c.Aggregator.Interval = getDuration("AGGREGATOR_INTERVAL", c.Aggregator.Interval)
+
+	c.GraphQL.Enabled = getBool("GRAPHQL_ENABLED", c.GraphQL.Enabled)
+	c.GraphQL.Port = getString("GRAPHQL_PORT", c.GraphQL.Port)
 }
@@ -106,7 +106,20 @@ func getBool(key string, defaultValue bool) bool {
 	}
 }
 
-func getDuration(key string, defaultValue int) time.Duration {
-	interval := getInt64(key, defaultValue)
-	return time.Duration(interval) * time.Minute
+// getDuration reads a duration given either in whole minutes, e.g. "5", or in Go notation, e.g. "90s".
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		log.Warnf("%s not found in environment variables, using default: %s", key, defaultValue)
+		return defaultValue
+	}
+	if minutes, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(minutes) * time.Minute
+	}
+	if parsedValue, err := time.ParseDuration(value); err == nil {
+		return parsedValue
+	} else {
+		log.Warnf("Failed to parse %s, using default: %s: %v", key, defaultValue, err)
+		return defaultValue
+	}
 }
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"github.com/Tsisar/extended-log-go/log"
+	"os"
+	"strings"
+)
+
+// Mappers turning decoded events into subgraph entities.
+const (
+	MapperVaults = "vaults" // vault, strategy and accountant entities
+	MapperNone   = "none"   // events are stored but not mapped
+)
+
+var mappers = []string{MapperVaults, MapperNone}
+
+// Commitment levels a program can be indexed at.
+const (
+	CommitmentConfirmed = "confirmed"
+	CommitmentFinalized = "finalized"
+)
+
+// Ways the history of a program is fetched.
+const (
+	FetchSignatures = "signatures" // getSignaturesForAddress, then getTransaction per signature
+	FetchBlocks     = "blocks"     // getBlock per slot, keeping the transactions that mention the program
+)
+
+// Program holds the indexing settings of one on-chain program.
+type Program struct {
+	Address            string `yaml:"address"`
+	IDL                string `yaml:"idl"`        // IDL the event decoders were generated from, checked against them
+	StartSlot          uint64 `yaml:"start_slot"` // history before this slot is not fetched
+	Commitment         string `yaml:"commitment"`
+	DecodeInstructions bool   `yaml:"decode_instructions"` // decode SPL token instructions of its transactions
+	Mapper             string `yaml:"mapper"`
+	FetchMode          string `yaml:"fetch_mode"`
+}
+
+// NewProgram returns a program with the default settings.
+func NewProgram(address string) Program {
+	return Program{
+		Address:            address,
+		Commitment:         CommitmentConfirmed,
+		DecodeInstructions: true,
+		Mapper:             MapperVaults,
+		FetchMode:          FetchSignatures,
+	}
+}
+
+// UnmarshalYAML fills the settings missing from the file with their defaults.
+func (p *Program) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Program
+	decoded := plain(NewProgram(""))
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+	*p = Program(decoded)
+	return nil
+}
+
+// programsFromEnv replaces the configured programs with the comma separated addresses of the
+// environment variable. Addresses already configured keep their settings.
+func programsFromEnv(key string, defaultValue []Program) []Program {
+	value := os.Getenv(key)
+	if value == "" {
+		log.Warnf("%s not found in environment variables, using %d configured programs", key, len(defaultValue))
+		return defaultValue
+	}
+
+	programs := make([]Program, 0)
+	for _, address := range strings.Split(value, ",") {
+		address = strings.TrimSpace(address)
+		program := NewProgram(address)
+		for _, p := range defaultValue {
+			if p.Address == address {
+				program = p
+			}
+		}
+		programs = append(programs, program)
+	}
+	return programs
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/core/events"
+	"github.com/gagliardetto/solana-go"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// validIntervals are the date_trunc units the aggregator supports.
+var validIntervals = []string{"minute", "hour", "day", "week", "month"}
+
+// validate checks the whole configuration and reports every problem found at once.
+func (c *config) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(len(c.RPC.Endpoints) > 0, "rpc.endpoints: at least one endpoint is required")
+	for _, endpoint := range c.RPC.Endpoints {
+		check(validURL(endpoint, "http", "https"), "rpc.endpoints: invalid HTTP endpoint %q", endpoint)
+	}
+	check(validURL(c.RPC.WSEndpoint, "ws", "wss"), "rpc.ws_endpoint: invalid WebSocket endpoint %q", c.RPC.WSEndpoint)
+	check(c.RPC.Timeout > 0, "rpc.timeout: must be positive")
+	check(c.RPC.KeepaliveTimeout >= 0, "rpc.keepalive_timeout: must not be negative")
+	check(c.RPC.WatchdogInterval >= 0, "rpc.watchdog_interval: must not be negative")
+	check(c.Source.Kind == SourceWebSocket || c.Source.Kind == SourceYellowstone, "source.kind: unsupported source %q", c.Source.Kind)
+	check(c.Source.Kind != SourceYellowstone || validURL(c.Source.Yellowstone.Endpoint, "http", "https"),
+		"source.yellowstone.endpoint: invalid gRPC endpoint %q", c.Source.Yellowstone.Endpoint)
+	check(slices.Contains([]string{RawJSONB, RawZstd, RawDirectory}, c.RawStorage.Format),
+		"raw_storage.format: unsupported format %q", c.RawStorage.Format)
+	check(c.RawStorage.Format != RawDirectory || c.RawStorage.Directory != "", "raw_storage.directory: is required for the directory format")
+	check(c.RawStorage.Retention >= 0, "raw_storage.retention: must not be negative")
+	check(c.RawStorage.PruneInterval > 0, "raw_storage.prune_interval: must be positive")
+	check(c.Retry.Attempts > 0, "retry.attempts: must be at least 1")
+	check(c.Retry.Delay >= 0, "retry.delay: must not be negative")
+	check(c.ReconcileInterval >= 0, "reconcile_interval: must not be negative")
+	check(c.ReconcileInterval == 0 || c.VersionedEntities,
+		"reconcile_interval: reverting dropped transactions needs versioned_entities, enable it or set 0 to disable the reconciliation")
+	check(c.ShutdownTimeout > 0, "shutdown_timeout: must be positive")
+
+	check(c.Postgres.Host != "", "postgres.host: is required")
+	check(c.Postgres.DB != "", "postgres.db: is required")
+	check(c.Postgres.User != "", "postgres.user: is required")
+	check(validPort(c.Postgres.Port), "postgres.port: invalid port %q", c.Postgres.Port)
+	check(c.Postgres.MaxOpenConns >= 0, "postgres.max_open_conns: must not be negative")
+	check(c.Postgres.MaxIdleConns >= 0, "postgres.max_idle_conns: must not be negative")
+	check(c.Postgres.ConnMaxLifetime >= 0, "postgres.conn_max_lifetime: must not be negative")
+
+	check(!c.Metrics.Enabled || validPort(c.Metrics.Port), "metrics.port: invalid port %q", c.Metrics.Port)
+	check(!c.GraphQL.Enabled || validPort(c.GraphQL.Port), "graphql.port: invalid port %q", c.GraphQL.Port)
+	check(c.Aggregator.Interval > 0, "aggregator.interval: must be positive")
+	for _, interval := range c.Aggregator.Intervals {
+		check(slices.Contains(validIntervals, interval), "aggregator.intervals: unsupported interval %q", interval)
+	}
+
+	for _, token := range c.Tokens {
+		_, err := solana.PublicKeyFromBase58(token)
+		check(err == nil, "tokens: invalid address %q: %v", token, err)
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range c.Programs {
+		check(!seen[p.Address], "programs[%d].address: duplicate program %s", i, p.Address)
+		seen[p.Address] = true
+		if err := p.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("programs[%d].%w", i, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return nil
+}
+
+// Validate checks the settings of a program, e.g. one added at runtime.
+func (p Program) Validate() error {
+	if _, err := solana.PublicKeyFromBase58(p.Address); err != nil {
+		return fmt.Errorf("address: invalid address %q: %w", p.Address, err)
+	}
+	if p.Commitment != CommitmentConfirmed && p.Commitment != CommitmentFinalized {
+		return fmt.Errorf("commitment: unsupported commitment %q", p.Commitment)
+	}
+	if !slices.Contains(mappers, p.Mapper) {
+		return fmt.Errorf("mapper: unknown mapper %q", p.Mapper)
+	}
+	if p.FetchMode != FetchSignatures && p.FetchMode != FetchBlocks {
+		return fmt.Errorf("fetch_mode: unsupported fetch mode %q", p.FetchMode)
+	}
+	if p.IDL != "" {
+		if err := validIDL(p.IDL, p.Address); err != nil {
+			return fmt.Errorf("idl: %w", err)
+		}
+	}
+	return nil
+}
+
+func validURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Host != "" && slices.Contains(schemes, u.Scheme)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
+
+// validIDL checks that the IDL file is the IDL of the program and that the parser decodes every
+// event it declares, with the same discriminator.
+func validIDL(path, address string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var idl struct {
+		Address string `json:"address"`
+		Events  []struct {
+			Name          string `json:"name"`
+			Discriminator []int  `json:"discriminator"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(data, &idl); err != nil {
+		return fmt.Errorf("invalid IDL %s: %w", path, err)
+	}
+	if idl.Address != "" && idl.Address != address {
+		return fmt.Errorf("IDL %s is of program %s", path, idl.Address)
+	}
+
+	for _, ev := range idl.Events {
+		// IDLs before Anchor 0.30 leave the discriminator out
+		disc := events.Discriminator(ev.Name)
+		if len(ev.Discriminator) > 0 {
+			if len(ev.Discriminator) != len(disc) {
+				return fmt.Errorf("IDL %s: invalid discriminator of event %s", path, ev.Name)
+			}
+			for i, b := range ev.Discriminator {
+				disc[i] = byte(b)
+			}
+		}
+		if name, ok := events.Discriminators[disc]; !ok || name != ev.Name {
+			return fmt.Errorf("IDL %s: event %s is not decoded by the parser", path, ev.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidIDL(t *testing.T) {
+	for path, address := range map[string]string{
+		"../../idl/tokenized_vault.json": "2qgFiQqjsbqQJkeJAhU56FidSw6j7kWVboZYKaPFmMxE",
+		"../../idl/strategy.json":        "7KuUusuUJBTjSVaiA8cojAhKER9ydu94QZcMW65SZRNR",
+		"../../idl/accountant.json":      "7sj4iadCbbBawmewg8yLYfUg5rZ3NLv6DHfzQF2q4WuS",
+	} {
+		if err := validIDL(path, address); err != nil {
+			t.Errorf("expected %s to be valid, got %v", path, err)
+		}
+	}
+
+	if err := validIDL("../../idl/strategy.json", "2qgFiQqjsbqQJkeJAhU56FidSw6j7kWVboZYKaPFmMxE"); err == nil {
+		t.Error("expected an error for the IDL of another program")
+	}
+
+	dir := t.TempDir()
+	for name, idl := range map[string]string{
+		"unknown event":         `{"events":[{"name":"UnknownEvent"}]}`,
+		"wrong discriminator":   `{"events":[{"name":"VaultInitEvent","discriminator":[1,2,3,4,5,6,7,8]}]}`,
+		"invalid discriminator": `{"events":[{"name":"VaultInitEvent","discriminator":[1,2]}]}`,
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "_")+".json")
+		if err := os.WriteFile(path, []byte(idl), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := validIDL(path, ""); err == nil {
+			t.Errorf("expected an error for the IDL with the %s", name)
+		}
+	}
+
+	path := filepath.Join(dir, "legacy.json")
+	if err := os.WriteFile(path, []byte(`{"events":[{"name":"VaultInitEvent"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := validIDL(path, ""); err != nil {
+		t.Errorf("expected an IDL without discriminators to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Job is an operation that must not interleave with real-time parsing,
+// e.g. a reparse or a subgraph rebuild requested through the admin API.
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) error
+	done chan error
+}
+
+// Finish runs the job and reports its result to the submitter.
+func (j Job) Finish(ctx context.Context) {
+	log.Infof("[control] Running job %s...", j.Name)
+	err := j.Run(ctx)
+	if err != nil {
+		log.Errorf("[control] Job %s failed: %v", j.Name, err)
+	} else {
+		log.Infof("[control] Job %s done", j.Name)
+	}
+	j.done <- err
+}
+
+var (
+	mu      sync.Mutex
+	paused  bool
+	changed = make(chan struct{})
+	jobs    = make(chan Job)
+)
+
+// Pause stops the parser from consuming new signatures until Resume is called.
+func Pause() {
+	setPaused(true)
+}
+
+// Resume lets the parser consume signatures again.
+func Resume() {
+	setPaused(false)
+}
+
+// Paused reports whether the pipeline is paused.
+func Paused() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return paused
+}
+
+// State returns whether the pipeline is paused and a channel that is closed on the next change.
+func State() (bool, <-chan struct{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	return paused, changed
+}
+
+func setPaused(p bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if paused == p {
+		return
+	}
+	paused = p
+	close(changed)
+	changed = make(chan struct{})
+	log.Infof("[control] Pipeline paused: %t", p)
+}
+
+// WaitResumed blocks while the pipeline is paused.
+func WaitResumed(ctx context.Context) error {
+	for {
+		p, ch := State()
+		if !p {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// Jobs returns the channel the parser receives submitted jobs from.
+func Jobs() <-chan Job {
+	return jobs
+}
+
+// Submit hands a job to the parser and waits for its result. If the context ends
+// before the parser picks the job up, the job is not run.
+func Submit(ctx context.Context, name string, run func(ctx context.Context) error) error {
+	job := Job{Name: name, Run: run, done: make(chan error, 1)}
+	select {
+	case jobs <- job:
+	case <-ctx.Done():
+		return fmt.Errorf("[control] job %s not started: %w", name, ctx.Err())
+	}
+
+	select {
+	case err := <-job.done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("[control] job %s still running: %w", name, ctx.Err())
+	}
+}
+
+// keptJobs is the number of jobs started with Start whose status is kept.
+const keptJobs = 100
+
+// Job states reported by JobStatus.
+const (
+	JobQueued  = "queued"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// JobStatus is the state of a job started with Start.
+type JobStatus struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Error      string     `json:"error,omitempty"`
+	QueuedAt   time.Time  `json:"queued_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+var (
+	statusMu sync.Mutex
+	statuses = make(map[string]*JobStatus)
+	order    []string // ids in start order, the oldest first
+	lastID   int
+)
+
+// Start submits a job in the background and returns its id, whose status Status reports.
+// Only the latest jobs are kept.
+func Start(name string, run func(ctx context.Context) error) string {
+	statusMu.Lock()
+	lastID++
+	id := strconv.Itoa(lastID)
+	statuses[id] = &JobStatus{ID: id, Name: name, State: JobQueued, QueuedAt: time.Now()}
+	order = append(order, id)
+	if len(order) > keptJobs {
+		delete(statuses, order[0])
+		order = order[1:]
+	}
+	statusMu.Unlock()
+
+	go func() {
+		err := Submit(context.Background(), name, func(ctx context.Context) error {
+			updateStatus(id, func(s *JobStatus) {
+				now := time.Now()
+				s.State, s.StartedAt = JobRunning, &now
+			})
+			return run(ctx)
+		})
+		updateStatus(id, func(s *JobStatus) {
+			now := time.Now()
+			s.State, s.FinishedAt = JobDone, &now
+			if err != nil {
+				s.State, s.Error = JobFailed, err.Error()
+			}
+		})
+	}()
+	return id
+}
+
+// Status returns the status of a job started with Start.
+func Status(id string) (JobStatus, bool) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	s, ok := statuses[id]
+	if !ok {
+		return JobStatus{}, false
+	}
+	return *s, true
+}
+
+func updateStatus(id string, update func(s *JobStatus)) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	if s, ok := statuses[id]; ok {
+		update(s)
+	}
+}
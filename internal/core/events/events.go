@@ -8,9 +8,14 @@ var Discriminators = make(map[[8]byte]string)
 
 func init() {
 	for name := range Registry {
-		hash := sha256.Sum256([]byte("event:" + name))
-		var disc [8]byte
-		copy(disc[:], hash[:8])
-		Discriminators[disc] = name
+		Discriminators[Discriminator(name)] = name
 	}
 }
+
+// Discriminator returns the discriminator Anchor prefixes the data of an event with.
+func Discriminator(name string) [8]byte {
+	hash := sha256.Sum256([]byte("event:" + name))
+	var disc [8]byte
+	copy(disc[:], hash[:8])
+	return disc
+}
@@ -0,0 +1,308 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"sync"
+)
+
+// blocksPage is the number of slots listed per getBlocks request; the RPC allows up to 500,000.
+const blocksPage = 1000
+
+// RPC errors of getBlock. Only a skipped slot has no block; a slot missing in long-term storage may
+// well have one that the node can't serve, so walking past it would lose its transactions.
+const (
+	errSlotSkipped            = -32007 // skipped, or missing due to a ledger jump to a recent snapshot
+	errLongTermStorageMissing = -32009 // skipped, or missing in long-term storage
+)
+
+// indexedBlocks is the number of recent blocks whose signatures are kept for BlockIndex.
+const indexedBlocks = 64
+
+// blockSignatures caches the signatures of recent blocks in block order, so transactions fetched
+// one by one from the same block cost a single getBlock.
+var blockSignatures = struct {
+	sync.Mutex
+	bySlot map[uint64][]solana.Signature
+	slots  []uint64 // in the order they were cached
+}{bySlot: make(map[uint64][]solana.Signature)}
+
+// blocksWatermark names the watermark holding the next slot to walk for a program fetched by blocks.
+func blocksWatermark(program string) string {
+	return "blocks:" + program
+}
+
+// fetchHistoricalBlocks walks the blocks up to the current slot for the programs fetched by blocks.
+// Programs with the same commitment share one walk. If resume is enabled, each program continues
+// after the last slot it walked.
+func fetchHistoricalBlocks(ctx context.Context, db *storage.Gorm, resume bool) error {
+	groups := make(map[string][]config.Program)
+	for _, program := range programs.All() {
+		if program.FetchMode == config.FetchBlocks {
+			groups[program.Commitment] = append(groups[program.Commitment], program)
+		}
+	}
+
+	for commitment, group := range groups {
+		from := make(map[string]uint64, len(group))
+		for _, program := range group {
+			from[program.Address] = program.StartSlot
+			if !resume {
+				continue
+			}
+			next, err := db.GetWatermark(ctx, blocksWatermark(program.Address))
+			if err != nil {
+				return err
+			}
+			if next > program.StartSlot {
+				from[program.Address] = next
+				log.Infof("[fetcher] Continuing blocks of program %s from slot %d", program.Address, next)
+			}
+		}
+
+		toSlot, err := currentSlot(ctx, rpc.CommitmentType(commitment))
+		if err != nil {
+			return err
+		}
+		// The walk is recorded per page, so an interrupted one doesn't start over
+		walked := func(lastSlot uint64) error {
+			for _, program := range group {
+				if from[program.Address] <= lastSlot {
+					if err := db.SetWatermark(ctx, blocksWatermark(program.Address), lastSlot+1); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		saved, err := walkBlocks(ctx, db, group, from, toSlot, walked)
+		if err != nil {
+			return err
+		}
+		log.Infof("[fetcher] Fetched %d transactions from blocks up to slot %d for %d programs", saved, toSlot, len(group))
+	}
+	return nil
+}
+
+// backfillBlocks stores the transactions of a program fetched by blocks in [fromSlot, toSlot].
+func backfillBlocks(ctx context.Context, db *storage.Gorm, program config.Program, fromSlot, toSlot uint64) (int, error) {
+	current, err := currentSlot(ctx, rpc.CommitmentType(program.Commitment))
+	if err != nil {
+		return 0, err
+	}
+	toSlot = min(toSlot, current)
+	from := map[string]uint64{program.Address: fromSlot}
+	return walkBlocks(ctx, db, []config.Program{program}, from, toSlot, nil)
+}
+
+// walkBlocks stores the transactions of the blocks up to toSlot that mention the programs, each linked
+// to the programs it mentions from their own start slot on. Skipped slots are not listed by getBlocks;
+// walked, if set, is called after every page of slots.
+func walkBlocks(ctx context.Context, db *storage.Gorm, group []config.Program, from map[string]uint64, toSlot uint64, walked func(lastSlot uint64) error) (int, error) {
+	if len(group) == 0 {
+		return 0, nil
+	}
+	startSlot := from[group[0].Address]
+	keys := make(map[solana.PublicKey]config.Program, len(group))
+	for _, program := range group {
+		key, err := solana.PublicKeyFromBase58(program.Address)
+		if err != nil {
+			return 0, fmt.Errorf("[fetcher] invalid program address %s: %w", program.Address, err)
+		}
+		keys[key] = program
+		startSlot = min(startSlot, from[program.Address])
+	}
+	commitment := rpc.CommitmentType(group[0].Commitment)
+
+	saved := 0
+	for pageStart := startSlot; pageStart <= toSlot; pageStart += blocksPage {
+		pageEnd := min(pageStart+blocksPage-1, toSlot)
+		slots, err := utils.Retry(func() (rpc.BlocksResult, error) {
+			return client.GetBlocks(ctx, pageStart, &pageEnd, commitment)
+		})
+		if err != nil {
+			return saved, fmt.Errorf("[fetcher] get blocks %d-%d failed: %w", pageStart, pageEnd, err)
+		}
+		log.Debugf("[fetcher] Walking %d blocks in slots %d-%d", len(slots), pageStart, pageEnd)
+
+		for _, slot := range slots {
+			n, err := saveBlock(ctx, db, slot, keys, from, commitment)
+			if err != nil {
+				return saved, err
+			}
+			saved += n
+			monitoring.FetcherCurrentSlot.Set(float64(slot))
+		}
+		if walked != nil {
+			if err := walked(pageEnd); err != nil {
+				return saved, err
+			}
+		}
+	}
+	return saved, nil
+}
+
+// saveBlock stores the transactions of the block at a slot that mention the programs, in block order.
+func saveBlock(ctx context.Context, db *storage.Gorm, slot uint64, keys map[solana.PublicKey]config.Program, from map[string]uint64, commitment rpc.CommitmentType) (int, error) {
+	block, err := fetchBlock(ctx, slot, commitment)
+	if err != nil {
+		return 0, fmt.Errorf("[fetcher] get block %d failed: %w", slot, err)
+	}
+	if block == nil {
+		log.Debugf("[fetcher] Slot %d was skipped", slot)
+		return 0, nil
+	}
+
+	var txs []core.Transaction
+	links := make(map[string][]string)
+	for i, txWithMeta := range block.Transactions {
+		if txWithMeta.Transaction == nil || txWithMeta.Meta == nil {
+			continue
+		}
+		tx, err := txWithMeta.GetTransaction()
+		if err != nil {
+			return 0, fmt.Errorf("[fetcher] failed to decode transaction %d of block %d: %w", i, slot, err)
+		}
+		if len(tx.Signatures) == 0 {
+			continue
+		}
+		mentioned := mentionedPrograms(tx, txWithMeta.Meta, keys, from, slot)
+		if len(mentioned) == 0 {
+			continue
+		}
+
+		// Stored in the shape of a getTransaction result, like fetched transactions
+		signature := tx.Signatures[0].String()
+		txWithMeta.Slot = slot
+		txWithMeta.BlockTime = block.BlockTime
+		raw, err := json.Marshal(txWithMeta)
+		if err != nil {
+			return 0, fmt.Errorf("[fetcher] marshal transaction %s failed: %w", signature, err)
+		}
+		txs = append(txs, core.Transaction{
+			Signature:  signature,
+			Slot:       slot,
+			BlockTime:  utils.BlockTime(block.BlockTime),
+			JsonTx:     raw,
+			BlockIndex: utils.Ptr(uint32(i)),
+			Finalized:  commitment == rpc.CommitmentFinalized,
+		})
+		links[signature] = mentioned
+	}
+
+	if err := db.SaveBlockTransactions(ctx, txs, links); err != nil {
+		return 0, fmt.Errorf("[fetcher] failed to save transactions of block %d: %w", slot, err)
+	}
+	if len(txs) > 0 {
+		log.Infof("[fetcher] Saved %d transactions of block %d", len(txs), slot)
+	}
+	return len(txs), nil
+}
+
+// mentionedPrograms returns the programs among the accounts of a transaction, including those loaded
+// from lookup tables, that are fetched from the slot on.
+func mentionedPrograms(tx *solana.Transaction, meta *rpc.TransactionMeta, keys map[solana.PublicKey]config.Program, from map[string]uint64, slot uint64) []string {
+	var mentioned []string
+	for _, accounts := range []solana.PublicKeySlice{tx.Message.AccountKeys, meta.LoadedAddresses.Writable, meta.LoadedAddresses.ReadOnly} {
+		for _, account := range accounts {
+			program, ok := keys[account]
+			if ok && from[program.Address] <= slot && !utils.Contains(mentioned, program.Address) {
+				mentioned = append(mentioned, program.Address)
+			}
+		}
+	}
+	return mentioned
+}
+
+// fetchBlock returns the block at a slot with its full transactions, or nil if the slot was skipped.
+// A slot missing in the node's long-term storage is an error, so the walk stops before it and resumes
+// there, e.g. against an endpoint with the full history.
+func fetchBlock(ctx context.Context, slot uint64, commitment rpc.CommitmentType) (*rpc.GetBlockResult, error) {
+	block, err := utils.Retry(func() (*rpc.GetBlockResult, error) {
+		block, err := client.GetBlockWithOpts(ctx, slot, &rpc.GetBlockOpts{
+			Encoding:                       solana.EncodingBase64,
+			TransactionDetails:             rpc.TransactionDetailsFull,
+			Rewards:                        utils.Ptr(false),
+			Commitment:                     commitment,
+			MaxSupportedTransactionVersion: utils.Ptr(rpc.MaxSupportedTransactionVersion0),
+		})
+		var rpcErr *jsonrpc.RPCError
+		if errors.As(err, &rpcErr) && rpcErr.Code == errSlotSkipped {
+			return nil, nil
+		}
+		return block, err
+	})
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == errLongTermStorageMissing {
+		return nil, fmt.Errorf("slot %d is missing in the node's long-term storage: %w", slot, err)
+	}
+	return block, err
+}
+
+// currentSlot returns the latest slot at the commitment.
+func currentSlot(ctx context.Context, commitment rpc.CommitmentType) (uint64, error) {
+	slot, err := utils.Retry(func() (uint64, error) {
+		return client.GetSlot(ctx, commitment)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("[fetcher] get slot failed: %w", err)
+	}
+	return slot, nil
+}
+
+// BlockIndex returns the position of a transaction in the block at a slot, or nil if the block
+// doesn't contain it.
+func BlockIndex(ctx context.Context, slot uint64, signature string) (*uint32, error) {
+	sig, err := solana.SignatureFromBase58(signature)
+	if err != nil {
+		return nil, fmt.Errorf("[fetcher] invalid signature %s: %w", signature, err)
+	}
+
+	blockSignatures.Lock()
+	signatures, ok := blockSignatures.bySlot[slot]
+	blockSignatures.Unlock()
+	if !ok {
+		block, err := utils.Retry(func() (*rpc.GetBlockResult, error) {
+			return client.GetBlockWithOpts(ctx, slot, &rpc.GetBlockOpts{
+				TransactionDetails:             rpc.TransactionDetailsSignatures,
+				Rewards:                        utils.Ptr(false),
+				Commitment:                     rpc.CommitmentConfirmed,
+				MaxSupportedTransactionVersion: utils.Ptr(rpc.MaxSupportedTransactionVersion0),
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[fetcher] get signatures of block %d failed: %w", slot, err)
+		}
+		signatures = block.Signatures
+
+		blockSignatures.Lock()
+		if _, ok := blockSignatures.bySlot[slot]; !ok {
+			blockSignatures.bySlot[slot] = signatures
+			blockSignatures.slots = append(blockSignatures.slots, slot)
+			if len(blockSignatures.slots) > indexedBlocks {
+				delete(blockSignatures.bySlot, blockSignatures.slots[0])
+				blockSignatures.slots = blockSignatures.slots[1:]
+			}
+		}
+		blockSignatures.Unlock()
+	}
+
+	for i, s := range signatures {
+		if s == sig {
+			return utils.Ptr(uint32(i)), nil
+		}
+	}
+	return nil, nil
+}
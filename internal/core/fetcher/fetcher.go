@@ -6,26 +6,41 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/core/rpcpool"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
 	"github.com/Tsisar/solana-indexer/internal/storage"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
 	"github.com/Tsisar/solana-indexer/internal/utils"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
+	"slices"
 )
 
-var client = rpc.New(config.App.RPCEndpoint) // RPC client used for querying the Solana blockchain
+var client = rpcpool.New() // RPC client used for querying the Solana blockchain
 
 // Start orchestrates the entire data fetching and parsing process:
-// 1. Fetch historical signatures,
+// 1. Fetch historical signatures, or the blocks of programs fetched by blocks,
 // 2. Fetch full transaction JSONs,
 // 3. Parse saved transactions.
 func Start(ctx context.Context, db *storage.Gorm, resume bool, done chan struct{}) error {
 	defer close(done) // ensure the signal is sent even on error
 
+	// The history is stored into partitions up to the current slot, not into the default ones
+	slot, err := currentSlot(ctx, rpc.CommitmentConfirmed)
+	if err != nil {
+		return err
+	}
+	if _, err := db.CreatePartitions(ctx, slot); err != nil {
+		return fmt.Errorf("[fetcher] %w", err)
+	}
+
 	if err := fetchHistoricalSignatures(ctx, db, resume); err != nil {
 		return fmt.Errorf("[fetcher] failed to fetch historical signatures: %w", err)
 	}
+	if err := fetchHistoricalBlocks(ctx, db, resume); err != nil {
+		return fmt.Errorf("[fetcher] failed to fetch historical blocks: %w", err)
+	}
 	if err := fetchRawTransactions(ctx, db); err != nil {
 		return fmt.Errorf("[fetcher] failed to fetch full transactions: %w", err)
 	}
@@ -36,46 +51,101 @@ func Start(ctx context.Context, db *storage.Gorm, resume bool, done chan struct{
 // using paginated RPC requests. If resume is enabled, it will stop fetching
 // once the last known signature is reached.
 func fetchHistoricalSignatures(ctx context.Context, db *storage.Gorm, resume bool) error {
-	programs := config.App.Programs
-
-	for _, program := range programs {
+	for _, program := range programs.All() {
+		if program.FetchMode == config.FetchBlocks {
+			continue
+		}
 		sigs, err := fetchHistoricalSignaturesForAddress(ctx, db, program, resume)
 		if err != nil {
-			return fmt.Errorf("[fetcher] failed to fetch signatures for %s: %w", program, err)
+			return fmt.Errorf("[fetcher] failed to fetch signatures for %s: %w", program.Address, err)
 		}
 
-		for _, sig := range sigs {
-			signatureStr := sig.Signature.String()
-			transaction := core.Transaction{
-				Signature: signatureStr,
-				Slot:      sig.Slot,
-				BlockTime: utils.BlockTime(sig.BlockTime),
-			}
-
-			if err := db.SaveTransaction(ctx, &transaction, program); err != nil {
-				return fmt.Errorf("[fetcher] failed to save transaction %s: %w", signatureStr, err)
-			}
+		saved, err := db.SaveSignatures(ctx, signatureTransactions(sigs), program.Address)
+		if err != nil {
+			return fmt.Errorf("[fetcher] failed to save signatures for %s: %w", program.Address, err)
 		}
-		log.Infof("[fetcher] Fetched %d signatures for program %s", len(sigs), program)
+		log.Infof("[fetcher] Fetched %d signatures for program %s, %d new", len(sigs), program.Address, saved)
 	}
 	return nil
 }
 
-// fetchHistoricalSignaturesForAddress fetches all transaction signatures for a given address.
+// signatureTransactions returns the transactions of signatures, without their raw payload.
+func signatureTransactions(sigs []*rpc.TransactionSignature) []core.Transaction {
+	txs := make([]core.Transaction, len(sigs))
+	for i, sig := range sigs {
+		txs[i] = core.Transaction{
+			Signature: sig.Signature.String(),
+			Slot:      sig.Slot,
+			BlockTime: utils.BlockTime(sig.BlockTime),
+			Finalized: sig.ConfirmationStatus == rpc.ConfirmationStatusFinalized,
+		}
+	}
+	return txs
+}
+
+// fetchHistoricalSignaturesForAddress fetches all transaction signatures of a program down to its start slot.
 // It stops fetching once it reaches the last saved signature (if resume is enabled).
-func fetchHistoricalSignaturesForAddress(ctx context.Context, db *storage.Gorm, address string, resume bool) ([]*rpc.TransactionSignature, error) {
-	publicKey := solana.MustPublicKeyFromBase58(address)
+func fetchHistoricalSignaturesForAddress(ctx context.Context, db *storage.Gorm, program config.Program, resume bool) ([]*rpc.TransactionSignature, error) {
+	var until string
+	if resume {
+		lastSigStr, err := db.GetLatestSavedSignature(ctx, program.Address)
+		if err != nil {
+			log.Errorf("[fetcher] get last saved signature failed: %v", err)
+			return nil, err
+		}
+		if lastSigStr != "" {
+			until = lastSigStr
+			log.Infof("[fetcher] Using last saved signature %s as lower bound for program %s", lastSigStr, program.Address)
+		}
+	}
+	return signaturesUntil(ctx, program, until)
+}
+
+// SignaturesSince returns the signatures of a program newer than the given one, oldest first.
+// It is used to fill the gap left by an interrupted subscription.
+func SignaturesSince(ctx context.Context, program config.Program, since string) ([]*rpc.TransactionSignature, error) {
+	sigs, err := signaturesUntil(ctx, program, since)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(sigs)
+	return sigs, nil
+}
+
+// LatestSignatures returns up to limit of the newest signatures of a program, newest first.
+func LatestSignatures(ctx context.Context, program config.Program, limit int) ([]*rpc.TransactionSignature, error) {
+	publicKey, err := solana.PublicKeyFromBase58(program.Address)
+	if err != nil {
+		return nil, fmt.Errorf("[fetcher] invalid program address %s: %w", program.Address, err)
+	}
+	opts := &rpc.GetSignaturesForAddressOpts{
+		Limit:      utils.Ptr(limit),
+		Commitment: rpc.CommitmentType(program.Commitment),
+	}
+	sigs, err := utils.Retry(func() ([]*rpc.TransactionSignature, error) {
+		return client.GetSignaturesForAddressWithOpts(ctx, publicKey, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[fetcher] get signatures failed: %w", err)
+	}
+	return sigs, nil
+}
+
+// signaturesUntil pages the signatures of a program from the newest down to the until signature
+// (exclusive) or the program's start slot, newest first. An empty until pages the whole history.
+func signaturesUntil(ctx context.Context, program config.Program, untilStr string) ([]*rpc.TransactionSignature, error) {
+	address := program.Address
+	publicKey, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("[fetcher] invalid program address %s: %w", address, err)
+	}
 	var before solana.Signature
 	var until solana.Signature
 	var result []*rpc.TransactionSignature
 
-	if resume {
-		if lastSigStr, err := db.GetLatestSavedSignature(ctx, address); err != nil {
-			log.Errorf("[fetcher] get last saved signature failed: %v", err)
-			return nil, err
-		} else if lastSigStr != "" {
-			until = solana.MustSignatureFromBase58(lastSigStr)
-			log.Infof("[fetcher] Using last saved signature %s as lower bound for program %s", lastSigStr, address)
+	if untilStr != "" {
+		if until, err = solana.SignatureFromBase58(untilStr); err != nil {
+			return nil, fmt.Errorf("[fetcher] invalid signature %s: %w", untilStr, err)
 		}
 	}
 
@@ -85,7 +155,7 @@ func fetchHistoricalSignaturesForAddress(ctx context.Context, db *storage.Gorm,
 			Limit:      utils.Ptr(1000),
 			Before:     before,
 			Until:      until,
-			Commitment: rpc.CommitmentConfirmed,
+			Commitment: rpc.CommitmentType(program.Commitment),
 		}
 
 		getSignaturesForAddressWithOpts := func() ([]*rpc.TransactionSignature, error) {
@@ -101,7 +171,14 @@ func fetchHistoricalSignaturesForAddress(ctx context.Context, db *storage.Gorm,
 			break
 		}
 
-		result = append(result, sigs...)
+		// Signatures are returned newest first, so everything after the start slot is older
+		for _, sig := range sigs {
+			if sig.Slot < program.StartSlot {
+				log.Infof("[fetcher] Reached start slot %d for program %s", program.StartSlot, address)
+				return result, nil
+			}
+			result = append(result, sig)
+		}
 		before = sigs[len(sigs)-1].Signature
 	}
 
@@ -127,7 +204,7 @@ func fetchRawTransactions(ctx context.Context, db *storage.Gorm) error {
 			return fmt.Errorf("[fetcher] marshal raw transaction failed: %w", err)
 		}
 
-		if err := db.UpdateTransactionRaw(ctx, sig, raw); err != nil {
+		if err := db.UpdateTransactionRaw(ctx, sig, txRes.Slot, utils.BlockTime(txRes.BlockTime), raw); err != nil {
 			return fmt.Errorf("[fetcher] save transaction failed: %w", err)
 		}
 		log.Infof("[fetcher] Saved raw transaction: slot: %d tx: %s", txRes.Slot, sig)
@@ -151,3 +228,63 @@ func FetchRawTransaction(ctx context.Context, signature string) (*rpc.GetTransac
 
 	return utils.Retry(getTransactionResult)
 }
+
+// Backfill stores the signatures of a program in [fromSlot, toSlot] and fetches their raw transactions.
+// Signatures are paged from the newest to the oldest, so paging stops once fromSlot is passed.
+// An indexed program fetched by blocks walks the blocks of the range instead.
+func Backfill(ctx context.Context, db *storage.Gorm, program string, fromSlot, toSlot uint64) (int, error) {
+	publicKey, err := solana.PublicKeyFromBase58(program)
+	if err != nil {
+		return 0, fmt.Errorf("[fetcher] invalid program address %s: %w", program, err)
+	}
+	if err := db.SaveProgram(ctx, program); err != nil {
+		return 0, fmt.Errorf("[fetcher] failed to save program %s: %w", program, err)
+	}
+	if p, ok := programs.Get(program); ok && p.FetchMode == config.FetchBlocks {
+		saved, err := backfillBlocks(ctx, db, p, fromSlot, toSlot)
+		if err != nil {
+			return saved, err
+		}
+		log.Infof("[fetcher] Backfilled %d transactions of program %s from blocks in slots %d-%d", saved, program, fromSlot, toSlot)
+		return saved, nil
+	}
+
+	var before solana.Signature
+	saved := 0
+	for {
+		opts := &rpc.GetSignaturesForAddressOpts{
+			Limit:      utils.Ptr(1000),
+			Before:     before,
+			Commitment: rpc.CommitmentConfirmed,
+		}
+		sigs, err := utils.Retry(func() ([]*rpc.TransactionSignature, error) {
+			return client.GetSignaturesForAddressWithOpts(ctx, publicKey, opts)
+		})
+		if err != nil {
+			return saved, fmt.Errorf("[fetcher] get signatures failed: %w", err)
+		}
+		if len(sigs) == 0 {
+			break
+		}
+
+		inRange := slices.DeleteFunc(slices.Clone(sigs), func(sig *rpc.TransactionSignature) bool {
+			return sig.Slot > toSlot || sig.Slot < fromSlot
+		})
+		if _, err := db.SaveSignatures(ctx, signatureTransactions(inRange), program); err != nil {
+			return saved, fmt.Errorf("[fetcher] failed to save signatures: %w", err)
+		}
+		saved += len(inRange)
+
+		last := sigs[len(sigs)-1]
+		if last.Slot < fromSlot {
+			break
+		}
+		before = last.Signature
+	}
+	log.Infof("[fetcher] Backfilled %d signatures for program %s in slots %d-%d", saved, program, fromSlot, toSlot)
+
+	if err := fetchRawTransactions(ctx, db); err != nil {
+		return saved, fmt.Errorf("[fetcher] failed to fetch full transactions: %w", err)
+	}
+	return saved, nil
+}
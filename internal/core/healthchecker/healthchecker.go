@@ -9,7 +9,7 @@ import (
 // Check performs a full health check on the database.
 // It verifies both the sequence of transactions and the sequence of events.
 // If any issue is found, the health status is marked as "unhealthy".
-func Check(ctx context.Context, db *storage.Gorm) error {
+func Check(ctx context.Context, db storage.HealthStore) error {
 	// Validate transaction order and parse status
 	if err := checkTransactions(ctx, db); err != nil {
 		if err := db.SetHealth(ctx, "unhealthy", err.Error()); err != nil {
@@ -32,7 +32,7 @@ func Check(ctx context.Context, db *storage.Gorm) error {
 
 // checkTransactions ensures that all parsed transactions are in proper slot order,
 // and no parsed transaction follows an unparsed one.
-func checkTransactions(ctx context.Context, db *storage.Gorm) error {
+func checkTransactions(ctx context.Context, db storage.HealthStore) error {
 	//type row struct {
 	//	Slot   uint64
 	//	Parsed bool
@@ -61,7 +61,7 @@ func checkTransactions(ctx context.Context, db *storage.Gorm) error {
 // 1. `index` is strictly increasing
 // 2. `block_time` is non-decreasing
 // 3. `log_index` is increasing within the same transaction signature
-func checkEvents(ctx context.Context, db *storage.Gorm) error {
+func checkEvents(ctx context.Context, db storage.HealthStore) error {
 	// Uncomment to activate the check
 
 	// type row struct {
@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-func Start(ctx context.Context, db *storage.Gorm) error {
+func Start(ctx context.Context, db storage.HealthStore) error {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
@@ -0,0 +1,177 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go/rpc"
+	"maps"
+	"time"
+)
+
+// fetchBatch is the number of pending signatures loaded per query.
+const fetchBatch = 100
+
+// Backoff of a pending transaction the RPC doesn't serve yet; freshly confirmed transactions are often
+// not found for a few seconds. Whether one that never shows up was dropped is up to the reconciler.
+const (
+	minFetchBackoff = time.Second
+	maxFetchBackoff = 30 * time.Second
+)
+
+// retry schedules the next fetch of a pending transaction that was not found.
+type retry struct {
+	backoff time.Duration
+	at      time.Time
+}
+
+// fetchPending fetches the raw transactions of the pending queue in block order whenever
+// signatures are queued, and signals realtime once they can be parsed.
+// A failed fetch is reported and restarts the cycle. Once the context ends, the subscriptions
+// are awaited and what is still queued is fetched until the shutdown timeout; the rest stays
+// pending in the DB and is fetched on restart.
+func fetchPending(ctx context.Context, db *storage.Gorm, wake <-chan struct{}, realtime chan<- struct{}, subs *subscriptions) {
+	work, cancel := utils.Graceful(ctx, config.App.ShutdownTimeout)
+	defer cancel()
+
+	retries := make(map[string]retry)
+	for {
+		// Signatures left from a previous run are fetched right away
+		wait, err := fetchQueued(work, db, realtime, retries)
+		if err != nil {
+			subs.fail(err)
+			<-ctx.Done()
+			<-subs.stopped()
+			return
+		}
+		var due <-chan time.Time
+		if wait > 0 {
+			due = time.After(wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			<-subs.stopped()
+			clear(retries)
+			if _, err := fetchQueued(work, db, realtime, retries); err != nil {
+				log.Warnf("[listener] Pending queue not drained, the rest is fetched on restart: %v", err)
+				return
+			}
+			log.Info("[listener] Pending queue drained")
+			return
+		case <-wake:
+		case <-due:
+		}
+	}
+}
+
+// fetchQueued fetches pending transactions until none without a raw transaction are left, or only
+// ones waiting for a retry. It returns how long until the next retry is due, or 0 if none is.
+func fetchQueued(ctx context.Context, db *storage.Gorm, realtime chan<- struct{}, retries map[string]retry) (time.Duration, error) {
+	for {
+		txs, err := db.GetPendingUnfetched(ctx, fetchBatch)
+		if err != nil {
+			return 0, fmt.Errorf("[listener] %w", err)
+		}
+		if len(txs) < fetchBatch {
+			// Transactions no longer queued were fetched elsewhere or removed by the reconciler
+			queued := make(map[string]bool, len(txs))
+			for _, tx := range txs {
+				queued[tx.Signature] = true
+			}
+			maps.DeleteFunc(retries, func(signature string, _ retry) bool { return !queued[signature] })
+		}
+		if len(txs) == 0 {
+			return 0, nil
+		}
+
+		fetched := false
+		var next time.Time
+		for _, tx := range txs {
+			r, waiting := retries[tx.Signature]
+			if waiting && time.Now().Before(r.at) {
+				if next.IsZero() || r.at.Before(next) {
+					next = r.at
+				}
+				continue
+			}
+
+			found, err := fetch(ctx, db, tx)
+			if err != nil {
+				return 0, fmt.Errorf("[listener] fetch failed for transaction %s: %w", tx.Signature, err)
+			}
+			if !found {
+				r.backoff = min(max(2*r.backoff, minFetchBackoff), maxFetchBackoff)
+				r.at = time.Now().Add(r.backoff)
+				retries[tx.Signature] = r
+				log.Warnf("[listener] Transaction %s not found yet, retrying in %s", tx.Signature, r.backoff)
+				if next.IsZero() || r.at.Before(next) {
+					next = r.at
+				}
+				continue
+			}
+			delete(retries, tx.Signature)
+			fetched = true
+			signal(realtime)
+		}
+		if !fetched {
+			return max(time.Until(next), time.Millisecond), nil
+		}
+	}
+}
+
+// fetch loads a pending transaction from the Solana RPC and stores its raw payload and block index.
+// It reports false if the RPC doesn't know the transaction after all retries, leaving it queued.
+func fetch(ctx context.Context, db *storage.Gorm, tx core.Transaction) (bool, error) {
+	signature := tx.Signature
+	txRes, err := fetcher.FetchRawTransaction(ctx, signature)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("[listener] failed to fetch raw transaction %s: %w", signature, err)
+	}
+
+	// Serialize raw transaction to JSON
+	raw, err := json.Marshal(txRes)
+	if err != nil {
+		return false, fmt.Errorf("[listener] failed to marshal raw transaction %s: %w", signature, err)
+	}
+
+	if err := db.UpdateTransactionRaw(ctx, signature, txRes.Slot, utils.BlockTime(txRes.BlockTime), raw); err != nil {
+		return false, fmt.Errorf("[listener] failed to save transaction %s: %w", signature, err)
+	}
+	// A known index is only valid for the slot it was received in
+	if tx.BlockIndex == nil || txRes.Slot != tx.Slot {
+		if blockIndex := lookupBlockIndex(ctx, txRes.Slot, signature); blockIndex != nil {
+			if err := db.SetBlockIndex(ctx, signature, *blockIndex); err != nil {
+				return false, fmt.Errorf("[listener] %w", err)
+			}
+		}
+	}
+	monitoring.ListenerCurrentSlot.Set(float64(txRes.Slot))
+	log.Infof("[listener] Fetched transaction %s", signature)
+	return true, nil
+}
+
+// lookupBlockIndex returns the position of a transaction in the block at a slot. It is only used to
+// order transactions within their slot, so a failed lookup is logged and leaves the index unknown.
+func lookupBlockIndex(ctx context.Context, slot uint64, signature string) *uint32 {
+	blockIndex, err := fetcher.BlockIndex(ctx, slot, signature)
+	if err != nil {
+		log.Warnf("[listener] Block index of transaction %s unknown: %v", signature, err)
+		return nil
+	}
+	if blockIndex == nil {
+		log.Warnf("[listener] Transaction %s not found in block %d", signature, slot)
+	}
+	return blockIndex
+}
@@ -0,0 +1,131 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	seenLimit         = 10_000           // delivered signatures remembered per program
+	watchdogPollLimit = 100              // newest signatures polled per watchdog check
+	blockTimeSlack    = 10 * time.Second // block times are estimates, so early ones are not held against a subscription
+)
+
+// liveness tracks what the subscription of one program delivered, for the keepalive,
+// the watchdog and the idle metric. It outlives reconnects.
+type liveness struct {
+	program          config.Program
+	lastNotification atomic.Int64 // unix nanoseconds
+
+	mu    sync.Mutex
+	since time.Time // when the current subscription was established
+	seen  map[string]struct{}
+	order []string
+}
+
+func newLiveness(program config.Program) *liveness {
+	l := &liveness{program: program, seen: make(map[string]struct{})}
+	l.lastNotification.Store(time.Now().UnixNano())
+	return l
+}
+
+// subscribed marks the start of a new subscription.
+func (l *liveness) subscribed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.since = time.Now()
+}
+
+// saw records a signature delivered by a notification or queued by a gap fill.
+func (l *liveness) saw(signature string, notification bool) {
+	if notification {
+		l.lastNotification.Store(time.Now().UnixNano())
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[signature]; ok {
+		return
+	}
+	l.seen[signature] = struct{}{}
+	l.order = append(l.order, signature)
+	if len(l.order) > seenLimit {
+		delete(l.seen, l.order[0])
+		l.order = l.order[1:]
+	}
+}
+
+// reportIdle exports the seconds since the last notification of the program until the context ends.
+func (l *liveness) reportIdle(ctx context.Context) {
+	address := l.program.Address
+	defer monitoring.ListenerSecondsSinceNotification.DeleteLabelValues(address)
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			last := time.Unix(0, l.lastNotification.Load())
+			monitoring.ListenerSecondsSinceNotification.WithLabelValues(address).Set(time.Since(last).Seconds())
+		}
+	}
+}
+
+// watchdog polls the newest signatures of the program every interval and stops the subscription
+// once polling finds signatures it should have delivered by then, so it is resubscribed and the gap filled.
+func (l *liveness) watchdog(ctx context.Context, stop context.CancelCauseFunc, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			missed, err := l.missed(ctx, interval)
+			if err != nil {
+				log.Warnf("[listener] Watchdog poll for %s failed: %v", l.program.Address, err)
+				continue
+			}
+			if missed > 0 {
+				stop(fmt.Errorf("[listener] subscription of %s missed %d signatures found by polling", l.program.Address, missed))
+				return
+			}
+		}
+	}
+}
+
+// missed counts the polled signatures that landed after the subscription was established
+// and at least grace ago, but were neither delivered nor queued by a gap fill.
+func (l *liveness) missed(ctx context.Context, grace time.Duration) (int, error) {
+	sigs, err := fetcher.LatestSignatures(ctx, l.program, watchdogPollLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	from, to := l.since.Add(blockTimeSlack), time.Now().Add(-grace)
+	missed := 0
+	for _, sig := range sigs {
+		if sig.BlockTime == nil {
+			continue
+		}
+		landed := sig.BlockTime.Time()
+		if landed.Before(from) || landed.After(to) {
+			continue
+		}
+		if _, ok := l.seen[sig.Signature.String()]; !ok {
+			missed++
+		}
+	}
+	return missed, nil
+}
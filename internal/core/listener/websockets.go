@@ -7,175 +7,316 @@ import (
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/config"
 	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/Tsisar/solana-indexer/internal/core/source"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
 	"github.com/Tsisar/solana-indexer/internal/storage"
-	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
 	"github.com/Tsisar/solana-indexer/internal/utils"
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	"github.com/gagliardetto/solana-go/rpc/ws"
-	"gorm.io/datatypes"
+	"sync"
+	"time"
 )
 
-// fetchTask represents a signature received from a WebSocket subscription
-// along with the associated program it was observed in.
-type fetchTask struct {
-	Signature string
-	Program   string
-}
-
-// Start initializes WebSocket subscriptions and starts processing
-// of transactions fetched via WebSocket events.
-// It ensures all programs are subscribed before signaling readiness via wsReady.
-func Start(ctx context.Context, db *storage.Gorm, wsReady chan<- struct{}, realtimeStream chan<- string, errorChan chan<- error) error {
-	// Connect to Solana WebSocket endpoint
-	wsClient, err := ws.Connect(ctx, config.App.RPCWSEndpoint)
+// Start initializes the subscriptions of the configured source and starts processing
+// of the transactions it delivers.
+// It ensures all programs are subscribed before signaling readiness via wsReady,
+// then follows programs added or removed at runtime until the context ends.
+// Every program has its own subscription that reconnects on its own, see subscriptions.run.
+// Received transactions are queued in the DB as pending; those delivered by signature only are
+// fetched in slot order. realtime is signaled whenever transactions are ready to be parsed.
+// On shutdown it unsubscribes, fetches what is queued within the shutdown timeout
+// and closes realtime before returning.
+func Start(ctx context.Context, db *storage.Gorm, wsReady chan<- struct{}, realtime chan<- struct{}, errorChan chan<- error) error {
+	src, err := source.New()
 	if err != nil {
-		return fmt.Errorf("[listener] failed to connect to WebSocket: %w", err)
+		return fmt.Errorf("[listener] %w", err)
+	}
+	wake := make(chan struct{}, 1)
+	subs := &subscriptions{
+		db:        db,
+		source:    src,
+		wake:      wake,
+		realtime:  realtime,
+		errorChan: errorChan,
+		active:    make(map[string]subscription),
 	}
 
-	fetchQueue := make(chan fetchTask, 1000)
-	programCount := len(config.App.Programs)
-	connected := make(chan struct{}, programCount)
+	// Returning stops the subscriptions, then waits for the queue to be drained
+	fetcherDone := make(chan struct{})
+	defer func() {
+		<-fetcherDone
+		close(realtime)
+	}()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	subs.ctx = ctx
 
-	// Start transaction fetcher that processes incoming WebSocket events
+	// Start transaction fetcher that processes the pending queue
 	go func() {
-		if err := fetchFromQueue(ctx, db, fetchQueue, realtimeStream); err != nil {
-			errorChan <- fmt.Errorf("[listener] fetcher error: %v", err)
-		}
+		defer close(fetcherDone)
+		fetchPending(ctx, db, wake, realtime, subs)
 	}()
 
-	// Start WebSocket subscriptions for all configured programs
-	for _, program := range config.App.Programs {
-		publicKey := solana.MustPublicKeyFromBase58(program)
-
-		go func(pid solana.PublicKey) {
-			if err := watch(ctx, wsClient, pid, connected, fetchQueue); err != nil {
-				errorChan <- fmt.Errorf("[listener] watch failed for %s: %w", pid.String(), err)
-			}
-		}(publicKey)
+	// Start WebSocket subscriptions for all active programs
+	changed := programs.Changed()
+	initial := programs.All()
+	connected := make(chan struct{}, len(initial))
+	for _, program := range initial {
+		if err := subs.add(program, connected); err != nil {
+			return err
+		}
 	}
 
 	// Wait until all programs are subscribed or an error occurs
-	readyCount := 0
-	for {
+	for readyCount := 0; readyCount < len(initial); readyCount++ {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-connected:
-			readyCount++
-			if readyCount == programCount {
-				log.Info("[listener] All WebSocket subscriptions are active.")
-				wsReady <- struct{}{}
-				return nil
-			}
 		}
 	}
+	log.Info("[listener] All subscriptions are active.")
+	wsReady <- struct{}{}
+
+	subs.follow(changed)
+	log.Info("[listener] Stopped accepting notifications, draining pending queue...")
+	return nil
 }
 
-// watch subscribes to transaction logs for a single Solana program
-// and forwards the observed signatures to the fetchQueue.
-func watch(ctx context.Context, wsClient *ws.Client, publicKey solana.PublicKey, readySignal chan<- struct{}, fetchQueue chan<- fetchTask) error {
-	log.Infof("[listener] subscribing for %s...", publicKey.String())
+// subscription is the running watch of one program.
+type subscription struct {
+	commitment string
+	cancel     context.CancelFunc
+}
+
+// subscriptions manages the watches of the active programs, each with a subscription of its own.
+type subscriptions struct {
+	ctx       context.Context
+	db        *storage.Gorm
+	source    source.Source
+	wake      chan<- struct{} // signals the fetcher that signatures were queued
+	realtime  chan<- struct{} // signals the parser that full transactions were queued
+	errorChan chan<- error
+	active    map[string]subscription
+	watching  sync.WaitGroup
+}
 
-	sub, err := wsClient.LogsSubscribeMentions(publicKey, rpc.CommitmentConfirmed)
+// Reconnect backoff of a failed subscription.
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// fail reports an error without blocking; only the first one restarts the cycle.
+func (s *subscriptions) fail(err error) {
+	select {
+	case s.errorChan <- err:
+	default:
+		log.Errorf("[listener] %v", err)
+	}
+}
+
+// enqueue stores a received transaction as pending before the next one is read, so it survives
+// a restart. A transaction delivered in full is ready to be parsed, otherwise the fetcher is woken.
+func (s *subscriptions) enqueue(ctx context.Context, program string, tx source.Transaction) error {
+	// A notification received during shutdown is still stored
+	ctx = context.WithoutCancel(ctx)
+	if tx.Result == nil {
+		if err := s.db.EnqueuePending(ctx, tx.Signature, tx.Slot, tx.BlockIndex, program); err != nil {
+			return fmt.Errorf("[listener] %w", err)
+		}
+		signal(s.wake)
+		return nil
+	}
+
+	raw, err := json.Marshal(tx.Result)
 	if err != nil {
-		return fmt.Errorf("[listener] logs subscribe failed for %s: %w", publicKey.String(), err)
+		return fmt.Errorf("[listener] failed to marshal raw transaction %s: %w", tx.Signature, err)
+	}
+	blockIndex := tx.BlockIndex
+	if blockIndex == nil {
+		blockIndex = lookupBlockIndex(ctx, tx.Slot, tx.Signature)
 	}
+	if err := s.db.EnqueueFetched(ctx, tx.Signature, tx.Slot, blockIndex, utils.BlockTime(tx.Result.BlockTime), raw, program); err != nil {
+		return fmt.Errorf("[listener] %w", err)
+	}
+	monitoring.ListenerCurrentSlot.Set(float64(tx.Slot))
+	signal(s.realtime)
+	return nil
+}
 
-	log.Infof("[listener] subscribed to program %s", publicKey.String())
-	readySignal <- struct{}{}
+// signal notifies a channel without blocking; a pending notification already covers this one.
+func signal(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			sub.Unsubscribe()
-			return nil
-		default:
-			msg, err := sub.Recv(ctx)
-			if err != nil {
-				log.Errorf("[listener] WebSocket recv failed for %s: %v", publicKey.String(), err)
-				sub.Unsubscribe()
-				return fmt.Errorf("[listener] recv failed for %s: %w", publicKey.String(), err)
-			}
+// stopped returns a channel that is closed once every watch has unsubscribed.
+func (s *subscriptions) stopped() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		s.watching.Wait()
+		close(done)
+	}()
+	return done
+}
 
-			if msg == nil {
-				continue
-			}
+// add starts watching a program.
+func (s *subscriptions) add(program config.Program, connected chan<- struct{}) error {
+	if _, err := solana.PublicKeyFromBase58(program.Address); err != nil {
+		return fmt.Errorf("[listener] invalid program address %s: %w", program.Address, err)
+	}
 
-			// Push received signature into fetch queue for processing
-			fetchQueue <- fetchTask{
-				Signature: msg.Value.Signature.String(),
-				Program:   publicKey.String(),
-			}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.active[program.Address] = subscription{commitment: program.Commitment, cancel: cancel}
+	s.watching.Add(1)
+	go func() {
+		defer s.watching.Done()
+		s.run(ctx, program, connected)
+	}()
+	return nil
+}
+
+// run keeps a program subscribed until the context ends. A failed subscription reconnects
+// with backoff and first queues the signatures it missed, while the other programs keep streaming.
+// connected is signaled after the first successful subscribe.
+func (s *subscriptions) run(ctx context.Context, program config.Program, connected chan<- struct{}) {
+	live := newLiveness(program)
+	go live.reportIdle(ctx)
+
+	var lastSeen string
+	backoff := minBackoff
+	subscribed := func() {
+		live.subscribed()
+		backoff = minBackoff
+		if connected != nil {
+			connected <- struct{}{}
+			connected = nil
 		}
 	}
+
+	for reconnect := false; ; reconnect = true {
+		err := s.watch(ctx, program, live, &lastSeen, reconnect, subscribed)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("[listener] Subscription of %s failed, reconnecting in %s: %v", program.Address, backoff, err)
+		monitoring.ListenerReconnectsTotal.WithLabelValues(program.Address).Inc()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
 }
 
-// fetchFromQueue processes transactions sequentially from the fetchQueue:
-// it ensures each transaction is fetched from RPC and stored in the DB,
-// and then its signature is passed to the parser stream.
-func fetchFromQueue(ctx context.Context, db *storage.Gorm, queue <-chan fetchTask, stream chan<- string) error {
+// follow subscribes to programs added at runtime and unsubscribes from removed ones
+// until the context ends.
+func (s *subscriptions) follow(changed <-chan struct{}) {
 	for {
 		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case task := <-queue:
-			if err := fetch(ctx, db, task.Program, task.Signature, stream); err != nil {
-				return fmt.Errorf("[listener] fetch failed for transaction %s: %w", task.Signature, err)
+		case <-s.ctx.Done():
+			return
+		case <-changed:
+		}
+		changed = programs.Changed()
+
+		wanted := make(map[string]bool)
+		for _, program := range programs.All() {
+			wanted[program.Address] = true
+			sub, ok := s.active[program.Address]
+			if ok && sub.commitment == program.Commitment {
+				continue
+			}
+			if ok {
+				sub.cancel()
+			}
+			log.Infof("[listener] Subscribing to program %s", program.Address)
+			if err := s.add(program, make(chan struct{}, 1)); err != nil {
+				log.Errorf("[listener] %v", err)
+			}
+		}
+		for address, sub := range s.active {
+			if !wanted[address] {
+				log.Infof("[listener] Unsubscribing from removed program %s", address)
+				sub.cancel()
+				delete(s.active, address)
 			}
-			log.Infof("[listener] Fetched transaction %s for program %s", task.Signature, task.Program)
 		}
 	}
 }
 
-// fetch loads a transaction from the Solana RPC,
-// stores it in the database, and pushes the signature to the parsing stream.
-func fetch(ctx context.Context, db *storage.Gorm, program, signature string, stream chan<- string) error {
-	// Skip if already fetched
-	fetched, err := db.IsRawFetched(ctx, signature)
-	if err != nil {
-		return fmt.Errorf("[listener] failed to check if transaction %s is fetched: %w", signature, err)
-	}
-	if fetched {
-		log.Warnf("[listener] Transaction %s already fetched, skipping...", signature)
-		err := db.AssociateTransactionWithProgram(ctx, signature, program)
-		if err != nil {
-			return fmt.Errorf("[listener] failed to associate transaction %s with program %s: %w", signature, program, err)
+// watch subscribes to the transactions of a single Solana program through the source
+// and queues them, tracking the last signature in lastSeen.
+// It returns nil once the context ends and an error if the subscription fails or the source's
+// keepalive or the watchdog find it stale.
+// With gapFill set, the signatures missed since lastSeen are queued right after subscribing.
+func (s *subscriptions) watch(ctx context.Context, program config.Program, live *liveness, lastSeen *string, gapFill bool, subscribed func()) error {
+	log.Infof("[listener] subscribing for %s...", program.Address)
+
+	// The watchdog ends the subscription with the reason as cause
+	conn, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	err := s.source.Subscribe(conn, program, func() error {
+		subscribed()
+		if interval := config.App.RPC.WatchdogInterval; interval > 0 {
+			go live.watchdog(conn, stop, interval)
+		}
+		if gapFill {
+			return s.fillGap(ctx, program, live, lastSeen)
+		}
+		return nil
+	}, func(tx source.Transaction) error {
+		// Queue the received transaction; if that fails, the reconnect's gap fill picks it up
+		live.saw(tx.Signature, true)
+		if err := s.enqueue(ctx, program.Address, tx); err != nil {
+			return err
 		}
+		*lastSeen = tx.Signature
+		return nil
+	})
+	if ctx.Err() != nil {
 		return nil
 	}
-
-	// Fetch raw transaction from RPC
-	txRes, err := fetcher.FetchRawTransaction(ctx, signature)
-	if err != nil {
-		return fmt.Errorf("[listener] failed to fetch raw transaction %s: %w", signature, err)
+	if conn.Err() != nil {
+		return context.Cause(conn)
 	}
+	return err
+}
 
-	// Serialize raw transaction to JSON
-	raw, err := json.Marshal(txRes)
-	if err != nil {
-		return fmt.Errorf("[listener] failed to marshal raw transaction %s: %w", signature, err)
+// fillGap queues the signatures of a program that were missed while its subscription was down,
+// oldest first. Without a signature seen in this session, the latest saved one is the lower bound.
+func (s *subscriptions) fillGap(ctx context.Context, program config.Program, live *liveness, lastSeen *string) error {
+	since := *lastSeen
+	if since == "" {
+		latest, err := s.db.GetLatestSavedSignature(ctx, program.Address)
+		if err != nil {
+			return fmt.Errorf("[listener] failed to get last saved signature of %s: %w", program.Address, err)
+		}
+		since = latest
 	}
-
-	// Save to database
-	transaction := core.Transaction{
-		Signature: signature,
-		Slot:      txRes.Slot,
-		BlockTime: utils.BlockTime(txRes.BlockTime),
-		JsonTx:    datatypes.JSON(raw),
+	if since == "" {
+		log.Warnf("[listener] No signature of %s seen yet, nothing to fill", program.Address)
+		return nil
 	}
 
-	if err := db.SaveTransaction(ctx, &transaction, program); err != nil {
-		return fmt.Errorf("[listener] failed to save transaction %s: %w", signature, err)
+	sigs, err := fetcher.SignaturesSince(ctx, program, since)
+	if err != nil {
+		return fmt.Errorf("[listener] gap fill failed for %s: %w", program.Address, err)
 	}
-	monitoring.ListenerCurrentSlot.Set(float64(txRes.Slot))
-
-	// Push the signature to the realtime parsing stream
-	select {
-	case stream <- signature:
-		return nil
-	case <-ctx.Done():
-		return nil
+	for _, sig := range sigs {
+		signature := sig.Signature.String()
+		live.saw(signature, false)
+		if err := s.enqueue(ctx, program.Address, source.Transaction{Signature: signature, Slot: sig.Slot}); err != nil {
+			return err
+		}
+		*lastSeen = signature
 	}
+	log.Infof("[listener] Queued %d signatures missed by program %s since %s", len(sigs), program.Address, since)
+	return nil
 }
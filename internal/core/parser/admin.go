@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/control"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"gorm.io/datatypes"
+	"math"
+	"sort"
+)
+
+// Refetch re-downloads the given transactions, parses them again without mapping and replays
+// the subgraph from the earliest affected slot. Unknown transactions are stored for the given program.
+func Refetch(ctx context.Context, db *storage.Gorm, signatures []string, program string) error {
+	type refetched struct {
+		signature string
+		slot      uint64
+	}
+
+	fromSlot := uint64(math.MaxUint64)
+	var txs []refetched
+	for _, sig := range signatures {
+		existing, err := db.GetTransaction(ctx, sig)
+		if err != nil {
+			return fmt.Errorf("[parser] failed to load transaction %s: %w", sig, err)
+		}
+		if existing == nil && program == "" {
+			return fmt.Errorf("[parser] transaction %s is unknown, a program is required to store it", sig)
+		}
+
+		txRes, err := fetcher.FetchRawTransaction(ctx, sig)
+		if err != nil {
+			return fmt.Errorf("[parser] failed to re-fetch transaction %s: %w", sig, err)
+		}
+		if txRes == nil {
+			return fmt.Errorf("[parser] transaction %s not found on chain", sig)
+		}
+		raw, err := json.Marshal(txRes)
+		if err != nil {
+			return fmt.Errorf("[parser] failed to marshal transaction %s: %w", sig, err)
+		}
+
+		if existing == nil {
+			transaction := core.Transaction{
+				Signature: sig,
+				Slot:      txRes.Slot,
+				BlockTime: utils.BlockTime(txRes.BlockTime),
+				JsonTx:    datatypes.JSON(raw),
+			}
+			if err := db.SaveTransaction(ctx, &transaction, program); err != nil {
+				return fmt.Errorf("[parser] failed to save transaction %s: %w", sig, err)
+			}
+		} else {
+			if err := db.ReplaceRawTransaction(ctx, sig, txRes.Slot, utils.BlockTime(txRes.BlockTime), raw); err != nil {
+				return fmt.Errorf("[parser] failed to replace transaction %s: %w", sig, err)
+			}
+			fromSlot = min(fromSlot, existing.Slot)
+		}
+		fromSlot = min(fromSlot, txRes.Slot)
+		txs = append(txs, refetched{signature: sig, slot: txRes.Slot})
+		log.Infof("[parser] Re-fetched transaction %s at slot %d", sig, txRes.Slot)
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].slot < txs[j].slot })
+	for _, tx := range txs {
+		if err := parseStored(ctx, db, tx.signature, false); err != nil {
+			return fmt.Errorf("[parser] failed to re-parse transaction %s: %w", tx.signature, err)
+		}
+	}
+
+	if err := subgraph.RebuildFrom(ctx, db, fromSlot); err != nil {
+		return fmt.Errorf("[parser] failed to rebuild subgraph: %w", err)
+	}
+	return nil
+}
+
+// RefetchRange re-downloads and re-parses all stored transactions in [fromSlot, toSlot].
+func RefetchRange(ctx context.Context, db *storage.Gorm, fromSlot, toSlot uint64) error {
+	txs, err := db.GetTransactionsInSlotRange(ctx, fromSlot, toSlot)
+	if err != nil {
+		return fmt.Errorf("[parser] failed to load transactions to re-fetch: %w", err)
+	}
+	signatures := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		signatures = append(signatures, tx.Signature)
+	}
+	return Refetch(ctx, db, signatures, "")
+}
+
+// Reparse marks all stored transactions in [fromSlot, toSlot] unparsed, parses them again
+// from their stored payloads without mapping and replays the subgraph from fromSlot.
+func Reparse(ctx context.Context, db *storage.Gorm, fromSlot, toSlot uint64) error {
+	txs, err := db.GetTransactionsInSlotRange(ctx, fromSlot, toSlot)
+	if err != nil {
+		return fmt.Errorf("[parser] failed to load transactions to re-parse: %w", err)
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+
+	signatures := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		signatures = append(signatures, tx.Signature)
+	}
+	if err := db.MarkUnparsed(ctx, signatures); err != nil {
+		return fmt.Errorf("[parser] failed to mark transactions unparsed: %w", err)
+	}
+	log.Infof("[parser] Re-parsing %d transactions in slots %d-%d", len(signatures), fromSlot, toSlot)
+
+	for _, sig := range signatures {
+		if err := parseStored(ctx, db, sig, false); err != nil {
+			return fmt.Errorf("[parser] failed to re-parse transaction %s: %w", sig, err)
+		}
+	}
+
+	if err := subgraph.RebuildFrom(ctx, db, fromSlot); err != nil {
+		return fmt.Errorf("[parser] failed to rebuild subgraph: %w", err)
+	}
+	return nil
+}
+
+// MergeProgram parses the backfilled transactions of a program. When transactions at or below
+// the parsed head produced events, the subgraph is replayed from the lowest of them, so the
+// history merges in canonical order; otherwise everything is mapped in place.
+func MergeProgram(ctx context.Context, db *storage.Gorm, program string) error {
+	head, err := db.GetParsedHead(ctx)
+	if err != nil {
+		return fmt.Errorf("[parser] %w", err)
+	}
+	txs, err := db.GetUnparsedTransactions(ctx, program)
+	if err != nil {
+		return fmt.Errorf("[parser] %w", err)
+	}
+	log.Infof("[parser] Merging %d transactions of program %s, parsed head is slot %d", len(txs), program, head)
+
+	// Transactions are in slot order, so the replay point is known before the first one above the head
+	replayFrom := uint64(math.MaxUint64)
+	for _, tx := range txs {
+		mapping := tx.Slot > head && replayFrom == math.MaxUint64
+		if err := parseStored(ctx, db, tx.Signature, mapping); err != nil {
+			return fmt.Errorf("[parser] failed to parse transaction %s: %w", tx.Signature, err)
+		}
+		if tx.Slot > head || replayFrom != math.MaxUint64 {
+			continue
+		}
+		hasEvents, err := db.HasEvents(ctx, tx.Signature)
+		if err != nil {
+			return fmt.Errorf("[parser] %w", err)
+		}
+		if hasEvents {
+			replayFrom = tx.Slot
+		}
+	}
+
+	if replayFrom == math.MaxUint64 {
+		log.Infof("[parser] Program %s merged without a subgraph rebuild", program)
+		return nil
+	}
+	if err := subgraph.RebuildFrom(ctx, db, replayFrom); err != nil {
+		return fmt.Errorf("[parser] failed to rebuild subgraph: %w", err)
+	}
+	return nil
+}
+
+// Backfill fetches the history of a newly added program and merges it in the parser loop.
+func Backfill(ctx context.Context, db *storage.Gorm, program config.Program) error {
+	if _, err := fetcher.Backfill(ctx, db, program.Address, program.StartSlot, math.MaxInt64); err != nil {
+		return fmt.Errorf("[parser] failed to backfill program %s: %w", program.Address, err)
+	}
+	return control.Submit(ctx, "merge program "+program.Address, func(ctx context.Context) error {
+		return MergeProgram(ctx, db, program.Address)
+	})
+}
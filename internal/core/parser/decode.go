@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Decode extracts the events of a raw transaction without saving or mapping them.
+func Decode(ctx context.Context, db storage.TransactionStore, rawTx []byte, sig string) ([]core.Event, error) {
+	var tx rpc.GetTransactionResult
+	if err := json.Unmarshal(rawTx, &tx); err != nil {
+		return nil, fmt.Errorf("[parser] unmarshal tx JSON: %w", err)
+	}
+	if tx.Meta == nil || tx.Meta.LogMessages == nil {
+		return nil, nil
+	}
+
+	var decoded []core.Event
+	collect := storeOnly(&decoded)
+
+	if err := parseTokenInstructions(ctx, db, sig, &tx, collect); err != nil {
+		return nil, fmt.Errorf("[parser] error parsing instructions in %s: %w", sig, err)
+	}
+	if err := parseLogs(ctx, sig, &tx, collect); err != nil {
+		return nil, fmt.Errorf("[parser] error parsing logs in %s: %w", sig, err)
+	}
+	return decoded, nil
+}
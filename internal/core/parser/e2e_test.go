@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/rpcreplay"
+	"github.com/Tsisar/solana-indexer/internal/core/source"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "write the golden file of the end-to-end test")
+
+// e2eProgram is the program the fixtures in testdata/e2e were recorded for.
+const e2eProgram = "AJCGiygDjwN8ZS7hsRUHCNWJSmcPeSdn7egXfLJN5Gbp"
+
+// TestEndToEnd fetches the history of a program from recorded RPC responses, parses and maps it
+// into a memory store and compares the resulting subgraph state with the golden file. The
+// transactions are found both as the historical fetch does, by signatures, and as the listener
+// does, by the logs subscription of its WebSocket source. Run with -update to rewrite the golden
+// file after an intended change of the mappings.
+func TestEndToEnd(t *testing.T) {
+	dir := filepath.Join("testdata", "e2e")
+	fixtures, err := rpcreplay.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay, err := rpcreplay.NewServer(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay.SlotInterval = 10 * time.Millisecond
+	server := httptest.NewServer(replay)
+	defer server.Close()
+
+	// The RPC clients read the endpoints on each call
+	endpoints := config.App.RPC.Endpoints
+	config.App.RPC.Endpoints = []string{server.URL}
+	defer func() { config.App.RPC.Endpoints = endpoints }()
+
+	ctx := context.Background()
+	program := config.NewProgram(e2eProgram)
+
+	t.Run("historical", func(t *testing.T) {
+		sigs, err := fetcher.SignaturesSince(ctx, program, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var txs []source.Transaction
+		for _, sig := range sigs {
+			txs = append(txs, source.Transaction{Signature: sig.Signature.String(), Slot: sig.Slot})
+		}
+		checkEndToEnd(t, filepath.Join(dir, "golden.json"), program, txs, *update)
+	})
+
+	t.Run("realtime", func(t *testing.T) {
+		ws := &source.WebSocket{Endpoint: "ws" + strings.TrimPrefix(server.URL, "http")}
+		subCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		var txs []source.Transaction
+		err := ws.Subscribe(subCtx, program, func() error { return nil }, func(tx source.Transaction) error {
+			txs = append(txs, tx)
+			if len(txs) == len(fixtures.Notifications) {
+				cancel()
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txs) != len(fixtures.Notifications) {
+			t.Fatalf("expected %d notifications, got %d", len(fixtures.Notifications), len(txs))
+		}
+		checkEndToEnd(t, filepath.Join(dir, "golden.json"), program, txs, false)
+	})
+}
+
+// checkEndToEnd fetches, parses and maps the transactions into a new memory store and compares
+// the subgraph state with the golden file.
+func checkEndToEnd(t *testing.T, golden string, program config.Program, txs []source.Transaction, update bool) {
+	ctx := context.Background()
+	db := memory.New()
+
+	if len(txs) == 0 {
+		t.Fatal("expected recorded transactions")
+	}
+	for _, tx := range txs {
+		result, err := fetcher.FetchRawTransaction(ctx, tx.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Slot != tx.Slot {
+			t.Errorf("expected transaction %s at slot %d, got %d", tx.Signature, tx.Slot, result.Slot)
+		}
+		raw, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveTransaction(ctx, &core.Transaction{
+			Signature: tx.Signature,
+			Slot:      result.Slot,
+			BlockTime: utils.BlockTime(result.BlockTime),
+			JsonTx:    raw,
+		}, program.Address); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Parse and map in the order of the parser
+	pending, err := db.GetOrderedNoParsedSignatures(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != len(txs) {
+		t.Fatalf("expected %d transactions to parse, got %d", len(txs), len(pending))
+	}
+	for _, sig := range pending {
+		if err := parseOneTransaction(ctx, db, false, sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ev := range db.Events() {
+		if !slices.Contains(pending, ev.TransactionSignature) {
+			t.Errorf("unexpected event %s of %s", ev.Name, ev.TransactionSignature)
+		}
+	}
+
+	state, err := rpcreplay.Snapshot(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Vaults) == 0 || len(state.Strategies) == 0 || len(state.AccountVaultPositions) == 0 {
+		t.Errorf("expected vaults, strategies and account vault positions, got %d, %d and %d",
+			len(state.Vaults), len(state.Strategies), len(state.AccountVaultPositions))
+	}
+	diff, err := rpcreplay.CompareGolden(golden, state, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("state differs from the golden file at %s", diff)
+	}
+}
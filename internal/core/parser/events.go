@@ -7,9 +7,7 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/core/events"
-	"github.com/Tsisar/solana-indexer/internal/storage"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
-	"github.com/Tsisar/solana-indexer/internal/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/utils"
 	"github.com/gagliardetto/solana-go/rpc"
 	"gorm.io/datatypes"
@@ -18,13 +16,20 @@ import (
 
 // parseLogs processes the log messages from a transaction,
 // identifying any base64-encoded event logs and parsing them into structured events.
-func parseLogs(ctx context.Context, db *storage.Gorm, sig string, tx *rpc.GetTransactionResult) error {
+// Events emitted by programs configured without a mapper are skipped.
+func parseLogs(ctx context.Context, sig string, tx *rpc.GetTransactionResult, emit eventSink) error {
 	timestamp := utils.BlockTime(tx.BlockTime)
 
+	var stack invocations
 	for idx, msg := range tx.Meta.LogMessages {
+		stack.observe(msg)
+		if !mapsEvents(stack.current()) {
+			continue
+		}
+
 		// Look only for logs that start with the expected prefix
 		if strings.HasPrefix(msg, "Program data: ") {
-			if err := handleLogData(ctx, db, msg, sig, tx.Slot, timestamp, idx); err != nil {
+			if err := handleLogData(ctx, emit, msg, sig, tx.Slot, timestamp, idx); err != nil {
 				return err
 			}
 		}
@@ -39,8 +44,8 @@ func parseLogs(ctx context.Context, db *storage.Gorm, sig string, tx *rpc.GetTra
 // 2. Extracts the 8-byte discriminator.
 // 3. Looks up the corresponding event name and decoder function.
 // 4. Decodes the event payload.
-// 5. Serializes it to JSON and stores the result in the database.
-func handleLogData(ctx context.Context, db *storage.Gorm, msg, sig string, slot uint64, blockTime int64, index int) error {
+// 5. Serializes it to JSON and emits the resulting event.
+func handleLogData(ctx context.Context, emit eventSink, msg, sig string, slot uint64, blockTime int64, index int) error {
 	// 1. Strip "Program data: " prefix and decode from base64
 	rawB64 := strings.TrimPrefix(msg, "Program data: ")
 	data, err := base64.StdEncoding.DecodeString(rawB64)
@@ -85,21 +90,15 @@ func handleLogData(ctx context.Context, db *storage.Gorm, msg, sig string, slot
 		return fmt.Errorf("[parser] failed to marshal event value: %w", err)
 	}
 
-	// 8. Save the event to the database
+	// 8. Emit the event, e.g. to store and map it
 	evRecord := core.Event{
 		TransactionSignature: sig,
 		Slot:                 slot,
 		LogIndex:             2000 + index, // 2000+offset to avoid collisions with other log types
 		BlockTime:            blockTime,
 		Name:                 eventName,
+		Kind:                 core.EventKindProgram,
 		JsonEv:               datatypes.JSON(jsonVal),
 	}
-	if err := db.SaveEvent(ctx, evRecord); err != nil {
-		return fmt.Errorf("[parser] save event %s: %w", eventName, err)
-	}
-
-	// 9. Map event for subgraph processing
-	subgraph.MapEvent(ctx, db, evRecord)
-
-	return nil
+	return emit(ctx, evRecord)
 }
@@ -2,38 +2,47 @@ package parser
 
 import (
 	"context"
-	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
-	"github.com/Tsisar/solana-indexer/internal/config"
 	"github.com/Tsisar/solana-indexer/internal/core/events"
+	"github.com/Tsisar/solana-indexer/internal/core/rpcpool"
 	"github.com/Tsisar/solana-indexer/internal/storage"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
-	"github.com/Tsisar/solana-indexer/internal/subgraph"
 	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
 	"github.com/gagliardetto/solana-go/programs/token"
 	"github.com/gagliardetto/solana-go/rpc"
+	"math"
 )
 
-var client = rpc.New(config.App.RPCEndpoint)
+var client = rpcpool.New()
 
 // parseTokenInstructions processes token-related inner instructions from a transaction.
-// It resolves address table lookups if needed and decodes each known SPL token instruction.
-func parseTokenInstructions(ctx context.Context, db *storage.Gorm, sig string, tx *rpc.GetTransactionResult) error {
+// It resolves address table lookups if needed and decodes each known SPL token instruction,
+// unless none of the programs mentioned by the transaction decodes instructions.
+func parseTokenInstructions(ctx context.Context, db storage.TransactionStore, sig string, tx *rpc.GetTransactionResult, emit eventSink) error {
 	parsedTx, err := tx.Transaction.GetTransaction()
 	if err != nil {
 		return fmt.Errorf("[parser] get transaction: %w", err)
 	}
 	msg := &parsedTx.Message
+	if !decodesInstructions(mentionedPrograms(msg)) {
+		log.Debugf("[parser] Instruction decoding disabled for transaction %s", sig)
+		return nil
+	}
 
-	if err := resolveAddressLookupsIfNeeded(ctx, msg); err != nil {
+	if err := resolveAddressLookupsIfNeeded(ctx, db, msg, tx); err != nil {
 		return fmt.Errorf("[parser] resolve lookups for tx %s: %w", sig, err)
 	}
 
 	// Parse top-level instructions
 	for i, instr := range msg.Instructions {
-		if err := processInstruction(ctx, db, msg, sig, tx, 0, i, &instr); err != nil {
+		if err := processInstruction(ctx, emit, msg, sig, tx, 0, i, &instr); err != nil {
+			if errors.As(err, new(*mappingError)) {
+				return err
+			}
 			log.Warnf("[parser] top-level parse error: %v", err)
 		}
 	}
@@ -46,7 +55,10 @@ func parseTokenInstructions(ctx context.Context, db *storage.Gorm, sig string, t
 
 	for _, inner := range tx.Meta.InnerInstructions {
 		for i, innerInstr := range inner.Instructions {
-			if err := processInstruction(ctx, db, msg, sig, tx, inner.Index, i, &innerInstr); err != nil {
+			if err := processInstruction(ctx, emit, msg, sig, tx, inner.Index, i, &innerInstr); err != nil {
+				if errors.As(err, new(*mappingError)) {
+					return err
+				}
 				log.Warnf("[parser] inner parse error: %v", err)
 			}
 		}
@@ -56,8 +68,8 @@ func parseTokenInstructions(ctx context.Context, db *storage.Gorm, sig string, t
 }
 
 // processInstruction attempts to decode and map an SPL token instruction from the given compiled instruction.
-// If the instruction is known, it stores a corresponding event in the database and notifies the subgraph.
-func processInstruction(ctx context.Context, db *storage.Gorm, msg *solana.Message, sig string,
+// If the instruction is known, it emits a corresponding event.
+func processInstruction(ctx context.Context, emit eventSink, msg *solana.Message, sig string,
 	tx *rpc.GetTransactionResult, instrIndex uint16, innerIndex int, instr *solana.CompiledInstruction,
 ) error {
 	if len(instr.Data) == 0 || instr.Data[0] > token.Instruction_InitializeMint2 {
@@ -118,32 +130,32 @@ func processInstruction(ctx context.Context, db *storage.Gorm, msg *solana.Messa
 		BlockTime:            blockTime,
 		LogIndex:             1000 + int(instrIndex+1)*100 + innerIndex,
 		Name:                 name,
+		Kind:                 core.EventKindInstruction,
 	}
 	evRecord.JsonEv, _ = json.Marshal(mapped)
 
-	if err := db.SaveEvent(ctx, evRecord); err != nil {
-		return fmt.Errorf("[parser] save event %s: %w", evRecord.Name, err)
-	}
-
-	subgraph.MapInstruction(ctx, db, evRecord)
-	return nil
+	return emit(ctx, evRecord)
 }
 
 // resolveAddressLookupsIfNeeded resolves address table lookups for versioned transactions.
-// It fetches Lookup Table (LUT) data as needed and populates the message with resolved addresses.
-func resolveAddressLookupsIfNeeded(ctx context.Context, msg *solana.Message) error {
+// It prefers the loadedAddresses already present in the transaction metadata and only
+// falls back to slot-checked Lookup Table (LUT) snapshots when they are missing.
+func resolveAddressLookupsIfNeeded(ctx context.Context, db storage.TransactionStore, msg *solana.Message, tx *rpc.GetTransactionResult) error {
 	if msg.IsVersioned() && len(msg.AddressTableLookups) > 0 && !msg.IsResolved() {
-		addressTables := make(map[solana.PublicKey]solana.PublicKeySlice)
+		addressTables, ok := addressTablesFromMeta(msg, tx.Meta)
+		if !ok {
+			addressTables = make(map[solana.PublicKey]solana.PublicKeySlice)
 
-		for _, lookup := range msg.AddressTableLookups {
-			maxIndex := maxUint8Slice(lookup.ReadonlyIndexes, lookup.WritableIndexes)
+			for _, lookup := range msg.AddressTableLookups {
+				maxIndex := maxUint8Slice(lookup.ReadonlyIndexes, lookup.WritableIndexes)
 
-			addresses, err := getOrFetchLUT(ctx, lookup.AccountKey, int(maxIndex))
-			if err != nil {
-				return fmt.Errorf("[parser] failed to fetch LUT %s: %w", lookup.AccountKey, err)
-			}
+				addresses, err := getOrFetchLUT(ctx, db, lookup.AccountKey, int(maxIndex), tx.Slot)
+				if err != nil {
+					return fmt.Errorf("[parser] failed to fetch LUT %s: %w", lookup.AccountKey, err)
+				}
 
-			addressTables[lookup.AccountKey] = addresses
+				addressTables[lookup.AccountKey] = addresses
+			}
 		}
 
 		if err := msg.SetAddressTables(addressTables); err != nil {
@@ -235,14 +247,15 @@ func mapTokenInstruction(inst interface{}) (string, any) {
 }
 
 // fetchAddressLookupTable fetches and decodes a Lookup Table (LUT) account from the blockchain.
-// Returns a list of resolved addresses up to the given index.
-func fetchAddressLookupTable(ctx context.Context, address solana.PublicKey) (solana.PublicKeySlice, error) {
+// The returned snapshot records the slot it was read at, together with the
+// last-extended and deactivation slots needed for slot-aware resolution.
+func fetchAddressLookupTable(ctx context.Context, address solana.PublicKey) (*lutCacheEntry, error) {
 	resp, err := client.GetAccountInfoWithOpts(
 		ctx,
 		address,
 		&rpc.GetAccountInfoOpts{
-			Encoding:   "base64",
-			Commitment: rpc.CommitmentFinalized,
+			Encoding:   solana.EncodingBase64,
+			Commitment: rpc.CommitmentConfirmed,
 		},
 	)
 	if err != nil {
@@ -252,26 +265,20 @@ func fetchAddressLookupTable(ctx context.Context, address solana.PublicKey) (sol
 		return nil, fmt.Errorf("[parser] empty LUT account data")
 	}
 
-	data := resp.Value.Data.GetBinary()
-	if len(data) < 8 {
-		return nil, fmt.Errorf("[parser] invalid LUT data (too short)")
+	state, err := addresslookuptable.DecodeAddressLookupTableState(resp.Value.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("[parser] invalid LUT data: %w", err)
 	}
 
-	numAddresses := binary.LittleEndian.Uint32(data[4:8])
-	available := (len(data) - 8) / 32
-	if available < int(numAddresses) {
-		numAddresses = uint32(available)
+	entry := &lutCacheEntry{
+		Addresses:                  state.Addresses,
+		LastExtendedSlot:           state.LastExtendedSlot,
+		LastExtendedSlotStartIndex: int(state.LastExtendedSlotStartIndex),
+		FetchedSlot:                resp.Context.Slot,
 	}
-
-	addresses := make([]solana.PublicKey, 0, numAddresses)
-	offset := 8
-
-	for i := uint32(0); i < numAddresses; i++ {
-		var pub solana.PublicKey
-		copy(pub[:], data[offset:offset+32])
-		addresses = append(addresses, pub)
-		offset += 32
+	if state.DeactivationSlot != math.MaxUint64 {
+		entry.DeactivationSlot = &state.DeactivationSlot
 	}
-
-	return addresses, nil
+	log.Debugf("[parser] Fetched LUT %s at slot %d: %d addresses", address, entry.FetchedSlot, len(entry.Addresses))
+	return entry, nil
 }
@@ -2,25 +2,50 @@ package parser
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"gorm.io/datatypes"
 	"sync"
-	"time"
 )
 
-// lutCacheEntry represents a cached result of a fetched Address Lookup Table (LUT).
-// It stores the decoded addresses, how many addresses there are, and when it was cached.
+// lutCacheEntry is a decoded Address Lookup Table snapshot.
+// It mirrors a row of core.lookup_tables and is kept in memory in front of it.
 type lutCacheEntry struct {
-	Addresses solana.PublicKeySlice
-	Count     int
-	Timestamp time.Time
+	Addresses                  solana.PublicKeySlice
+	LastExtendedSlot           uint64
+	LastExtendedSlotStartIndex int
+	DeactivationSlot           *uint64
+	FetchedSlot                uint64
 }
 
 var (
-	lutCache     sync.Map   // Caches LUT results by PublicKey
+	lutCache     sync.Map   // Caches LUT snapshots by PublicKey
 	lutCacheLock sync.Mutex // Prevents concurrent LUT fetches for the same key
 )
 
-const lutTTL = 24 * time.Hour // Time-to-live for LUT cache entries
+// lutDeactivationCooldown is the number of slots a deactivated table can still be
+// used for lookups (the length of the SlotHashes sysvar plus one).
+const lutDeactivationCooldown = 513
+
+// availableAt reports whether the address at index was already part of the table
+// at the given slot. Addresses appended by an extension become usable in the next slot.
+func (e *lutCacheEntry) availableAt(index int, slot uint64) bool {
+	if index >= len(e.Addresses) {
+		return false
+	}
+	if index >= e.LastExtendedSlotStartIndex && slot <= e.LastExtendedSlot {
+		return false
+	}
+	if e.DeactivationSlot != nil && slot > *e.DeactivationSlot+lutDeactivationCooldown {
+		return false
+	}
+	return true
+}
 
 // maxUint8Slice returns the maximum value found in two uint8 slices.
 func maxUint8Slice(a, b []uint8) uint8 {
@@ -38,19 +63,17 @@ func maxUint8Slice(a, b []uint8) uint8 {
 	return max
 }
 
-// getOrFetchLUT returns cached addresses for the given lookup table key,
-// or fetches them from the chain if not cached, outdated, or incomplete.
+// getOrFetchLUT returns the addresses of the given lookup table as they were at the given slot.
 //
-// expectedMaxIndex is used to ensure that the cache contains enough addresses.
-// This function double-checks the cache before and after acquiring a lock to
-// avoid redundant RPC calls in concurrent contexts.
-func getOrFetchLUT(ctx context.Context, key solana.PublicKey, expectedMaxIndex int) (solana.PublicKeySlice, error) {
+// The in-memory cache is checked first, then the snapshot stored in core.lookup_tables,
+// and only then the chain. A snapshot is refetched when it does not contain
+// expectedMaxIndex yet (the table was extended after the snapshot was taken).
+// An error is returned if the index did not exist at the transaction's slot.
+func getOrFetchLUT(ctx context.Context, db storage.TransactionStore, key solana.PublicKey, expectedMaxIndex int, slot uint64) (solana.PublicKeySlice, error) {
 	// First optimistic cache read
 	if val, ok := lutCache.Load(key); ok {
-		entry := val.(lutCacheEntry)
-
-		// Check if the cache is fresh and sufficient
-		if time.Since(entry.Timestamp) < lutTTL && expectedMaxIndex < entry.Count {
+		entry := val.(*lutCacheEntry)
+		if entry.availableAt(expectedMaxIndex, slot) {
 			return entry.Addresses, nil
 		}
 	}
@@ -60,25 +83,137 @@ func getOrFetchLUT(ctx context.Context, key solana.PublicKey, expectedMaxIndex i
 	defer lutCacheLock.Unlock()
 
 	// Check again inside the lock to avoid race conditions
+	var entry *lutCacheEntry
 	if val, ok := lutCache.Load(key); ok {
-		entry := val.(lutCacheEntry)
-		if time.Since(entry.Timestamp) < lutTTL && expectedMaxIndex < entry.Count {
-			return entry.Addresses, nil
+		entry = val.(*lutCacheEntry)
+	}
+
+	// Fall back to the persisted snapshot
+	if entry == nil || expectedMaxIndex >= len(entry.Addresses) {
+		stored, err := loadStoredLUT(ctx, db, key)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			entry = stored
+			lutCache.Store(key, entry)
+		}
+	}
+
+	// Fetch from blockchain if nothing is stored or the snapshot is too old
+	if entry == nil || expectedMaxIndex >= len(entry.Addresses) {
+		fetched, err := fetchAddressLookupTable(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveLUT(ctx, db, key, fetched); err != nil {
+			return nil, err
+		}
+		entry = fetched
+		lutCache.Store(key, entry)
+	}
+
+	if !entry.availableAt(expectedMaxIndex, slot) {
+		return nil, fmt.Errorf("[parser] LUT %s index %d not available at slot %d (size %d, last extended at %d from index %d)",
+			key, expectedMaxIndex, slot, len(entry.Addresses), entry.LastExtendedSlot, entry.LastExtendedSlotStartIndex)
+	}
+	return entry.Addresses, nil
+}
+
+// addressTablesFromMeta rebuilds the lookup tables referenced by msg from the
+// loadedAddresses that the RPC node already resolved at the transaction's slot.
+// Returns false if the metadata does not carry a complete resolution.
+func addressTablesFromMeta(msg *solana.Message, meta *rpc.TransactionMeta) (map[solana.PublicKey]solana.PublicKeySlice, bool) {
+	if meta == nil {
+		return nil, false
+	}
+
+	var writableCount, readonlyCount int
+	for _, lookup := range msg.AddressTableLookups {
+		writableCount += len(lookup.WritableIndexes)
+		readonlyCount += len(lookup.ReadonlyIndexes)
+	}
+	loaded := meta.LoadedAddresses
+	if writableCount+readonlyCount == 0 ||
+		len(loaded.Writable) != writableCount ||
+		len(loaded.ReadOnly) != readonlyCount {
+		return nil, false
+	}
+
+	// Loaded addresses are ordered as all writable lookups first, then all readonly ones.
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice)
+	var w, r int
+	for _, lookup := range msg.AddressTableLookups {
+		table := tables[lookup.AccountKey]
+		size := int(maxUint8Slice(lookup.ReadonlyIndexes, lookup.WritableIndexes)) + 1
+		if len(table) < size {
+			grown := make(solana.PublicKeySlice, size)
+			copy(grown, table)
+			table = grown
 		}
+		for _, idx := range lookup.WritableIndexes {
+			table[idx] = loaded.Writable[w]
+			w++
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			table[idx] = loaded.ReadOnly[r]
+			r++
+		}
+		tables[lookup.AccountKey] = table
 	}
+	return tables, true
+}
 
-	// Fetch from blockchain
-	addresses, err := fetchAddressLookupTable(ctx, key)
+// loadStoredLUT reads a lookup table snapshot from core.lookup_tables.
+// Returns nil if the table has never been stored.
+func loadStoredLUT(ctx context.Context, db storage.TransactionStore, key solana.PublicKey) (*lutCacheEntry, error) {
+	lut, err := db.GetLookupTable(ctx, key.String())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("[parser] failed to load LUT %s: %w", key, err)
+	}
+	if lut == nil {
+		return nil, nil
 	}
 
-	// Cache the result
-	lutCache.Store(key, lutCacheEntry{
-		Addresses: addresses,
-		Count:     len(addresses),
-		Timestamp: time.Now(),
-	})
+	var addresses []string
+	if err := json.Unmarshal(lut.Addresses, &addresses); err != nil {
+		return nil, fmt.Errorf("[parser] failed to decode stored LUT %s: %w", key, err)
+	}
+	entry := &lutCacheEntry{
+		Addresses:                  make(solana.PublicKeySlice, 0, len(addresses)),
+		LastExtendedSlot:           lut.LastExtendedSlot,
+		LastExtendedSlotStartIndex: lut.LastExtendedSlotStartIndex,
+		DeactivationSlot:           lut.DeactivationSlot,
+		FetchedSlot:                lut.FetchedSlot,
+	}
+	for _, address := range addresses {
+		pub, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("[parser] invalid address %q in stored LUT %s: %w", address, key, err)
+		}
+		entry.Addresses = append(entry.Addresses, pub)
+	}
+	log.Debugf("[parser] Loaded LUT %s from DB (%d addresses, fetched at slot %d)", key, len(entry.Addresses), entry.FetchedSlot)
+	return entry, nil
+}
 
-	return addresses, nil
+// saveLUT persists a freshly fetched lookup table snapshot to core.lookup_tables.
+func saveLUT(ctx context.Context, db storage.TransactionStore, key solana.PublicKey, entry *lutCacheEntry) error {
+	addresses := make([]string, 0, len(entry.Addresses))
+	for _, pub := range entry.Addresses {
+		addresses = append(addresses, pub.String())
+	}
+	raw, err := json.Marshal(addresses)
+	if err != nil {
+		return fmt.Errorf("[parser] failed to encode LUT %s: %w", key, err)
+	}
+
+	return db.SaveLookupTable(ctx, &core.LookupTable{
+		Address:                    key.String(),
+		Addresses:                  datatypes.JSON(raw),
+		LastExtendedSlot:           entry.LastExtendedSlot,
+		LastExtendedSlotStartIndex: entry.LastExtendedSlotStartIndex,
+		DeactivationSlot:           entry.DeactivationSlot,
+		FetchedSlot:                entry.FetchedSlot,
+	})
 }
@@ -3,19 +3,45 @@ package parser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/control"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/reconciler"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
 	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/utils"
 	"github.com/gagliardetto/solana-go/rpc"
+	"time"
 )
 
+// Store is the storage that parsing a stored transaction needs. *storage.Gorm keeps it in Postgres,
+// memory.Store in memory for tests.
+type Store = storage.ParseStore
+
+// pendingBatch is the number of pending signatures loaded per query.
+const pendingBatch = 100
+
+// ingested signals transactions queued with their payload outside the listener, e.g. by the webhook.
+var ingested = make(chan struct{}, 1)
+
+// Wake makes the parser look at the real-time queue, which it otherwise does when the listener signals it.
+func Wake() {
+	select {
+	case ingested <- struct{}{}:
+	default:
+	}
+}
+
 // Start processes all unparsed transactions from the DB
-// and then continues parsing from the real-time stream.
+// and then continues parsing the real-time queue whenever realtime is signaled.
 // Returns error if any transaction fails to parse.
-func Start(ctx context.Context, db *storage.Gorm, resume bool, done chan struct{}, in <-chan string) error {
+func Start(ctx context.Context, db *storage.Gorm, resume bool, done chan struct{}, realtime <-chan struct{}) error {
 	// Load list of signatures that are not parsed
 	signatures, err := db.GetOrderedNoParsedSignatures(ctx, resume)
 	if err != nil {
@@ -23,31 +49,168 @@ func Start(ctx context.Context, db *storage.Gorm, resume bool, done chan struct{
 	}
 	log.Infof("[parser] Found %d transactions to parse", len(signatures))
 
+	// Transactions behind the parsed head, e.g. the history of a newly added program, can't be
+	// mapped in place. They are only stored and merged by replaying the subgraph from the lowest one.
+	behind, replayFrom, err := behindHead(ctx, db, resume)
+	if err != nil {
+		return err
+	}
+
+	// A transaction being parsed on shutdown is finished within the shutdown timeout
+	work, cancel := utils.Graceful(ctx, config.App.ShutdownTimeout)
+	defer cancel()
+
 	for _, sig := range signatures {
-		if err := parseOneTransaction(ctx, db, resume, sig); err != nil {
+		if err := control.WaitResumed(ctx); err != nil {
+			return nil
+		}
+		if behind {
+			err = parseStored(work, db, sig, false)
+		} else {
+			err = parseOneTransaction(work, db, resume, sig)
+		}
+		if err != nil {
 			return fmt.Errorf("[parser] failed to parse transaction %s: %w", sig, err)
 		}
 	}
+	if behind {
+		log.Infof("[parser] Merging transactions behind the parsed head from slot %d", replayFrom)
+		if err := subgraph.RebuildFrom(ctx, db, replayFrom); err != nil {
+			return fmt.Errorf("[parser] failed to rebuild subgraph: %w", err)
+		}
+	}
 	done <- struct{}{}
 
-	log.Infof("[parser] done with DB, switching to real-time stream...")
+	log.Infof("[parser] done with DB, switching to real-time queue...")
 
-	// Switch to processing incoming signatures from WebSocket stream
+	// Reconciliation runs in the same loop so it never interleaves with real-time parsing
+	var reconcile <-chan time.Time
+	if config.App.ReconcileInterval > 0 {
+		reconcileTicker := time.NewTicker(config.App.ReconcileInterval)
+		defer reconcileTicker.Stop()
+		reconcile = reconcileTicker.C
+	}
+	reparse := func(ctx context.Context, sig string) error {
+		return parseOneTransaction(ctx, db, false, sig)
+	}
+
+	// Switch to parsing the pending transactions fetched by the listener.
+	// While paused, they stay queued in the DB and only admin jobs are run.
+	if !control.Paused() {
+		if _, err := parsePending(work, db); err != nil {
+			return err
+		}
+	}
 	for {
+		paused, changed := control.State()
+		queue, wake := realtime, ingested
+		if paused {
+			queue, wake = nil, nil
+		}
+
 		select {
 		case <-ctx.Done():
 			log.Debugf("[parser] context cancelled")
-			return nil
-		case sig := <-in:
+			if paused {
+				return nil
+			}
+			return drain(work, db, realtime)
+		case <-changed:
+		case _, ok := <-queue:
+			if !ok {
+				// The listener only closes the queue signal once the cycle is stopping
+				return nil
+			}
+			if _, err := parsePending(work, db); err != nil {
+				return err
+			}
+		case <-wake:
+			if _, err := parsePending(work, db); err != nil {
+				return err
+			}
+		case <-reconcile:
+			if paused {
+				continue
+			}
+			if err := reconciler.Reconcile(ctx, db, reparse); err != nil {
+				log.Errorf("[parser] finalized reconciliation failed: %v", err)
+			}
+		case job := <-control.Jobs():
+			job.Finish(ctx)
+		}
+	}
+}
+
+// parsePending parses the fetched transactions of the real-time queue in slot order
+// until none are ready, and returns how many it parsed.
+func parsePending(ctx context.Context, db *storage.Gorm) (int, error) {
+	parsed := 0
+	for ctx.Err() == nil {
+		signatures, err := db.GetPendingFetched(ctx, pendingBatch)
+		if err != nil {
+			return parsed, fmt.Errorf("[parser] %w", err)
+		}
+		if len(signatures) == 0 {
+			break
+		}
+		for _, sig := range signatures {
 			if err := parseOneTransaction(ctx, db, true, sig); err != nil {
-				return fmt.Errorf("[parser] failed to parse real-time transaction %s: %w", sig, err)
+				return parsed, fmt.Errorf("[parser] failed to parse real-time transaction %s: %w", sig, err)
+			}
+			parsed++
+		}
+	}
+	return parsed, nil
+}
+
+// drain keeps parsing the real-time queue on shutdown until the listener has fetched what it could
+// and closed realtime, or the work context ends. Whatever is left stays pending in the DB
+// and is parsed on restart.
+func drain(work context.Context, db *storage.Gorm, realtime <-chan struct{}) error {
+	total := 0
+	for {
+		parsed, err := parsePending(work, db)
+		total += parsed
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-work.Done():
+			log.Warnf("[parser] Shutdown timeout reached after draining %d transactions, the rest is parsed on restart", total)
+			return nil
+		case _, ok := <-realtime:
+			if !ok {
+				parsed, err := parsePending(work, db)
+				if err != nil {
+					return err
+				}
+				log.Infof("[parser] Real-time queue drained: %d transactions parsed", total+parsed)
+				return nil
 			}
 		}
 	}
 }
 
+// behindHead reports whether resumed parsing starts at or below the highest parsed slot
+// and returns the lowest unparsed slot.
+func behindHead(ctx context.Context, db *storage.Gorm, resume bool) (bool, uint64, error) {
+	if !resume {
+		return false, 0, nil
+	}
+	head, err := db.GetParsedHead(ctx)
+	if err != nil {
+		return false, 0, fmt.Errorf("[parser] %w", err)
+	}
+	lowest, ok, err := db.GetMinUnparsedSlot(ctx)
+	if err != nil {
+		return false, 0, fmt.Errorf("[parser] %w", err)
+	}
+	return ok && head > 0 && lowest <= head, lowest, nil
+}
+
 // parseOneTransaction coordinates parsing of one transaction from DB by signature.
-func parseOneTransaction(ctx context.Context, db *storage.Gorm, resume bool, sig string) error {
+func parseOneTransaction(ctx context.Context, db Store, resume bool, sig string) error {
 	if resume {
 		parsed, err := db.IsParsed(ctx, sig)
 		if err != nil {
@@ -59,27 +222,79 @@ func parseOneTransaction(ctx context.Context, db *storage.Gorm, resume bool, sig
 		}
 	}
 
+	return parseStored(ctx, db, sig, true)
+}
+
+// parseStored parses a stored transaction and marks it parsed. Without mapping the events are
+// only stored, e.g. when the subgraph is replayed from them afterwards. The events, the entity
+// changes and the parsed flag are written together; a mapping error is recorded in the subgraph
+// metadata and ends the indexer.
+func parseStored(ctx context.Context, db Store, sig string, mapping bool) error {
 	// Retrieve raw transaction from DB
 	rawTx, err := db.GetRawTransaction(ctx, sig)
 	if err != nil {
 		return fmt.Errorf("[parser] failed to get raw transaction %s: %w", sig, err)
 	}
-
-	// Parse and store token instructions and logs
-	if err := parseTransaction(ctx, db, rawTx, sig); err != nil {
-		return fmt.Errorf("[parser] failed to parse transaction %s: %w", sig, err)
+	if len(rawTx) == 0 {
+		// The payload was pruned by the retention; it is fetched for this parse only
+		if rawTx, err = refetchRaw(ctx, sig); err != nil {
+			return err
+		}
 	}
 
-	// Mark transaction as parsed in DB
-	if err := db.MarkParsed(ctx, sig); err != nil {
-		return fmt.Errorf("[parser] failed to mark %s as parsed: %w", sig, err)
+	err = db.Atomically(ctx, func(tx Store) error {
+		// Parse and store token instructions and logs
+		if err := parseTransaction(ctx, tx, rawTx, sig, mapping); err != nil {
+			return fmt.Errorf("[parser] failed to parse transaction %s: %w", sig, err)
+		}
+
+		// Mark transaction as parsed in DB
+		if err := tx.MarkParsed(ctx, sig); err != nil {
+			return fmt.Errorf("[parser] failed to mark %s as parsed: %w", sig, err)
+		}
+		return nil
+	})
+	var mapErr *mappingError
+	if errors.As(err, &mapErr) {
+		// The changes of the transaction were discarded, the error is recorded outside of it
+		subgraph.MapError(ctx, db, mapErr.err)
+		log.Fatalf("[parser] Failed to map transaction %s: %v", sig, mapErr.err)
 	}
+	return err
+}
 
-	return nil
+// mappingError is an error of the subgraph mappings, as opposed to one of parsing or storage.
+type mappingError struct {
+	err error
+}
+
+func (e *mappingError) Error() string {
+	return fmt.Sprintf("failed to map: %v", e.err)
+}
+
+func (e *mappingError) Unwrap() error {
+	return e.err
+}
+
+// refetchRaw fetches the raw payload of a stored transaction that has none.
+func refetchRaw(ctx context.Context, sig string) ([]byte, error) {
+	log.Infof("[parser] Transaction %s has no raw payload, fetching it from RPC", sig)
+	txRes, err := fetcher.FetchRawTransaction(ctx, sig)
+	if err != nil {
+		return nil, fmt.Errorf("[parser] failed to fetch transaction %s: %w", sig, err)
+	}
+	if txRes == nil {
+		return nil, fmt.Errorf("[parser] transaction %s not found on chain", sig)
+	}
+	raw, err := json.Marshal(txRes)
+	if err != nil {
+		return nil, fmt.Errorf("[parser] failed to marshal transaction %s: %w", sig, err)
+	}
+	return raw, nil
 }
 
 // parseTransaction unmarshals the JSON payload and extracts events and instructions.
-func parseTransaction(ctx context.Context, db *storage.Gorm, rawTx []byte, sig string) error {
+func parseTransaction(ctx context.Context, db Store, rawTx []byte, sig string, mapping bool) error {
 	var tx rpc.GetTransactionResult
 
 	// Decode JSON
@@ -92,18 +307,56 @@ func parseTransaction(ctx context.Context, db *storage.Gorm, rawTx []byte, sig s
 		return nil
 	}
 
+	// Entity changes made while parsing belong to the transaction's slot
+	ctx = generic.WithSlot(ctx, tx.Slot)
+
 	log.Infof("[parser] Parsing instructions for %s", sig)
-	if err := parseTokenInstructions(ctx, db, sig, &tx); err != nil {
+	var events []core.Event
+	emit := storeOnly(&events)
+	if mapping {
+		emit = storeAndMap(db, &events)
+	}
+	if err := parseTokenInstructions(ctx, db, sig, &tx, emit); err != nil {
 		return fmt.Errorf("[parser] error parsing instructions in %s: %w", sig, err)
 	}
 
 	log.Infof("[parser] Parsing logs for %s", sig)
-	if err := parseLogs(ctx, db, sig, &tx); err != nil {
+	if err := parseLogs(ctx, sig, &tx, emit); err != nil {
 		return fmt.Errorf("[parser] error parsing logs in %s: %w", sig, err)
 	}
+	if err := db.SaveEvents(ctx, events); err != nil {
+		return fmt.Errorf("[parser] save events of %s: %w", sig, err)
+	}
 
-	subgraph.MapMetadata(ctx, db, sig, tx.Slot, utils.BlockTime(tx.BlockTime))
+	if mapping {
+		if err := subgraph.MapMetadata(ctx, db, sig, tx.Slot, utils.BlockTime(tx.BlockTime)); err != nil {
+			return &mappingError{err: err}
+		}
+	}
 	monitoring.ParserCurrentSlot.Set(float64(tx.Slot))
 
 	return nil
 }
+
+// eventSink receives every event decoded from a transaction.
+type eventSink func(ctx context.Context, ev core.Event) error
+
+// storeAndMap returns a sink that maps events into the subgraph and collects them to be saved
+// together once the transaction is parsed.
+func storeAndMap(db storage.EntityStore, events *[]core.Event) eventSink {
+	return func(ctx context.Context, ev core.Event) error {
+		*events = append(*events, ev)
+		if err := subgraph.Map(ctx, db, ev); err != nil {
+			return &mappingError{err: err}
+		}
+		return nil
+	}
+}
+
+// storeOnly returns a sink that collects events to be saved without mapping them.
+func storeOnly(events *[]core.Event) eventSink {
+	return func(ctx context.Context, ev core.Event) error {
+		*events = append(*events, ev)
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Tsisar/solana-indexer/internal/core/events"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/near/borsh-go"
+	"testing"
+)
+
+// programData returns the log line of an event emitted by a program.
+func programData(t *testing.T, name string, ev any) string {
+	t.Helper()
+	var data []byte
+	for disc, n := range events.Discriminators {
+		if n == name {
+			data = append(data, disc[:]...)
+		}
+	}
+	payload, err := borsh.Serialize(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Program data: " + base64.StdEncoding.EncodeToString(append(data, payload...))
+}
+
+// storeTransaction stores a transaction of the program with the given log lines, as fetched.
+func storeTransaction(t *testing.T, db *memory.Store, slot uint64, program solana.PublicKey, logs ...string) string {
+	t.Helper()
+	payer := solana.NewWallet()
+	tx := &solana.Transaction{
+		Message: solana.Message{
+			Header:          solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+			AccountKeys:     solana.PublicKeySlice{payer.PublicKey(), program},
+			RecentBlockhash: solana.Hash(solana.NewWallet().PublicKey()),
+			Instructions:    []solana.CompiledInstruction{{ProgramIDIndex: 1, Accounts: []uint16{0}, Data: []byte{1}}},
+		},
+	}
+	if _, err := tx.Sign(func(solana.PublicKey) *solana.PrivateKey { return &payer.PrivateKey }); err != nil {
+		t.Fatal(err)
+	}
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, _ := json.Marshal([]string{base64.StdEncoding.EncodeToString(bin), string(solana.EncodingBase64)})
+	logs = append([]string{"Program " + program.String() + " invoke [1]"}, logs...)
+	logs = append(logs, "Program "+program.String()+" success")
+	blockTime := solana.UnixTimeSeconds(1_700_000_000 + int64(slot))
+	result := rpc.GetTransactionResult{
+		Slot:        slot,
+		BlockTime:   &blockTime,
+		Transaction: new(rpc.TransactionResultEnvelope),
+		Meta:        &rpc.TransactionMeta{LogMessages: logs, InnerInstructions: []rpc.InnerInstruction{}},
+	}
+	if err := result.Transaction.UnmarshalJSON(envelope); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := tx.Signatures[0].String()
+	if err := db.SaveTransaction(context.Background(), &core.Transaction{
+		Signature: sig,
+		Slot:      slot,
+		BlockTime: int64(blockTime),
+		JsonTx:    raw,
+	}, program.String()); err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func vaultInit(vault, mint, shareMint solana.PublicKey) events.VaultInitEvent {
+	return events.VaultInitEvent{
+		VaultKey: vault,
+		UnderlyingToken: events.TokenData{
+			Mint:     mint,
+			Account:  solana.NewWallet().PublicKey(),
+			Decimals: 6,
+			Metadata: events.TokenMetaData{Name: "USD Coin", Symbol: "USDC"},
+		},
+		Accountant: solana.NewWallet().PublicKey(),
+		ShareToken: events.TokenData{
+			Mint:     shareMint,
+			Account:  solana.NewWallet().PublicKey(),
+			Decimals: 6,
+			Metadata: events.TokenMetaData{Name: "Vault Share", Symbol: "vUSDC"},
+		},
+		DepositLimit: 1_000_000_000,
+	}
+}
+
+func TestParseStoredMapsEvents(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	program := solana.NewWallet().PublicKey()
+	vault, mint, shareMint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	sig := storeTransaction(t, db, 10, program,
+		"Program log: Instruction: Initialize",
+		programData(t, "VaultInitEvent", vaultInit(vault, mint, shareMint)),
+	)
+
+	if err := parseStored(ctx, db, sig, true); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := db.Events()
+	if len(stored) != 1 || stored[0].Name != "VaultInitEvent" || stored[0].Kind != core.EventKindProgram ||
+		stored[0].LogIndex != 2002 || stored[0].Slot != 10 {
+		t.Fatalf("expected the program event VaultInitEvent at log index 2002, got %v", stored)
+	}
+	var decoded struct{ VaultKey solana.PublicKey }
+	if err := json.Unmarshal(stored[0].JsonEv, &decoded); err != nil || decoded.VaultKey != vault {
+		t.Errorf("expected the event of vault %s, got %s: %v", vault, stored[0].JsonEv, err)
+	}
+	if parsed, err := db.IsParsed(ctx, sig); err != nil || !parsed {
+		t.Errorf("expected %s to be parsed: %v", sig, err)
+	}
+
+	v := subgraph.Vault{ID: vault.String()}
+	if ok, err := v.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the mapped vault: %v", err)
+	}
+	if v.TokenID != mint.String() || v.ShareTokenID != shareMint.String() || v.DepositLimit.String() != "1000000000" {
+		t.Errorf("unexpected vault %+v", v)
+	}
+	var blocks []subgraph.BlockInfo
+	if err := db.FindEntities(ctx, &blocks); err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Number != 10 || blocks[0].Hash != sig {
+		t.Errorf("expected the metadata of slot 10, got %v", blocks)
+	}
+}
+
+func TestParseStoredWithoutMapping(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	program := solana.NewWallet().PublicKey()
+	vault := solana.NewWallet().PublicKey()
+	sig := storeTransaction(t, db, 11, program,
+		programData(t, "VaultInitEvent", vaultInit(vault, solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey())),
+		programData(t, "VaultShutDownEvent", events.VaultShutDownEvent{VaultKey: vault, Shutdown: true}),
+	)
+
+	if err := parseStored(ctx, db, sig, false); err != nil {
+		t.Fatal(err)
+	}
+	stored := db.Events()
+	if len(stored) != 2 || stored[0].Name != "VaultInitEvent" || stored[1].Name != "VaultShutDownEvent" {
+		t.Fatalf("expected the events in log order, got %v", stored)
+	}
+	if count, _ := db.CountEntities(ctx, &subgraph.Vault{}, "id"); count != 0 {
+		t.Errorf("expected no mapped vault, got %d", count)
+	}
+	if parsed, _ := db.IsParsed(ctx, sig); !parsed {
+		t.Errorf("expected %s to be parsed", sig)
+	}
+}
+
+func TestParseStoredDiscardsFailedTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	program := solana.NewWallet().PublicKey()
+	vault := solana.NewWallet().PublicKey()
+	truncated := programData(t, "VaultShutDownEvent", events.VaultShutDownEvent{VaultKey: vault})
+	sig := storeTransaction(t, db, 12, program,
+		programData(t, "VaultInitEvent", vaultInit(vault, solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey())),
+		// A payload that doesn't decode fails the parse
+		truncated[:len(truncated)-12],
+	)
+
+	if err := parseStored(ctx, db, sig, true); err == nil {
+		t.Fatal("expected an error for an undecodable event")
+	}
+	if len(db.Events()) != 0 {
+		t.Errorf("expected no events, got %v", db.Events())
+	}
+	if count, _ := db.CountEntities(ctx, &subgraph.Vault{}, "id"); count != 0 {
+		t.Errorf("expected the mapped vault to be discarded, got %d", count)
+	}
+	if parsed, _ := db.IsParsed(ctx, sig); parsed {
+		t.Errorf("expected %s not to be parsed", sig)
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/programs"
+	"github.com/gagliardetto/solana-go"
+	"strings"
+)
+
+// mentionedPrograms returns the settings of the indexed programs a transaction references.
+// Program IDs are always static account keys, so lookup tables don't need to be resolved.
+func mentionedPrograms(msg *solana.Message) []config.Program {
+	var mentioned []config.Program
+	for _, key := range msg.AccountKeys {
+		if p, ok := programs.Get(key.String()); ok {
+			mentioned = append(mentioned, p)
+		}
+	}
+	return mentioned
+}
+
+// decodesInstructions reports whether token instructions of a transaction should be decoded:
+// at least one mentioned program has to map them. Transactions of unknown programs, e.g.
+// decoded from the CLI, are decoded in full.
+func decodesInstructions(programs []config.Program) bool {
+	if len(programs) == 0 {
+		return true
+	}
+	for _, p := range programs {
+		if p.DecodeInstructions && p.Mapper != config.MapperNone {
+			return true
+		}
+	}
+	return false
+}
+
+// mapsEvents reports whether the events emitted by a program are extracted.
+// Programs with the "none" mapper only have their transactions stored.
+func mapsEvents(program string) bool {
+	p, ok := programs.Get(program)
+	return !ok || p.Mapper != config.MapperNone
+}
+
+// invocations tracks the program currently executing while walking a transaction's logs.
+type invocations []string
+
+// observe updates the call stack from a "Program <id> invoke|success|failed" log line.
+func (s *invocations) observe(msg string) {
+	fields := strings.Fields(msg)
+	if len(fields) < 3 || fields[0] != "Program" {
+		return
+	}
+	switch {
+	case fields[2] == "invoke":
+		*s = append(*s, fields[1])
+	case fields[2] == "success", strings.HasPrefix(fields[2], "failed"):
+		if len(*s) > 0 {
+			*s = (*s)[:len(*s)-1]
+		}
+	}
+}
+
+// current returns the executing program, or an empty string outside of any invocation.
+func (s invocations) current() string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[len(s)-1]
+}
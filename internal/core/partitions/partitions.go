@@ -0,0 +1,31 @@
+package partitions
+
+import (
+	"context"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"time"
+)
+
+// interval is how often partitions are created ahead; a partition holds about 46 days of slots.
+const interval = time.Hour
+
+// Start keeps a slot partition ahead of the stored transactions and events until the context ends.
+func Start(ctx context.Context, db *storage.Gorm) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		created, err := db.CreatePartitions(ctx, 0)
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("[partitions] %v", err)
+		}
+		if created > 0 {
+			log.Infof("[partitions] Created %d slot partitions", created)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
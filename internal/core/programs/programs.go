@@ -0,0 +1,169 @@
+package programs
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"sync"
+	"time"
+)
+
+// pollInterval is how often the registry picks up programs changed by other processes, e.g. the CLI.
+const pollInterval = 30 * time.Second
+
+// The registry mirrors the active programs of core.programs, which is the source of truth.
+var (
+	mu       sync.Mutex
+	active   = make(map[string]config.Program)
+	order    []string
+	changed  = make(chan struct{})
+	onAdded  func(config.Program)
+	reloadMu sync.Mutex
+)
+
+// Load reads the active programs from the database without notifying anyone.
+func Load(ctx context.Context, db *storage.Gorm) error {
+	return reload(ctx, db)
+}
+
+// Watch keeps the registry in sync with the database until the context ends and calls
+// added for every program that becomes active while watching; added must not block.
+func Watch(ctx context.Context, db *storage.Gorm, added func(config.Program)) {
+	mu.Lock()
+	onAdded = added
+	mu.Unlock()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := reload(ctx, db); err != nil {
+				log.Errorf("[programs] Failed to reload programs: %v", err)
+			}
+		}
+	}
+}
+
+// All returns the active programs ordered by address.
+func All() []config.Program {
+	mu.Lock()
+	defer mu.Unlock()
+	programs := make([]config.Program, 0, len(order))
+	for _, address := range order {
+		programs = append(programs, active[address])
+	}
+	return programs
+}
+
+// Get returns the settings of an active program.
+func Get(address string) (config.Program, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	p, ok := active[address]
+	return p, ok
+}
+
+// Changed returns a channel that is closed on the next change of the active programs.
+func Changed() <-chan struct{} {
+	mu.Lock()
+	defer mu.Unlock()
+	return changed
+}
+
+// Add stores and activates a program. Watchers start indexing it right away.
+func Add(ctx context.Context, db *storage.Gorm, p config.Program) error {
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("[programs] invalid program: %w", err)
+	}
+	if err := db.UpsertProgram(ctx, toModel(p)); err != nil {
+		return fmt.Errorf("[programs] %w", err)
+	}
+	return reload(ctx, db)
+}
+
+// Remove deactivates a program; its stored transactions and events are kept.
+// It reports whether the program was active.
+func Remove(ctx context.Context, db *storage.Gorm, address string) (bool, error) {
+	found, err := db.DeactivateProgram(ctx, address)
+	if err != nil {
+		return false, fmt.Errorf("[programs] %w", err)
+	}
+	return found, reload(ctx, db)
+}
+
+// reload replaces the registry with the active programs of the database and
+// reports newly active programs to the watcher.
+func reload(ctx context.Context, db *storage.Gorm) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	models, err := db.GetActivePrograms(ctx)
+	if err != nil {
+		return fmt.Errorf("[programs] %w", err)
+	}
+
+	next := make(map[string]config.Program, len(models))
+	nextOrder := make([]string, 0, len(models))
+	for _, m := range models {
+		next[m.ID] = fromModel(m)
+		nextOrder = append(nextOrder, m.ID)
+	}
+
+	mu.Lock()
+	var added []config.Program
+	updated := len(next) != len(active)
+	for address, p := range next {
+		prev, ok := active[address]
+		if !ok {
+			added = append(added, p)
+		}
+		if prev != p {
+			updated = true
+		}
+	}
+	active, order = next, nextOrder
+	callback := onAdded
+	if updated {
+		close(changed)
+		changed = make(chan struct{})
+	}
+	mu.Unlock()
+
+	for _, p := range added {
+		log.Infof("[programs] Program %s is now indexed", p.Address)
+		if callback != nil {
+			callback(p)
+		}
+	}
+	return nil
+}
+
+func toModel(p config.Program) core.Program {
+	return core.Program{
+		ID:                 p.Address,
+		IDL:                p.IDL,
+		StartSlot:          p.StartSlot,
+		Commitment:         p.Commitment,
+		DecodeInstructions: p.DecodeInstructions,
+		Mapper:             p.Mapper,
+		FetchMode:          p.FetchMode,
+	}
+}
+
+func fromModel(m core.Program) config.Program {
+	return config.Program{
+		Address:            m.ID,
+		IDL:                m.IDL,
+		StartSlot:          m.StartSlot,
+		Commitment:         m.Commitment,
+		DecodeInstructions: m.DecodeInstructions,
+		Mapper:             m.Mapper,
+		FetchMode:          m.FetchMode,
+	}
+}
@@ -0,0 +1,222 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/core/fetcher"
+	"github.com/Tsisar/solana-indexer/internal/core/rpcpool"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"math"
+)
+
+var client = rpcpool.New() // RPC client used for querying the Solana blockchain
+
+const (
+	// FinalizedWatermark is the name of the watermark holding the last reconciled finalized slot.
+	FinalizedWatermark = "finalized_slot"
+
+	// statusBatchSize is the maximum number of signatures accepted by getSignatureStatuses.
+	statusBatchSize = 256
+
+	// pageSize is the number of unfinalized transactions loaded at once.
+	pageSize = 4 * statusBatchSize
+)
+
+// ReparseFunc parses a single stored transaction again.
+type ReparseFunc func(ctx context.Context, signature string) error
+
+// Reconcile re-verifies confirmed transactions once their slot is finalized.
+//
+// Transactions finalized in the slot they were observed in are marked as finalized.
+// Transactions that disappeared are deleted together with their events, and transactions
+// that landed in a different slot are re-fetched and re-parsed. If anything was reverted,
+// the subgraph is rolled back through the entity versions and the remaining events are replayed
+// from the earliest affected slot, so the reconciliation requires versioned entities.
+//
+// A transaction without a signature status is only considered gone once getTransaction at the
+// finalized commitment doesn't find it either and its slot is below the finalized slot: statuses
+// only cover the node's recent status cache, and are sometimes missing for a moment.
+func Reconcile(ctx context.Context, db *storage.Gorm, reparse ReparseFunc) error {
+	getFinalizedSlot := func() (uint64, error) {
+		return client.GetSlot(ctx, rpc.CommitmentFinalized)
+	}
+	finalizedSlot, err := utils.Retry(getFinalizedSlot)
+	if err != nil {
+		return fmt.Errorf("[reconciler] failed to get finalized slot: %w", err)
+	}
+
+	var finalized int
+	var dropped, moved []core.Transaction
+	var after *storage.TransactionCursor
+	for {
+		txs, err := db.GetUnfinalizedTransactions(ctx, finalizedSlot, after, pageSize)
+		if err != nil {
+			return fmt.Errorf("[reconciler] failed to load unfinalized transactions: %w", err)
+		}
+		for start := 0; start < len(txs); start += statusBatchSize {
+			batch := txs[start:min(start+statusBatchSize, len(txs))]
+			done, gone, changed, err := check(ctx, batch, finalizedSlot)
+			if err != nil {
+				return err
+			}
+			if err := db.MarkFinalized(ctx, done); err != nil {
+				return fmt.Errorf("[reconciler] failed to mark transactions as finalized: %w", err)
+			}
+			finalized += len(done)
+			dropped = append(dropped, gone...)
+			moved = append(moved, changed...)
+		}
+		if len(txs) < pageSize {
+			break
+		}
+		last := txs[len(txs)-1]
+		after = &storage.TransactionCursor{Slot: last.Slot, Signature: last.Signature}
+	}
+
+	if len(dropped) > 0 || len(moved) > 0 {
+		if err := revert(ctx, db, dropped, moved, reparse); err != nil {
+			return err
+		}
+	}
+
+	if err := db.SetWatermark(ctx, FinalizedWatermark, finalizedSlot); err != nil {
+		return fmt.Errorf("[reconciler] failed to save finalized watermark: %w", err)
+	}
+	monitoring.FinalizedSlot.Set(float64(finalizedSlot))
+	log.Debugf("[reconciler] Reconciled up to finalized slot %d: %d finalized, %d dropped, %d moved",
+		finalizedSlot, finalized, len(dropped), len(moved))
+	return nil
+}
+
+// check sorts a batch of transactions into those finalized in their slot, those gone and those
+// that landed in another slot. Transactions not decided yet are in none of them.
+func check(ctx context.Context, batch []core.Transaction, finalizedSlot uint64) (finalized []string, dropped, moved []core.Transaction, err error) {
+	statuses, err := getStatuses(ctx, batch)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for i, tx := range batch {
+		status := statuses[i]
+		if status == nil {
+			res, err := getFinalizedTransaction(ctx, tx.Signature)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			switch {
+			case res == nil && tx.Slot < finalizedSlot:
+				dropped = append(dropped, tx)
+			case res == nil:
+				log.Debugf("[reconciler] Transaction %s is not finalized yet", tx.Signature)
+			case res.Slot != tx.Slot:
+				moved = append(moved, tx)
+			default:
+				finalized = append(finalized, tx.Signature)
+			}
+			continue
+		}
+		switch {
+		case status.ConfirmationStatus != rpc.ConfirmationStatusFinalized:
+			log.Debugf("[reconciler] Transaction %s is still %s", tx.Signature, status.ConfirmationStatus)
+		case status.Slot != tx.Slot:
+			moved = append(moved, tx)
+		default:
+			finalized = append(finalized, tx.Signature)
+		}
+	}
+	return finalized, dropped, moved, nil
+}
+
+// getFinalizedTransaction returns a transaction at the finalized commitment, or nil if there is none.
+func getFinalizedTransaction(ctx context.Context, signature string) (*rpc.GetTransactionResult, error) {
+	sig, err := solana.SignatureFromBase58(signature)
+	if err != nil {
+		return nil, fmt.Errorf("[reconciler] invalid signature %s: %w", signature, err)
+	}
+	res, err := utils.Retry(func() (*rpc.GetTransactionResult, error) {
+		res, err := client.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
+			Encoding:                       solana.EncodingBase64,
+			Commitment:                     rpc.CommitmentFinalized,
+			MaxSupportedTransactionVersion: utils.Ptr(uint64(0)),
+		})
+		if errors.Is(err, rpc.ErrNotFound) {
+			return nil, nil
+		}
+		return res, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[reconciler] failed to get transaction %s: %w", signature, err)
+	}
+	return res, nil
+}
+
+// getStatuses returns the signature statuses of a batch of transactions, searching the full history.
+func getStatuses(ctx context.Context, batch []core.Transaction) ([]*rpc.SignatureStatusesResult, error) {
+	sigs := make([]solana.Signature, 0, len(batch))
+	for _, tx := range batch {
+		sig, err := solana.SignatureFromBase58(tx.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("[reconciler] invalid signature %s: %w", tx.Signature, err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	getSignatureStatuses := func() (*rpc.GetSignatureStatusesResult, error) {
+		return client.GetSignatureStatuses(ctx, true, sigs...)
+	}
+	res, err := utils.Retry(getSignatureStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("[reconciler] failed to get signature statuses: %w", err)
+	}
+	if len(res.Value) != len(batch) {
+		return nil, fmt.Errorf("[reconciler] expected %d signature statuses, got %d", len(batch), len(res.Value))
+	}
+	return res.Value, nil
+}
+
+// revert removes dropped transactions, re-fetches and re-parses moved ones,
+// and reverts the subgraph to the earliest affected slot.
+func revert(ctx context.Context, db *storage.Gorm, dropped []core.Transaction, moved []core.Transaction, reparse ReparseFunc) error {
+	fromSlot := uint64(math.MaxUint64)
+	for _, tx := range dropped {
+		log.Warnf("[reconciler] Transaction %s was dropped by a fork, reverting...", tx.Signature)
+		if err := db.DeleteTransaction(ctx, tx.Signature); err != nil {
+			return fmt.Errorf("[reconciler] failed to revert transaction %s: %w", tx.Signature, err)
+		}
+		fromSlot = min(fromSlot, tx.Slot)
+		monitoring.DroppedTransactionsTotal.Inc()
+	}
+
+	for _, tx := range moved {
+		txRes, err := fetcher.FetchRawTransaction(ctx, tx.Signature)
+		if err != nil {
+			return fmt.Errorf("[reconciler] failed to re-fetch transaction %s: %w", tx.Signature, err)
+		}
+		log.Warnf("[reconciler] Transaction %s moved from slot %d to %d, re-parsing...", tx.Signature, tx.Slot, txRes.Slot)
+
+		raw, err := json.Marshal(txRes)
+		if err != nil {
+			return fmt.Errorf("[reconciler] failed to marshal transaction %s: %w", tx.Signature, err)
+		}
+		if err := db.ResetTransaction(ctx, tx.Signature, txRes.Slot, utils.BlockTime(txRes.BlockTime), raw); err != nil {
+			return fmt.Errorf("[reconciler] failed to reset transaction %s: %w", tx.Signature, err)
+		}
+		fromSlot = min(fromSlot, tx.Slot, txRes.Slot)
+		if err := reparse(ctx, tx.Signature); err != nil {
+			return fmt.Errorf("[reconciler] failed to re-parse transaction %s: %w", tx.Signature, err)
+		}
+	}
+
+	if err := subgraph.RevertFrom(ctx, db, fromSlot); err != nil {
+		return fmt.Errorf("[reconciler] failed to revert subgraph: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"time"
+)
+
+// pruneBatch is the number of transactions pruned per query.
+const pruneBatch = 1000
+
+// Start applies the retention of raw payloads every prune interval until the context ends.
+// Without a retention raw payloads are kept forever.
+func Start(ctx context.Context, db *storage.Gorm) {
+	retention := config.App.RawStorage.Retention
+	if retention == 0 {
+		return
+	}
+	log.Infof("[retention] Pruning raw payloads of parsed, finalized transactions older than %s", retention)
+
+	ticker := time.NewTicker(config.App.RawStorage.PruneInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := Prune(ctx, db, time.Now().Add(-retention)); err != nil && ctx.Err() == nil {
+			log.Errorf("[retention] %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes the raw payloads of parsed, finalized transactions with a block time before the cutoff
+// and returns how many were pruned. Their signatures remain, so a reparse fetches them again.
+func Prune(ctx context.Context, db *storage.Gorm, before time.Time) (int, error) {
+	total := 0
+	for {
+		n, err := db.PruneRawTransactions(ctx, before.Unix(), pruneBatch)
+		total += n
+		monitoring.RawPrunedTotal.Add(float64(n))
+		if err != nil {
+			return total, fmt.Errorf("[retention] failed to prune raw transactions: %w", err)
+		}
+		if n < pruneBatch {
+			break
+		}
+	}
+	if total > 0 {
+		log.Infof("[retention] Pruned the raw payloads of %d transactions before %s", total, before.Format(time.RFC3339))
+	}
+	return total, nil
+}
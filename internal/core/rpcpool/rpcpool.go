@@ -0,0 +1,98 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// pool is a JSON-RPC client spreading calls over several endpoints. Calls stick to the
+// current endpoint and fail over to the next one on transport or HTTP errors.
+// JSON-RPC errors are answers of the node and are returned as is.
+type pool struct {
+	mu        sync.Mutex
+	endpoints []string
+	clients   []rpc.JSONRPCClient
+	current   atomic.Uint32
+}
+
+// New returns a Solana RPC client backed by the configured endpoints. The endpoints are read on
+// each call, so a client created at package initialization follows later changes of them.
+func New() *rpc.Client {
+	return rpc.NewWithCustomRPCClient(&pool{})
+}
+
+// connect returns the clients of the configured endpoints, creating them if the endpoints changed.
+func (p *pool) connect() ([]string, []rpc.JSONRPCClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients == nil || !slices.Equal(p.endpoints, config.App.RPC.Endpoints) {
+		p.endpoints = slices.Clone(config.App.RPC.Endpoints)
+		p.clients = make([]rpc.JSONRPCClient, 0, len(p.endpoints))
+		httpClient := &http.Client{Timeout: config.App.RPC.Timeout}
+		for _, endpoint := range p.endpoints {
+			p.clients = append(p.clients, jsonrpc.NewClientWithOpts(endpoint, &jsonrpc.RPCClientOpts{HTTPClient: httpClient}))
+		}
+		p.current.Store(0)
+	}
+	return p.endpoints, p.clients
+}
+
+func (p *pool) CallForInto(ctx context.Context, out interface{}, method string, params []interface{}) error {
+	return p.call(ctx, method, func(c rpc.JSONRPCClient) error {
+		return c.CallForInto(ctx, out, method, params)
+	})
+}
+
+func (p *pool) CallWithCallback(ctx context.Context, method string, params []interface{}, callback func(*http.Request, *http.Response) error) error {
+	return p.call(ctx, method, func(c rpc.JSONRPCClient) error {
+		return c.CallWithCallback(ctx, method, params, callback)
+	})
+}
+
+func (p *pool) CallBatch(ctx context.Context, requests jsonrpc.RPCRequests) (jsonrpc.RPCResponses, error) {
+	var responses jsonrpc.RPCResponses
+	err := p.call(ctx, "batch", func(c rpc.JSONRPCClient) error {
+		var err error
+		responses, err = c.CallBatch(ctx, requests)
+		return err
+	})
+	return responses, err
+}
+
+// call tries each endpoint once, starting with the current one.
+func (p *pool) call(ctx context.Context, method string, fn func(rpc.JSONRPCClient) error) error {
+	endpoints, clients := p.connect()
+	if len(clients) == 0 {
+		return fmt.Errorf("[rpcpool] no RPC endpoints configured")
+	}
+
+	start := p.current.Load()
+	var err error
+	for i := 0; i < len(clients); i++ {
+		idx := (int(start) + i) % len(clients)
+		err = fn(clients[idx])
+		if err == nil || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		next := uint32((idx + 1) % len(clients))
+		if p.current.CompareAndSwap(uint32(idx), next) && len(clients) > 1 {
+			log.Warnf("[rpcpool] %s failed on %s, switching to %s: %v", method, endpoints[idx], endpoints[next], err)
+		}
+	}
+	return err
+}
+
+// retryable reports whether another endpoint may answer the call successfully.
+func retryable(err error) bool {
+	var rpcErr *jsonrpc.RPCError
+	return !errors.As(err, &rpcErr)
+}
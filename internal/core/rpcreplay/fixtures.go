@@ -0,0 +1,104 @@
+package rpcreplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Files of a fixture directory, with one JSON object per line in recording order.
+const (
+	callsFile         = "calls.jsonl"
+	notificationsFile = "notifications.jsonl"
+)
+
+// Fixtures are recorded responses of a Solana RPC endpoint.
+type Fixtures struct {
+	Calls         []Call
+	Notifications []Notification
+}
+
+// Call is a recorded JSON-RPC call with either its result or its error.
+type Call struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// Notification is a recorded logsSubscribe notification of the program it mentions.
+type Notification struct {
+	Mentions string          `json:"mentions"`
+	Result   json.RawMessage `json:"result"` // context and value of the logsNotification
+}
+
+// Load reads the fixtures of a directory written by Save.
+func Load(dir string) (*Fixtures, error) {
+	f := &Fixtures{}
+	if err := readLines(filepath.Join(dir, callsFile), &f.Calls); err != nil {
+		return nil, err
+	}
+	if err := readLines(filepath.Join(dir, notificationsFile), &f.Notifications); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Save writes the fixtures into a directory, creating it if needed.
+func (f *Fixtures) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("[rpcreplay] failed to create %s: %w", dir, err)
+	}
+	if err := writeLines(filepath.Join(dir, callsFile), f.Calls); err != nil {
+		return err
+	}
+	return writeLines(filepath.Join(dir, notificationsFile), f.Notifications)
+}
+
+// readLines appends the JSON lines of a file to out; a missing file has none.
+func readLines[T any](path string, out *[]T) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("[rpcreplay] failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(file))
+	for line := 1; ; line++ {
+		var v T
+		if err := dec.Decode(&v); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("[rpcreplay] invalid entry %d of %s: %w", line, path, err)
+		}
+		*out = append(*out, v)
+	}
+}
+
+func writeLines[T any](path string, values []T) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("[rpcreplay] failed to create %s: %w", path, err)
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			file.Close()
+			return fmt.Errorf("[rpcreplay] failed to encode %s: %w", path, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("[rpcreplay] failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,134 @@
+package rpcreplay
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"gorm.io/gorm/schema"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// State is the subgraph state an end-to-end run is checked against, as rows of column values
+// sorted by id.
+type State struct {
+	Vaults                []map[string]any `json:"vaults"`
+	Strategies            []map[string]any `json:"strategies"`
+	AccountVaultPositions []map[string]any `json:"account_vault_positions"`
+}
+
+// Snapshot reads the vaults, strategies and account vault positions of a store.
+func Snapshot(ctx context.Context, db storage.EntityStore) (*State, error) {
+	var (
+		vaults     []subgraph.Vault
+		strategies []subgraph.Strategy
+		positions  []subgraph.AccountVaultPosition
+		schemas    sync.Map
+		state      State
+		err        error
+	)
+	if err := db.FindEntities(ctx, &vaults); err != nil {
+		return nil, fmt.Errorf("[rpcreplay] failed to load vaults: %w", err)
+	}
+	if err := db.FindEntities(ctx, &strategies); err != nil {
+		return nil, fmt.Errorf("[rpcreplay] failed to load strategies: %w", err)
+	}
+	if err := db.FindEntities(ctx, &positions); err != nil {
+		return nil, fmt.Errorf("[rpcreplay] failed to load account vault positions: %w", err)
+	}
+	if state.Vaults, err = rows(ctx, &schemas, vaults); err != nil {
+		return nil, err
+	}
+	if state.Strategies, err = rows(ctx, &schemas, strategies); err != nil {
+		return nil, err
+	}
+	if state.AccountVaultPositions, err = rows(ctx, &schemas, positions); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// rows returns the column values of entities sorted by id, leaving out the creation and update
+// times, which differ between runs.
+func rows[T any](ctx context.Context, schemas *sync.Map, entities []T) ([]map[string]any, error) {
+	var zero T
+	sch, err := schema.Parse(&zero, schemas, schema.NamingStrategy{})
+	if err != nil {
+		return nil, fmt.Errorf("[rpcreplay] failed to parse model %T: %w", zero, err)
+	}
+
+	result := make([]map[string]any, 0, len(entities))
+	for i := range entities {
+		value := reflect.ValueOf(&entities[i]).Elem()
+		row := make(map[string]any, len(sch.DBNames))
+		for _, field := range sch.Fields {
+			if field.DBName == "" || field.AutoCreateTime > 0 || field.AutoUpdateTime > 0 {
+				continue
+			}
+			v := field.ReflectValueOf(ctx, value)
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				row[field.DBName] = nil
+				continue
+			}
+			if valuer, ok := v.Addr().Interface().(driver.Valuer); ok {
+				column, err := valuer.Value()
+				if err != nil {
+					return nil, fmt.Errorf("[rpcreplay] failed to encode %s.%s: %w", sch.Table, field.DBName, err)
+				}
+				row[field.DBName] = column
+				continue
+			}
+			row[field.DBName] = reflect.Indirect(v).Interface()
+		}
+		result = append(result, row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return fmt.Sprint(result[i]["id"]) < fmt.Sprint(result[j]["id"])
+	})
+	return result, nil
+}
+
+// CompareGolden compares a state with the golden file at path, or writes the file if update is set.
+// It returns the first differing line, or an empty string if the state matches.
+func CompareGolden(path string, state *State, update bool) (string, error) {
+	got, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("[rpcreplay] failed to encode state: %w", err)
+	}
+	got = append(got, '\n')
+	if update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			return "", fmt.Errorf("[rpcreplay] failed to write %s: %w", path, err)
+		}
+		return "", nil
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("[rpcreplay] failed to read %s: %w", path, err)
+	}
+	if bytes.Equal(got, want) {
+		return "", nil
+	}
+	gotLines, wantLines := strings.Split(string(got), "\n"), strings.Split(string(want), "\n")
+	for i := 0; i < max(len(gotLines), len(wantLines)); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, strings.TrimSpace(w), strings.TrimSpace(g)), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,80 @@
+package rpcreplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// message is a JSON-RPC request, response or notification.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// notificationParams are the params of a subscription notification.
+type notificationParams struct {
+	Result       json.RawMessage `json:"result"`
+	Subscription uint64          `json:"subscription"`
+}
+
+// parseMessages parses a single JSON-RPC message or a batch of them.
+func parseMessages(body []byte) ([]message, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []message
+		err := json.Unmarshal(body, &batch)
+		return batch, true, err
+	}
+	var single message
+	err := json.Unmarshal(body, &single)
+	return []message{single}, false, err
+}
+
+// callKey identifies the calls of a method with the same parameters, whatever their formatting.
+func callKey(method string, params json.RawMessage) (string, error) {
+	if len(params) == 0 {
+		return method, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("[rpcreplay] invalid params of %s: %w", method, err)
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("[rpcreplay] invalid params of %s: %w", method, err)
+	}
+	return method + " " + string(canonical), nil
+}
+
+// rpcError returns a JSON-RPC error object.
+func rpcError(code int, msg string) json.RawMessage {
+	data, _ := json.Marshal(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{code, msg})
+	return data
+}
+
+// serve serves the handler on the listener until the context ends.
+func serve(ctx context.Context, l net.Listener, handler http.Handler) error {
+	server := &http.Server{Handler: handler}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("[rpcreplay] server failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package rpcreplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/gorilla/websocket"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Recorder is a proxy to a real Solana RPC endpoint that records the JSON-RPC calls and the
+// logsSubscribe notifications passing through it as fixtures for a Server.
+type Recorder struct {
+	upstream   string
+	wsUpstream string
+	client     *http.Client
+
+	mu       sync.Mutex
+	fixtures Fixtures
+}
+
+// NewRecorder returns a recorder forwarding calls to the HTTP endpoint and subscriptions to the
+// WebSocket endpoint.
+func NewRecorder(upstream, wsUpstream string) *Recorder {
+	return &Recorder{upstream: upstream, wsUpstream: wsUpstream, client: &http.Client{}}
+}
+
+// Fixtures returns what has been recorded so far.
+func (r *Recorder) Fixtures() *Fixtures {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Fixtures{
+		Calls:         slices.Clone(r.fixtures.Calls),
+		Notifications: slices.Clone(r.fixtures.Notifications),
+	}
+}
+
+// Serve accepts connections on the listener until the context ends.
+func (r *Recorder) Serve(ctx context.Context, l net.Listener) error {
+	return serve(ctx, l, r)
+}
+
+// ServeHTTP forwards a JSON-RPC call or batch, or a WebSocket connection.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if websocket.IsWebSocketUpgrade(req) {
+		r.proxySubscriptions(w, req)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	upstreamReq, err := http.NewRequestWithContext(req.Context(), req.Method, r.upstream, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(upstreamReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	// Recorded before answering, so the fixtures hold every call the client got a response to
+	if resp.StatusCode == http.StatusOK {
+		r.recordCalls(body, respBody)
+	}
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+// recordCalls records the calls of a request with their responses, matched by id.
+func (r *Recorder) recordCalls(reqBody, respBody []byte) {
+	requests, _, err := parseMessages(reqBody)
+	if err != nil {
+		return
+	}
+	responses, _, err := parseMessages(respBody)
+	if err != nil {
+		log.Warnf("[rpcreplay] Not recording an invalid response: %v", err)
+		return
+	}
+	byID := make(map[string]message, len(responses))
+	for _, resp := range responses {
+		byID[string(resp.ID)] = resp
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, req := range requests {
+		resp, ok := byID[string(req.ID)]
+		if !ok {
+			continue
+		}
+		call := Call{Method: req.Method, Params: req.Params, Result: resp.Result, Error: resp.Error}
+		if call.Result == nil && call.Error == nil {
+			call.Result = json.RawMessage("null")
+		}
+		r.fixtures.Calls = append(r.fixtures.Calls, call)
+	}
+}
+
+// proxySubscriptions pipes a WebSocket connection to the upstream endpoint and records the
+// notifications of its logsSubscribe subscriptions.
+func (r *Recorder) proxySubscriptions(w http.ResponseWriter, req *http.Request) {
+	upstream, _, err := websocket.DefaultDialer.DialContext(req.Context(), r.wsUpstream, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Close()
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var (
+		mu       sync.Mutex
+		pending  = make(map[string]string) // mentions by request id
+		mentions = make(map[uint64]string) // mentions by subscription id
+	)
+
+	// Client to upstream
+	go func() {
+		defer upstream.Close()
+		for {
+			kind, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg message
+			if json.Unmarshal(data, &msg) == nil && msg.Method == "logsSubscribe" {
+				if program, err := logsMentions(msg.Params); err == nil {
+					mu.Lock()
+					pending[string(msg.ID)] = program
+					mu.Unlock()
+				}
+			}
+			if err := upstream.WriteMessage(kind, data); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Upstream to client
+	for {
+		kind, data, err := upstream.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg message
+		if json.Unmarshal(data, &msg) == nil {
+			mu.Lock()
+			switch {
+			case msg.ID != nil && pending[string(msg.ID)] != "":
+				var id uint64
+				if json.Unmarshal(msg.Result, &id) == nil {
+					mentions[id] = pending[string(msg.ID)]
+				}
+				delete(pending, string(msg.ID))
+			case msg.Method == "logsNotification":
+				var params notificationParams
+				if json.Unmarshal(msg.Params, &params) == nil && mentions[params.Subscription] != "" {
+					r.mu.Lock()
+					r.fixtures.Notifications = append(r.fixtures.Notifications, Notification{
+						Mentions: mentions[params.Subscription],
+						Result:   params.Result,
+					})
+					r.mu.Unlock()
+				}
+			}
+			mu.Unlock()
+		}
+		if err := conn.WriteMessage(kind, data); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package rpcreplay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/gorilla/websocket"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Server is a local Solana RPC endpoint answering from recorded fixtures, for running the indexer
+// offline. JSON-RPC calls over HTTP get the recorded responses of the same method and parameters in
+// turn, the last one repeatedly, and an error if there is none. A logsSubscribe over WebSocket gets
+// the recorded notifications of its program, and a slotSubscribe a notification of the highest
+// recorded slot every SlotInterval, which satisfies the listener's keepalive.
+type Server struct {
+	SlotInterval time.Duration
+
+	mu            sync.Mutex
+	calls         map[string][]Call
+	served        map[string]int
+	notifications map[string][]json.RawMessage
+	slot          uint64
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// NewServer returns a server replaying the given fixtures.
+func NewServer(f *Fixtures) (*Server, error) {
+	s := &Server{
+		SlotInterval:  time.Second,
+		calls:         make(map[string][]Call),
+		served:        make(map[string]int),
+		notifications: make(map[string][]json.RawMessage),
+	}
+	for _, call := range f.Calls {
+		key, err := callKey(call.Method, call.Params)
+		if err != nil {
+			return nil, err
+		}
+		s.calls[key] = append(s.calls[key], call)
+	}
+	for _, n := range f.Notifications {
+		var result struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+		}
+		if err := json.Unmarshal(n.Result, &result); err != nil {
+			return nil, fmt.Errorf("[rpcreplay] invalid notification of %s: %w", n.Mentions, err)
+		}
+		s.slot = max(s.slot, result.Context.Slot)
+		s.notifications[n.Mentions] = append(s.notifications[n.Mentions], n.Result)
+	}
+	return s, nil
+}
+
+// Serve accepts connections on the listener until the context ends.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	return serve(ctx, l, s)
+}
+
+// ServeHTTP answers a JSON-RPC call or batch, or upgrades the connection to a WebSocket.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		s.subscribe(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	requests, batch, err := parseMessages(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	responses := make([]message, len(requests))
+	for i, req := range requests {
+		responses[i] = s.answer(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	if batch {
+		enc.Encode(responses)
+	} else {
+		enc.Encode(responses[0])
+	}
+}
+
+// answer returns the next recorded response of a call.
+func (s *Server) answer(req message) message {
+	resp := message{JSONRPC: "2.0", ID: req.ID}
+	key, err := callKey(req.Method, req.Params)
+	if err != nil {
+		resp.Error = rpcError(-32602, err.Error())
+		return resp
+	}
+
+	s.mu.Lock()
+	recorded := s.calls[key]
+	i := min(s.served[key], len(recorded)-1)
+	s.served[key]++
+	s.mu.Unlock()
+
+	if len(recorded) == 0 {
+		log.Warnf("[rpcreplay] No recorded response for %s", key)
+		resp.Error = rpcError(-32000, "no recorded response for "+key)
+		return resp
+	}
+	resp.Result, resp.Error = recorded[i].Result, recorded[i].Error
+	return resp
+}
+
+// subscribe serves the subscriptions of one WebSocket connection.
+func (s *Server) subscribe(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	out := &wsWriter{conn: conn}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	subs := make(map[uint64]context.CancelFunc)
+	var nextID uint64
+
+	for {
+		var req message
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		resp := message{JSONRPC: "2.0", ID: req.ID}
+
+		switch req.Method {
+		case "logsSubscribe", "slotSubscribe":
+			nextID++
+			id := nextID
+			subCtx, stop := context.WithCancel(ctx)
+			subs[id] = stop
+			resp.Result = json.RawMessage(strconv.FormatUint(id, 10))
+			if !out.write(resp) {
+				return
+			}
+			if req.Method == "logsSubscribe" {
+				mentions, err := logsMentions(req.Params)
+				if err != nil {
+					log.Warnf("[rpcreplay] %v", err)
+				}
+				go s.sendLogs(subCtx, out, id, mentions)
+			} else {
+				go s.sendSlots(subCtx, out, id)
+			}
+			continue
+		case "logsUnsubscribe", "slotUnsubscribe":
+			var params []uint64
+			if err := json.Unmarshal(req.Params, &params); err == nil && len(params) == 1 && subs[params[0]] != nil {
+				subs[params[0]]()
+				delete(subs, params[0])
+				resp.Result = json.RawMessage("true")
+			} else {
+				resp.Result = json.RawMessage("false")
+			}
+		default:
+			resp.Error = rpcError(-32601, "method not supported by the replay: "+req.Method)
+		}
+		if !out.write(resp) {
+			return
+		}
+	}
+}
+
+// sendLogs sends the recorded notifications of a program.
+func (s *Server) sendLogs(ctx context.Context, out *wsWriter, id uint64, mentions string) {
+	for _, result := range s.notifications[mentions] {
+		if ctx.Err() != nil || !out.notify("logsNotification", id, result) {
+			return
+		}
+	}
+	log.Infof("[rpcreplay] Replayed %d notifications of %s", len(s.notifications[mentions]), mentions)
+}
+
+// sendSlots sends a notification of the highest recorded slot every SlotInterval.
+func (s *Server) sendSlots(ctx context.Context, out *wsWriter, id uint64) {
+	result := json.RawMessage(fmt.Sprintf(`{"parent":%d,"root":%d,"slot":%d}`, max(s.slot, 1)-1, s.slot, s.slot))
+	ticker := time.NewTicker(s.SlotInterval)
+	defer ticker.Stop()
+	for {
+		if !out.notify("slotNotification", id, result) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// logsMentions returns the program of a logsSubscribe, or "all" for a subscription to all logs.
+func logsMentions(params json.RawMessage) (string, error) {
+	var args []json.RawMessage
+	if err := json.Unmarshal(params, &args); err != nil || len(args) == 0 {
+		return "", fmt.Errorf("invalid logsSubscribe params %s", params)
+	}
+	var filter struct {
+		Mentions []string `json:"mentions"`
+	}
+	if err := json.Unmarshal(args[0], &filter); err != nil {
+		// "all" or "allWithVotes"
+		var kind string
+		if err := json.Unmarshal(args[0], &kind); err != nil {
+			return "", fmt.Errorf("invalid logsSubscribe filter %s", args[0])
+		}
+		return kind, nil
+	}
+	if len(filter.Mentions) != 1 {
+		return "", fmt.Errorf("invalid logsSubscribe filter %s", args[0])
+	}
+	return filter.Mentions[0], nil
+}
+
+// wsWriter serializes the writes of the goroutines serving one connection.
+type wsWriter struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (w *wsWriter) write(msg message) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.WriteJSON(msg) == nil
+}
+
+func (w *wsWriter) notify(method string, subscription uint64, result json.RawMessage) bool {
+	params, _ := json.Marshal(notificationParams{Result: result, Subscription: subscription})
+	return w.write(message{JSONRPC: "2.0", Method: method, Params: params})
+}
@@ -0,0 +1,185 @@
+package rpcreplay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	program = solana.MustPublicKeyFromBase58("AJCGiygDjwN8ZS7hsRUHCNWJSmcPeSdn7egXfLJN5Gbp")
+	account = solana.MustPublicKeyFromBase58("4d57SKXQgSJwpmKqQD2u5tgmLdgck9FzfiqUkjRDP24U")
+)
+
+// testFixtures returns a getAccountInfo call of account and notifications of program and of
+// another program.
+func testFixtures() *Fixtures {
+	f := &Fixtures{
+		Calls: []Call{{
+			Method: "getAccountInfo",
+			Params: json.RawMessage(fmt.Sprintf(`[%q,{"commitment":"confirmed","encoding":"base64"}]`, account)),
+			Result: json.RawMessage(`{"context":{"slot":120},"value":{"data":["AQID","base64"],"executable":false,"lamports":1461600,"owner":"11111111111111111111111111111111","rentEpoch":0,"space":3}}`),
+		}},
+	}
+	for i := 1; i <= 3; i++ {
+		f.Notifications = append(f.Notifications, Notification{
+			Mentions: program.String(),
+			Result:   notification(i, uint64(100+i)),
+		})
+	}
+	f.Notifications = append(f.Notifications, Notification{Mentions: account.String(), Result: notification(9, 200)})
+	return f
+}
+
+func notification(sig int, slot uint64) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"context":{"slot":%d},"value":{"signature":%q,"err":null,"logs":[]}}`, slot, solana.Signature{byte(sig)}))
+}
+
+func serveFixtures(t *testing.T, f *Fixtures) *httptest.Server {
+	s, err := NewServer(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SlotInterval = 10 * time.Millisecond
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// getAccountInfo calls getAccountInfo of account as the parser does for lookup tables.
+func getAccountInfo(t *testing.T, endpoint string) []byte {
+	resp, err := rpc.New(endpoint).GetAccountInfoWithOpts(context.Background(), account, &rpc.GetAccountInfoOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		t.Fatalf("getAccountInfo failed: %v", err)
+	}
+	if resp.Value == nil || resp.Value.Data == nil {
+		t.Fatal("expected account data")
+	}
+	return resp.Value.Data.GetBinary()
+}
+
+// subscribeLogs returns the signatures and slots of the first n notifications of program.
+func subscribeLogs(t *testing.T, endpoint string, n int) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := ws.Connect(ctx, endpoint)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.Close()
+	sub, err := client.LogsSubscribeMentions(program, rpc.CommitmentConfirmed)
+	if err != nil {
+		t.Fatalf("logsSubscribe failed: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	var got []string
+	for len(got) < n {
+		msg, err := sub.Recv(ctx)
+		if err != nil {
+			t.Fatalf("expected %d notifications, got %d: %v", n, len(got), err)
+		}
+		got = append(got, fmt.Sprintf("%s@%d", msg.Value.Signature, msg.Context.Slot))
+	}
+	return got
+}
+
+func expectedLogs() []string {
+	var expected []string
+	for i := 1; i <= 3; i++ {
+		expected = append(expected, fmt.Sprintf("%s@%d", solana.Signature{byte(i)}, 100+i))
+	}
+	return expected
+}
+
+func TestServerAnswersGetAccountInfo(t *testing.T) {
+	server := serveFixtures(t, testFixtures())
+
+	for i := 0; i < 2; i++ {
+		if data := getAccountInfo(t, server.URL); !reflect.DeepEqual(data, []byte{1, 2, 3}) {
+			t.Errorf("expected the recorded account data, got %v", data)
+		}
+	}
+
+	_, err := rpc.New(server.URL).GetAccountInfo(context.Background(), program)
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("expected an error for an unrecorded call, got %v", err)
+	}
+}
+
+func TestServerReplaysLogsSubscription(t *testing.T) {
+	server := serveFixtures(t, testFixtures())
+
+	if got := subscribeLogs(t, wsURL(server), 3); !reflect.DeepEqual(got, expectedLogs()) {
+		t.Errorf("expected notifications %v, got %v", expectedLogs(), got)
+	}
+
+	// The keepalive of the listener follows the slots
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := ws.Connect(ctx, wsURL(server))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.Close()
+	sub, err := client.SlotSubscribe()
+	if err != nil {
+		t.Fatalf("slotSubscribe failed: %v", err)
+	}
+	defer sub.Unsubscribe()
+	for i := 0; i < 2; i++ {
+		slot, err := sub.Recv(ctx)
+		if err != nil {
+			t.Fatalf("expected slot notifications: %v", err)
+		}
+		if slot.Slot != 200 {
+			t.Errorf("expected the highest recorded slot 200, got %d", slot.Slot)
+		}
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	upstream := serveFixtures(t, testFixtures())
+	recorder := NewRecorder(upstream.URL, wsURL(upstream))
+	proxy := httptest.NewServer(recorder)
+	defer proxy.Close()
+
+	data := getAccountInfo(t, proxy.URL)
+	logs := subscribeLogs(t, wsURL(proxy), 3)
+
+	dir := t.TempDir()
+	if err := recorder.Fixtures().Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Calls) != 1 || len(loaded.Notifications) != 3 {
+		t.Fatalf("expected 1 call and 3 notifications, got %d and %d", len(loaded.Calls), len(loaded.Notifications))
+	}
+
+	replay := serveFixtures(t, loaded)
+	if got := getAccountInfo(t, replay.URL); !reflect.DeepEqual(got, data) {
+		t.Errorf("expected the recorded account data %v, got %v", data, got)
+	}
+	if got := subscribeLogs(t, wsURL(replay), 3); !reflect.DeepEqual(got, logs) {
+		t.Errorf("expected the recorded notifications %v, got %v", logs, got)
+	}
+}
@@ -0,0 +1,92 @@
+package geyser
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/Tsisar/solana-indexer/internal/core/source/geyser/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"net"
+	"net/url"
+	"time"
+)
+
+// maxMessageSize bounds a received message; blocks of full transactions are not subscribed to.
+const maxMessageSize = 64 << 20
+
+// Client connects to a Yellowstone gRPC endpoint. Plain http endpoints are used without TLS.
+type Client struct {
+	conn   *grpc.ClientConn
+	geyser pb.GeyserClient
+}
+
+// NewClient returns a client of the endpoint that authenticates with the x-token header, if a token is set.
+// The connection is established on the first call.
+func NewClient(endpoint, token string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("[geyser] invalid endpoint %q: %w", endpoint, err)
+	}
+	var creds credentials.TransportCredentials
+	var port string // used when the endpoint has none
+	switch u.Scheme {
+	case "http":
+		creds, port = insecure.NewCredentials(), "80"
+	case "https":
+		creds, port = credentials.NewClientTLSFromCert(nil, ""), "443"
+	default:
+		return nil, fmt.Errorf("[geyser] unsupported endpoint scheme %q", u.Scheme)
+	}
+
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)),
+		// HTTP/2 pings detect dead connections; stalled streams are the caller's keepalive
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 30 * time.Second, Timeout: 15 * time.Second}),
+	}
+	if token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(tokenAuth{token: token, secure: u.Scheme == "https"}))
+	}
+	target := u.Host
+	if u.Port() == "" {
+		target = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		return nil, fmt.Errorf("[geyser] failed to create client of %s: %w", endpoint, err)
+	}
+	return &Client{conn: conn, geyser: pb.NewGeyserClient(conn)}, nil
+}
+
+// Subscribe opens a subscription with the initial request. The stream ends with the context.
+func (c *Client) Subscribe(ctx context.Context, request *pb.SubscribeRequest) (pb.Geyser_SubscribeClient, error) {
+	stream, err := c.geyser.Subscribe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[geyser] subscribe failed: %w", err)
+	}
+	if err := stream.Send(request); err != nil {
+		return nil, fmt.Errorf("[geyser] subscribe failed: %w", err)
+	}
+	return stream, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// tokenAuth sends the token of the endpoint with every call.
+type tokenAuth struct {
+	token  string
+	secure bool
+}
+
+func (a tokenAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"x-token": a.token}, nil
+}
+
+func (a tokenAuth) RequireTransportSecurity() bool {
+	return a.secure
+}
@@ -0,0 +1,357 @@
+package geyser
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	pb "github.com/Tsisar/solana-indexer/internal/core/source/geyser/proto"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Result converts a transaction update into the getTransaction result the RPC would return for it
+// with base64 encoding, so it is stored and parsed like a fetched transaction. Block times are
+// not part of transaction updates and come from the block meta of the slot, if known.
+func Result(update *pb.SubscribeUpdateTransaction, blockTime *int64) (*rpc.GetTransactionResult, error) {
+	info := update.GetTransaction()
+	if info.GetTransaction() == nil || info.GetMeta() == nil {
+		return nil, fmt.Errorf("[geyser] transaction or meta missing")
+	}
+	tx, err := transaction(info.GetTransaction())
+	if err != nil {
+		return nil, err
+	}
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("[geyser] failed to encode transaction: %w", err)
+	}
+	envelope, err := json.Marshal([]string{base64.StdEncoding.EncodeToString(bin), string(solana.EncodingBase64)})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &rpc.GetTransactionResult{
+		Slot:        update.GetSlot(),
+		Transaction: new(rpc.TransactionResultEnvelope),
+		Version:     rpc.LegacyTransactionVersion,
+	}
+	if err := result.Transaction.UnmarshalJSON(envelope); err != nil {
+		return nil, fmt.Errorf("[geyser] failed to wrap transaction: %w", err)
+	}
+	if info.GetTransaction().GetMessage().GetVersioned() {
+		result.Version = 0
+	}
+	if blockTime != nil {
+		bt := solana.UnixTimeSeconds(*blockTime)
+		result.BlockTime = &bt
+	}
+	if result.Meta, err = meta(info.GetMeta()); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NewTransactionUpdate converts a getTransaction result back into a transaction update,
+// e.g. to replay recorded transactions.
+func NewTransactionUpdate(result *rpc.GetTransactionResult) (*pb.SubscribeUpdateTransaction, error) {
+	if result.Transaction == nil || result.Meta == nil {
+		return nil, fmt.Errorf("[geyser] transaction or meta missing")
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("[geyser] failed to decode transaction: %w", err)
+	}
+	if len(tx.Signatures) == 0 {
+		return nil, fmt.Errorf("[geyser] transaction has no signature")
+	}
+
+	message := &pb.Message{
+		Header: &pb.MessageHeader{
+			NumRequiredSignatures:       uint32(tx.Message.Header.NumRequiredSignatures),
+			NumReadonlySignedAccounts:   uint32(tx.Message.Header.NumReadonlySignedAccounts),
+			NumReadonlyUnsignedAccounts: uint32(tx.Message.Header.NumReadonlyUnsignedAccounts),
+		},
+		RecentBlockhash: tx.Message.RecentBlockhash[:],
+		Versioned:       tx.Message.IsVersioned(),
+	}
+	for _, key := range tx.Message.AccountKeys {
+		message.AccountKeys = append(message.AccountKeys, key[:])
+	}
+	for _, instruction := range tx.Message.Instructions {
+		message.Instructions = append(message.Instructions, compiledInstruction(instruction))
+	}
+	for _, lookup := range tx.Message.AddressTableLookups {
+		message.AddressTableLookups = append(message.AddressTableLookups, &pb.MessageAddressTableLookup{
+			AccountKey:      lookup.AccountKey[:],
+			WritableIndexes: lookup.WritableIndexes,
+			ReadonlyIndexes: lookup.ReadonlyIndexes,
+		})
+	}
+	info := &pb.SubscribeUpdateTransactionInfo{
+		Signature:   tx.Signatures[0][:],
+		Transaction: &pb.Transaction{Message: message},
+		Meta: &pb.TransactionStatusMeta{
+			Fee:                   result.Meta.Fee,
+			PreBalances:           result.Meta.PreBalances,
+			PostBalances:          result.Meta.PostBalances,
+			LogMessages:           result.Meta.LogMessages,
+			LogMessagesNone:       result.Meta.LogMessages == nil,
+			InnerInstructionsNone: result.Meta.InnerInstructions == nil,
+			PreTokenBalances:      tokenBalances(result.Meta.PreTokenBalances),
+			PostTokenBalances:     tokenBalances(result.Meta.PostTokenBalances),
+			ComputeUnitsConsumed:  result.Meta.ComputeUnitsConsumed,
+		},
+	}
+	for _, signature := range tx.Signatures {
+		info.Transaction.Signatures = append(info.Transaction.Signatures, signature[:])
+	}
+
+	if result.Meta.Err != nil {
+		// The bincode encoding of the error isn't known here, only that the transaction failed
+		err, _ := json.Marshal(result.Meta.Err)
+		info.Meta.Err = &pb.TransactionError{Err: err}
+	}
+	for _, inner := range result.Meta.InnerInstructions {
+		converted := &pb.InnerInstructions{Index: uint32(inner.Index)}
+		for _, instruction := range inner.Instructions {
+			c := compiledInstruction(instruction)
+			converted.Instructions = append(converted.Instructions, &pb.InnerInstruction{
+				ProgramIdIndex: c.ProgramIdIndex,
+				Accounts:       c.Accounts,
+				Data:           c.Data,
+			})
+		}
+		info.Meta.InnerInstructions = append(info.Meta.InnerInstructions, converted)
+	}
+	for _, address := range result.Meta.LoadedAddresses.Writable {
+		info.Meta.LoadedWritableAddresses = append(info.Meta.LoadedWritableAddresses, address[:])
+	}
+	for _, address := range result.Meta.LoadedAddresses.ReadOnly {
+		info.Meta.LoadedReadonlyAddresses = append(info.Meta.LoadedReadonlyAddresses, address[:])
+	}
+	return &pb.SubscribeUpdateTransaction{Transaction: info, Slot: result.Slot}, nil
+}
+
+// Mentions reports whether the transaction references the account statically or through a lookup table.
+func Mentions(info *pb.SubscribeUpdateTransactionInfo, account solana.PublicKey) bool {
+	keys := [][][]byte{
+		info.GetTransaction().GetMessage().GetAccountKeys(),
+		info.GetMeta().GetLoadedWritableAddresses(),
+		info.GetMeta().GetLoadedReadonlyAddresses(),
+	}
+	for _, keys := range keys {
+		for _, key := range keys {
+			if string(key) == string(account[:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// transaction builds the signed transaction.
+func transaction(t *pb.Transaction) (*solana.Transaction, error) {
+	tx := &solana.Transaction{}
+	for _, signature := range t.GetSignatures() {
+		if len(signature) != solana.SignatureLength {
+			return nil, fmt.Errorf("[geyser] invalid signature length %d", len(signature))
+		}
+		tx.Signatures = append(tx.Signatures, solana.SignatureFromBytes(signature))
+	}
+
+	m := t.GetMessage()
+	tx.Message.Header = solana.MessageHeader{
+		NumRequiredSignatures:       uint8(m.GetHeader().GetNumRequiredSignatures()),
+		NumReadonlySignedAccounts:   uint8(m.GetHeader().GetNumReadonlySignedAccounts()),
+		NumReadonlyUnsignedAccounts: uint8(m.GetHeader().GetNumReadonlyUnsignedAccounts()),
+	}
+	for _, key := range m.GetAccountKeys() {
+		publicKey, err := publicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		tx.Message.AccountKeys = append(tx.Message.AccountKeys, publicKey)
+	}
+	blockhash, err := publicKey(m.GetRecentBlockhash())
+	if err != nil {
+		return nil, err
+	}
+	tx.Message.RecentBlockhash = solana.Hash(blockhash)
+	for _, instruction := range m.GetInstructions() {
+		tx.Message.Instructions = append(tx.Message.Instructions,
+			instructionOf(instruction.GetProgramIdIndex(), instruction.GetAccounts(), instruction.GetData()))
+	}
+
+	if m.GetVersioned() {
+		lookups := make([]solana.MessageAddressTableLookup, 0, len(m.GetAddressTableLookups()))
+		for _, lookup := range m.GetAddressTableLookups() {
+			table, err := publicKey(lookup.GetAccountKey())
+			if err != nil {
+				return nil, err
+			}
+			lookups = append(lookups, solana.MessageAddressTableLookup{
+				AccountKey:      table,
+				WritableIndexes: lookup.GetWritableIndexes(),
+				ReadonlyIndexes: lookup.GetReadonlyIndexes(),
+			})
+		}
+		tx.Message.SetAddressTableLookups(lookups)
+	}
+	return tx, nil
+}
+
+// instructionOf builds an instruction from the fields the outer and inner instructions share.
+func instructionOf(programIDIndex uint32, accountIndexes, data []byte) solana.CompiledInstruction {
+	accounts := make([]uint16, len(accountIndexes))
+	for i, account := range accountIndexes {
+		accounts[i] = uint16(account)
+	}
+	return solana.CompiledInstruction{
+		ProgramIDIndex: uint16(programIDIndex),
+		Accounts:       accounts,
+		Data:           data,
+	}
+}
+
+func compiledInstruction(instruction solana.CompiledInstruction) *pb.CompiledInstruction {
+	accounts := make([]byte, len(instruction.Accounts))
+	for i, account := range instruction.Accounts {
+		accounts[i] = byte(account)
+	}
+	return &pb.CompiledInstruction{
+		ProgramIdIndex: uint32(instruction.ProgramIDIndex),
+		Accounts:       accounts,
+		Data:           instruction.Data,
+	}
+}
+
+// meta builds the transaction meta in the shape of the RPC.
+func meta(m *pb.TransactionStatusMeta) (*rpc.TransactionMeta, error) {
+	converted := &rpc.TransactionMeta{
+		Fee:                  m.GetFee(),
+		PreBalances:          m.GetPreBalances(),
+		PostBalances:         m.GetPostBalances(),
+		Status:               rpc.DeprecatedTransactionMetaStatus{"Ok": nil},
+		ComputeUnitsConsumed: m.ComputeUnitsConsumed,
+	}
+	if m.GetErr() != nil {
+		converted.Err = base64.StdEncoding.EncodeToString(m.GetErr().GetErr())
+		converted.Status = rpc.DeprecatedTransactionMetaStatus{"Err": converted.Err}
+	}
+	// The parser tells transactions without log recording from ones without logs
+	if !m.GetLogMessagesNone() {
+		converted.LogMessages = append([]string{}, m.GetLogMessages()...)
+	}
+	if !m.GetInnerInstructionsNone() {
+		converted.InnerInstructions = []rpc.InnerInstruction{}
+	}
+	for _, inner := range m.GetInnerInstructions() {
+		instructions := rpc.InnerInstruction{Index: uint16(inner.GetIndex())}
+		for _, instruction := range inner.GetInstructions() {
+			instructions.Instructions = append(instructions.Instructions,
+				instructionOf(instruction.GetProgramIdIndex(), instruction.GetAccounts(), instruction.GetData()))
+		}
+		converted.InnerInstructions = append(converted.InnerInstructions, instructions)
+	}
+
+	var err error
+	if converted.PreTokenBalances, err = rpcTokenBalances(m.GetPreTokenBalances()); err != nil {
+		return nil, err
+	}
+	if converted.PostTokenBalances, err = rpcTokenBalances(m.GetPostTokenBalances()); err != nil {
+		return nil, err
+	}
+	converted.LoadedAddresses.Writable, err = publicKeys(m.GetLoadedWritableAddresses())
+	if err != nil {
+		return nil, err
+	}
+	converted.LoadedAddresses.ReadOnly, err = publicKeys(m.GetLoadedReadonlyAddresses())
+	if err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+func rpcTokenBalances(balances []*pb.TokenBalance) ([]rpc.TokenBalance, error) {
+	converted := make([]rpc.TokenBalance, 0, len(balances))
+	for _, balance := range balances {
+		mint, err := solana.PublicKeyFromBase58(balance.GetMint())
+		if err != nil {
+			return nil, fmt.Errorf("[geyser] invalid mint %q: %w", balance.GetMint(), err)
+		}
+		amount := balance.GetUiTokenAmount()
+		uiAmount := amount.GetUiAmount()
+		b := rpc.TokenBalance{
+			AccountIndex: uint16(balance.GetAccountIndex()),
+			Mint:         mint,
+			UiTokenAmount: &rpc.UiTokenAmount{
+				Amount:         amount.GetAmount(),
+				Decimals:       uint8(amount.GetDecimals()),
+				UiAmount:       &uiAmount,
+				UiAmountString: amount.GetUiAmountString(),
+			},
+		}
+		if balance.GetOwner() != "" {
+			owner, err := solana.PublicKeyFromBase58(balance.GetOwner())
+			if err != nil {
+				return nil, fmt.Errorf("[geyser] invalid token owner %q: %w", balance.GetOwner(), err)
+			}
+			b.Owner = &owner
+		}
+		if balance.GetProgramId() != "" {
+			program, err := solana.PublicKeyFromBase58(balance.GetProgramId())
+			if err != nil {
+				return nil, fmt.Errorf("[geyser] invalid token program %q: %w", balance.GetProgramId(), err)
+			}
+			b.ProgramId = &program
+		}
+		converted = append(converted, b)
+	}
+	return converted, nil
+}
+
+func tokenBalances(balances []rpc.TokenBalance) []*pb.TokenBalance {
+	converted := make([]*pb.TokenBalance, 0, len(balances))
+	for _, balance := range balances {
+		b := &pb.TokenBalance{
+			AccountIndex:  uint32(balance.AccountIndex),
+			Mint:          balance.Mint.String(),
+			UiTokenAmount: &pb.UiTokenAmount{},
+		}
+		if amount := balance.UiTokenAmount; amount != nil {
+			b.UiTokenAmount.Amount = amount.Amount
+			b.UiTokenAmount.Decimals = uint32(amount.Decimals)
+			b.UiTokenAmount.UiAmountString = amount.UiAmountString
+			if amount.UiAmount != nil {
+				b.UiTokenAmount.UiAmount = *amount.UiAmount
+			}
+		}
+		if balance.Owner != nil {
+			b.Owner = balance.Owner.String()
+		}
+		if balance.ProgramId != nil {
+			b.ProgramId = balance.ProgramId.String()
+		}
+		converted = append(converted, b)
+	}
+	return converted
+}
+
+func publicKey(b []byte) (solana.PublicKey, error) {
+	if len(b) != solana.PublicKeyLength {
+		return solana.PublicKey{}, fmt.Errorf("[geyser] invalid public key length %d", len(b))
+	}
+	return solana.PublicKeyFromBytes(b), nil
+}
+
+func publicKeys(keys [][]byte) (solana.PublicKeySlice, error) {
+	converted := make(solana.PublicKeySlice, 0, len(keys))
+	for _, key := range keys {
+		publicKey, err := publicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, publicKey)
+	}
+	return converted, nil
+}
@@ -0,0 +1,6 @@
+// Package proto holds the Yellowstone gRPC schema, geyser.proto and solana-storage.proto as
+// published in rpcpool/yellowstone-grpc, and the Go stubs generated from it. Only go_package
+// differs from upstream, so the stubs live in this module.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative geyser.proto solana-storage.proto
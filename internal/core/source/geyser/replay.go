@@ -0,0 +1,208 @@
+package geyser
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	pb "github.com/Tsisar/solana-indexer/internal/core/source/geyser/proto"
+	"github.com/Tsisar/solana-indexer/internal/storage/archive"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"google.golang.org/grpc"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ReplayServer is a local Geyser endpoint that replays recorded transactions to every subscriber,
+// for running the indexer without a Yellowstone provider. Transactions are sent in slot order with
+// the block meta of each slot after its transactions, then the stream is kept open with pings.
+type ReplayServer struct {
+	pb.UnimplementedGeyserServer
+	updates      []*pb.SubscribeUpdateTransaction
+	blockTimes   map[uint64]*int64
+	PingInterval time.Duration
+}
+
+// NewReplayServer returns a server replaying the given getTransaction results.
+func NewReplayServer(results []*rpc.GetTransactionResult) (*ReplayServer, error) {
+	s := &ReplayServer{blockTimes: make(map[uint64]*int64), PingInterval: 15 * time.Second}
+	for _, result := range results {
+		update, err := NewTransactionUpdate(result)
+		if err != nil {
+			return nil, err
+		}
+		s.updates = append(s.updates, update)
+		if result.BlockTime != nil {
+			blockTime := int64(*result.BlockTime)
+			s.blockTimes[result.Slot] = &blockTime
+		}
+	}
+	slices.SortStableFunc(s.updates, func(a, b *pb.SubscribeUpdateTransaction) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+	return s, nil
+}
+
+// LoadRecording reads the transactions of an archive written by the export command.
+func LoadRecording(r io.Reader) ([]*rpc.GetTransactionResult, error) {
+	ar, err := archive.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	var results []*rpc.GetTransactionResult
+	for {
+		record, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record.JsonTx) == 0 || string(record.JsonTx) == "null" {
+			continue
+		}
+		result := &rpc.GetTransactionResult{}
+		if err := json.Unmarshal(record.JsonTx, result); err != nil {
+			return nil, fmt.Errorf("[geyser] invalid transaction %s: %w", record.Signature, err)
+		}
+		results = append(results, result)
+	}
+}
+
+// Serve accepts connections on the listener until the context ends.
+func (s *ReplayServer) Serve(ctx context.Context, l net.Listener) error {
+	server := grpc.NewServer()
+	pb.RegisterGeyserServer(server, s)
+
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+	if err := server.Serve(l); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("[geyser] replay server failed: %w", err)
+	}
+	return nil
+}
+
+// Subscribe serves one Subscribe call.
+func (s *ReplayServer) Subscribe(stream pb.Geyser_SubscribeServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	out := &replayStream{stream: stream}
+
+	// Pings of the client are answered; the call ends when the client closes its side
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			ping, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if ping.Ping != nil {
+				out.send(&pb.SubscribeUpdate{UpdateOneof: &pb.SubscribeUpdate_Pong{
+					Pong: &pb.SubscribeUpdatePong{Id: ping.Ping.Id},
+				}})
+			}
+		}
+	}()
+
+	ctx := stream.Context()
+	if err := s.replay(ctx, out, request); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(s.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-closed:
+			return nil
+		case <-ticker.C:
+			if err := out.send(&pb.SubscribeUpdate{UpdateOneof: &pb.SubscribeUpdate_Ping{}}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replay sends the recorded transactions matching the request's filters.
+func (s *ReplayServer) replay(ctx context.Context, out *replayStream, request *pb.SubscribeRequest) error {
+	blocksMeta := slices.Sorted(maps.Keys(request.BlocksMeta))
+	sent := 0
+	for i, update := range s.updates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if filters := s.matching(update.Transaction, request); len(filters) > 0 {
+			err := out.send(&pb.SubscribeUpdate{
+				Filters:     filters,
+				UpdateOneof: &pb.SubscribeUpdate_Transaction{Transaction: update},
+			})
+			if err != nil {
+				return err
+			}
+			sent++
+		}
+		lastOfSlot := i == len(s.updates)-1 || s.updates[i+1].Slot != update.Slot
+		if lastOfSlot && len(blocksMeta) > 0 {
+			meta := &pb.SubscribeUpdateBlockMeta{Slot: update.Slot}
+			if blockTime := s.blockTimes[update.Slot]; blockTime != nil {
+				meta.BlockTime = &pb.UnixTimestamp{Timestamp: *blockTime}
+			}
+			err := out.send(&pb.SubscribeUpdate{
+				Filters:     blocksMeta,
+				UpdateOneof: &pb.SubscribeUpdate_BlockMeta{BlockMeta: meta},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	log.Infof("[geyser] Replayed %d transactions", sent)
+	return nil
+}
+
+// matching returns the names of the transaction filters an update matches.
+func (s *ReplayServer) matching(info *pb.SubscribeUpdateTransactionInfo, request *pb.SubscribeRequest) []string {
+	var names []string
+	for name, filter := range request.Transactions {
+		if filter.Vote != nil && *filter.Vote != info.IsVote {
+			continue
+		}
+		if filter.Failed != nil && *filter.Failed != (info.Meta.GetErr() != nil) {
+			continue
+		}
+		for _, account := range filter.AccountInclude {
+			key, err := solana.PublicKeyFromBase58(account)
+			if err == nil && Mentions(info, key) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// replayStream writes the updates of one call; pongs are sent concurrently with the replay.
+type replayStream struct {
+	mu     sync.Mutex
+	stream pb.Geyser_SubscribeServer
+}
+
+func (s *replayStream) send(update *pb.SubscribeUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(update)
+}
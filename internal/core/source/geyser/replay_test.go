@@ -0,0 +1,141 @@
+package geyser
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	pb "github.com/Tsisar/solana-indexer/internal/core/source/geyser/proto"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testResult builds the getTransaction result of a transaction calling the program.
+func testResult(t *testing.T, slot uint64, blockTime int64, program solana.PublicKey, logs ...string) *rpc.GetTransactionResult {
+	t.Helper()
+	payer := solana.NewWallet().PublicKey()
+	tx := &solana.Transaction{
+		Signatures: []solana.Signature{solana.SignatureFromBytes(solana.NewWallet().PrivateKey[:64])},
+		Message: solana.Message{
+			Header:          solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+			AccountKeys:     solana.PublicKeySlice{payer, program},
+			RecentBlockhash: solana.Hash(solana.NewWallet().PublicKey()),
+			Instructions:    []solana.CompiledInstruction{{ProgramIDIndex: 1, Accounts: []uint16{0}, Data: []byte{1, 2, 3}}},
+		},
+	}
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, _ := json.Marshal([]string{base64.StdEncoding.EncodeToString(bin), string(solana.EncodingBase64)})
+	result := &rpc.GetTransactionResult{
+		Slot:        slot,
+		Transaction: new(rpc.TransactionResultEnvelope),
+		Meta: &rpc.TransactionMeta{
+			Fee:               5000,
+			PreBalances:       []uint64{10_000, 1},
+			PostBalances:      []uint64{5_000, 1},
+			LogMessages:       logs,
+			InnerInstructions: []rpc.InnerInstruction{},
+		},
+	}
+	if err := result.Transaction.UnmarshalJSON(envelope); err != nil {
+		t.Fatal(err)
+	}
+	bt := solana.UnixTimeSeconds(blockTime)
+	result.BlockTime = &bt
+	return result
+}
+
+func TestReplayServerStreams(t *testing.T) {
+	program := solana.NewWallet().PublicKey()
+	other := solana.NewWallet().PublicKey()
+	results := []*rpc.GetTransactionResult{
+		testResult(t, 11, 1_700_000_011, other, "other"),
+		testResult(t, 10, 1_700_000_010, program, "first"),
+	}
+	server, err := NewReplayServer(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.PingInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(ctx, l) }()
+
+	client, err := NewClient("http://"+l.Addr().String(), "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	exclude := false
+	commitment := pb.CommitmentLevel_CONFIRMED
+	stream, err := client.Subscribe(ctx, &pb.SubscribeRequest{
+		Transactions: map[string]*pb.SubscribeRequestFilterTransactions{
+			"program": {Vote: &exclude, Failed: &exclude, AccountInclude: []string{program.String()}},
+		},
+		BlocksMeta: map[string]*pb.SubscribeRequestFilterBlocksMeta{"blocks": {}},
+		Commitment: &commitment,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The transaction of the program, then the block meta of each slot
+	update, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := update.GetTransaction()
+	if tx == nil || !slices.Equal(update.Filters, []string{"program"}) {
+		t.Fatalf("expected a transaction of the program filter, got %v", update)
+	}
+	result, err := Result(tx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := results[1].Transaction.GetTransaction()
+	got, _ := result.Transaction.GetTransaction()
+	if result.Slot != 10 || got.Signatures[0] != want.Signatures[0] {
+		t.Errorf("expected transaction %s in slot 10, got %s in slot %d", want.Signatures[0], got.Signatures[0], result.Slot)
+	}
+	if !slices.Equal(result.Meta.LogMessages, []string{"first"}) || result.Meta.Fee != 5000 {
+		t.Errorf("unexpected meta %+v", result.Meta)
+	}
+	for _, slot := range []uint64{10, 11} {
+		update, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta := update.GetBlockMeta()
+		if meta == nil || meta.Slot != slot || meta.BlockTime.GetTimestamp() != 1_700_000_000+int64(slot) {
+			t.Fatalf("expected the block meta of slot %d, got %v", slot, update)
+		}
+	}
+
+	// Pings are answered with their id
+	if err := stream.Send(&pb.SubscribeRequest{Ping: &pb.SubscribeRequestPing{Id: 7}}); err != nil {
+		t.Fatal(err)
+	}
+	update, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.GetPong().GetId() != 7 {
+		t.Fatalf("expected pong 7, got %v", update)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Fatal(err)
+	}
+}
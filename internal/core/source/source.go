@@ -0,0 +1,31 @@
+package source
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Transaction is a transaction that mentions a subscribed program.
+type Transaction struct {
+	Signature  string
+	Slot       uint64
+	BlockIndex *uint32                   // position in its block, nil if the source doesn't know it
+	Result     *rpc.GetTransactionResult // the full transaction, nil if it has to be fetched by signature
+}
+
+// Source delivers the transactions that mention a program in real time.
+type Source interface {
+	// Subscribe delivers the transactions of the program until the context ends, returning nil,
+	// or the subscription fails. subscribed is called once the subscription is established;
+	// transactions arriving meanwhile are delivered after it returns.
+	Subscribe(ctx context.Context, program config.Program, subscribed func() error, deliver func(Transaction) error) error
+}
+
+// New returns the configured source.
+func New() (Source, error) {
+	if config.App.Source.Kind == config.SourceYellowstone {
+		return NewYellowstone(config.App.Source.Yellowstone.Endpoint, config.App.Source.Yellowstone.Token)
+	}
+	return &WebSocket{Endpoint: config.App.RPC.WSEndpoint}, nil
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"time"
+)
+
+// WebSocket subscribes to the logs mentioning a program over the WebSocket of the RPC.
+// Notifications only carry the signature, so the transactions are fetched afterwards.
+type WebSocket struct {
+	Endpoint string
+}
+
+// Subscribe opens a connection of its own for the program.
+func (w *WebSocket) Subscribe(ctx context.Context, program config.Program, subscribed func() error, deliver func(Transaction) error) error {
+	publicKey, err := solana.PublicKeyFromBase58(program.Address)
+	if err != nil {
+		return fmt.Errorf("[source] invalid program address %s: %w", program.Address, err)
+	}
+
+	wsClient, err := ws.Connect(ctx, w.Endpoint)
+	if err != nil {
+		return fmt.Errorf("[source] failed to connect to WebSocket: %w", err)
+	}
+	defer wsClient.Close()
+
+	sub, err := wsClient.LogsSubscribeMentions(publicKey, rpc.CommitmentType(program.Commitment))
+	if err != nil {
+		return fmt.Errorf("[source] logs subscribe failed for %s: %w", program.Address, err)
+	}
+	defer sub.Unsubscribe()
+	log.Infof("[source] subscribed to program %s", program.Address)
+
+	// The keepalive ends the subscription with the reason as cause
+	conn, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	if timeout := config.App.RPC.KeepaliveTimeout; timeout > 0 {
+		go keepalive(conn, stop, wsClient, timeout)
+	}
+
+	// Notifications received meanwhile are buffered by the subscription
+	if err := subscribed(); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := sub.Recv(conn)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if conn.Err() != nil {
+				return context.Cause(conn)
+			}
+			return fmt.Errorf("[source] recv failed for %s: %w", program.Address, err)
+		}
+		if msg == nil {
+			continue
+		}
+
+		tx := Transaction{Signature: msg.Value.Signature.String(), Slot: msg.Context.Slot}
+		if err := deliver(tx); err != nil {
+			return err
+		}
+	}
+}
+
+// keepalive follows the slot notifications of a connection, which arrive several times a second,
+// and stops the subscription when none arrives within the timeout. The client's WebSocket pings
+// only detect dead connections; this also catches open ones that stopped delivering.
+func keepalive(ctx context.Context, stop context.CancelCauseFunc, wsClient *ws.Client, timeout time.Duration) {
+	sub, err := wsClient.SlotSubscribe()
+	if err != nil {
+		stop(fmt.Errorf("[source] slot subscribe failed: %w", err))
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		recvCtx, cancel := context.WithTimeout(ctx, timeout)
+		_, err := sub.Recv(recvCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			stop(fmt.Errorf("[source] no slot notification within %s", timeout))
+			return
+		}
+		if err != nil {
+			stop(fmt.Errorf("[source] slot subscription failed: %w", err))
+			return
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package source
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/core/source/geyser"
+	pb "github.com/Tsisar/solana-indexer/internal/core/source/geyser/proto"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// blockMetaLag is how many slots a transaction waits for the block meta of its slot. Without one,
+// it is delivered by signature and fetched, so its block time comes from the RPC.
+const blockMetaLag = 150
+
+// Yellowstone subscribes to the transactions mentioning a program over Yellowstone gRPC.
+// Updates carry the full transaction, so no getTransaction round trip is needed; they are
+// delivered once the block meta of their slot provides the block time.
+type Yellowstone struct {
+	client *geyser.Client
+}
+
+// NewYellowstone returns a source streaming from the endpoint, authenticated with the token if set.
+func NewYellowstone(endpoint, token string) (*Yellowstone, error) {
+	client, err := geyser.NewClient(endpoint, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Yellowstone{client: client}, nil
+}
+
+// Subscribe opens a stream of its own for the program.
+func (y *Yellowstone) Subscribe(ctx context.Context, program config.Program, subscribed func() error, deliver func(Transaction) error) error {
+	commitment := pb.CommitmentLevel_CONFIRMED
+	if program.Commitment == config.CommitmentFinalized {
+		commitment = pb.CommitmentLevel_FINALIZED
+	}
+	exclude := false
+	request := &pb.SubscribeRequest{
+		Transactions: map[string]*pb.SubscribeRequestFilterTransactions{
+			program.Address: {Vote: &exclude, Failed: &exclude, AccountInclude: []string{program.Address}},
+		},
+		BlocksMeta: map[string]*pb.SubscribeRequestFilterBlocksMeta{"blocks": {}},
+		Commitment: &commitment,
+	}
+
+	// The keepalive ends the stream with the reason as cause
+	conn, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	stream, err := y.client.Subscribe(conn, request)
+	if err != nil {
+		return fmt.Errorf("[source] yellowstone subscribe failed for %s: %w", program.Address, err)
+	}
+	log.Infof("[source] subscribed to program %s over Yellowstone gRPC", program.Address)
+
+	// Updates received meanwhile wait in the stream
+	if err := subscribed(); err != nil {
+		return err
+	}
+
+	// Block metas arrive every slot, so a stream without updates is stale
+	keepalive := func() {}
+	if timeout := config.App.RPC.KeepaliveTimeout; timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			stop(fmt.Errorf("[source] no update within %s", timeout))
+		})
+		defer timer.Stop()
+		keepalive = func() { timer.Reset(timeout) }
+	}
+
+	slots := &slotBuffer{slots: make(map[uint64][]*pb.SubscribeUpdateTransaction), deliver: deliver}
+	defer slots.release()
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if conn.Err() != nil {
+				return context.Cause(conn)
+			}
+			if errors.Is(err, io.EOF) {
+				err = errors.New("stream ended by the server")
+			}
+			return fmt.Errorf("[source] recv failed for %s: %w", program.Address, err)
+		}
+		keepalive()
+
+		switch u := update.UpdateOneof.(type) {
+		case *pb.SubscribeUpdate_Ping:
+			// Answering keeps load balancers in front of the endpoint from closing the stream
+			if err := stream.Send(&pb.SubscribeRequest{Ping: &pb.SubscribeRequestPing{Id: 1}}); err != nil {
+				return err
+			}
+		case *pb.SubscribeUpdate_Transaction:
+			slots.add(u.Transaction)
+		case *pb.SubscribeUpdate_BlockMeta:
+			var blockTime *int64
+			if u.BlockMeta.BlockTime != nil {
+				blockTime = &u.BlockMeta.BlockTime.Timestamp
+			}
+			if err := slots.complete(u.BlockMeta.Slot, blockTime); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// slotBuffer holds the transactions of each slot until its block meta arrives.
+type slotBuffer struct {
+	slots   map[uint64][]*pb.SubscribeUpdateTransaction
+	deliver func(Transaction) error
+}
+
+func (b *slotBuffer) add(update *pb.SubscribeUpdateTransaction) {
+	b.slots[update.Slot] = append(b.slots[update.Slot], update)
+}
+
+// complete delivers the transactions of the slot with its block time, after those of slots that
+// fell blockMetaLag behind without a block meta.
+func (b *slotBuffer) complete(slot uint64, blockTime *int64) error {
+	for _, s := range slices.Sorted(maps.Keys(b.slots)) {
+		if s+blockMetaLag >= slot {
+			break
+		}
+		log.Warnf("[source] No block meta for slot %d, its transactions are fetched", s)
+		if err := b.flush(s, nil, false); err != nil {
+			return err
+		}
+	}
+	return b.flush(slot, blockTime, true)
+}
+
+// release delivers the transactions still waiting by signature when the stream ends, so they are
+// fetched; a failed delivery is left to the gap fill.
+func (b *slotBuffer) release() {
+	for _, s := range slices.Sorted(maps.Keys(b.slots)) {
+		if err := b.flush(s, nil, false); err != nil {
+			log.Warnf("[source] %v", err)
+			return
+		}
+	}
+}
+
+// flush delivers the transactions of a slot in block order, with their payload if full is set.
+func (b *slotBuffer) flush(slot uint64, blockTime *int64, full bool) error {
+	updates := b.slots[slot]
+	delete(b.slots, slot)
+	slices.SortFunc(updates, func(x, y *pb.SubscribeUpdateTransaction) int {
+		return cmp.Compare(x.Transaction.GetIndex(), y.Transaction.GetIndex())
+	})
+
+	for _, update := range updates {
+		tx := Transaction{
+			Signature:  solana.SignatureFromBytes(update.Transaction.GetSignature()).String(),
+			Slot:       slot,
+			BlockIndex: utils.Ptr(uint32(update.Transaction.GetIndex())),
+		}
+		if full {
+			result, err := geyser.Result(update, blockTime)
+			if err != nil {
+				log.Warnf("[source] Failed to convert transaction %s, it is fetched: %v", tx.Signature, err)
+			}
+			tx.Result = result
+		}
+		if err := b.deliver(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
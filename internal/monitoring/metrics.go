@@ -2,9 +2,9 @@ package monitoring
 
 import (
 	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/prometheus/client_golang/prometheus"
-	"gorm.io/gorm"
 	"math/big"
 )
 
@@ -30,6 +30,51 @@ var (
 		},
 	)
 
+	ListenerReconnectsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "indexer_listener_reconnects_total",
+			Help: "Number of WebSocket subscription reconnects per program",
+		},
+		[]string{"program"},
+	)
+
+	ListenerSecondsSinceNotification = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "indexer_listener_seconds_since_last_notification",
+			Help: "Seconds since the WebSocket subscription of a program delivered its last notification",
+		},
+		[]string{"program"},
+	)
+
+	FinalizedSlot = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "indexer_finalized_slot",
+			Help: "Highest finalized slot up to which transactions were reconciled",
+		},
+	)
+
+	DroppedTransactionsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "indexer_dropped_transactions_total",
+			Help: "Number of confirmed transactions reverted because they were not finalized",
+		},
+	)
+
+	WebhookTransactionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "indexer_webhook_transactions_total",
+			Help: "Number of transactions pushed to the webhook by result: accepted, duplicate or rejected",
+		},
+		[]string{"result"},
+	)
+
+	RawPrunedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "indexer_raw_pruned_total",
+			Help: "Number of transactions whose raw payload was pruned by the retention",
+		},
+	)
+
 	DepositsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "indexer_deposit_total",
@@ -84,6 +129,12 @@ func init() {
 		FetcherCurrentSlot,
 		ParserCurrentSlot,
 		ListenerCurrentSlot,
+		ListenerReconnectsTotal,
+		ListenerSecondsSinceNotification,
+		FinalizedSlot,
+		DroppedTransactionsTotal,
+		WebhookTransactionsTotal,
+		RawPrunedTotal,
 		DepositsTotal,
 		WithdrawalsTotal,
 		DepositTokenSum,
@@ -93,7 +144,7 @@ func init() {
 	)
 }
 
-func Withdrawal(ctx context.Context, db *gorm.DB, withdraw subgraph.Withdrawal) {
+func Withdrawal(ctx context.Context, db generic.EntityStore, withdraw subgraph.Withdrawal) {
 	token := subgraph.Token{ID: withdraw.TokenID}
 	ok, err := token.Load(ctx, db)
 	if err != nil || !ok {
@@ -111,7 +162,7 @@ func Withdrawal(ctx context.Context, db *gorm.DB, withdraw subgraph.Withdrawal)
 	WithdrawalTokenSum.WithLabelValues(withdraw.VaultID, withdraw.TokenID).Add(f64)
 }
 
-func Deposit(ctx context.Context, db *gorm.DB, deposit subgraph.Deposit) {
+func Deposit(ctx context.Context, db generic.EntityStore, deposit subgraph.Deposit) {
 	token := subgraph.Token{ID: deposit.TokenID}
 	ok, err := token.Load(ctx, db)
 	if err != nil || !ok {
@@ -0,0 +1,225 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"io"
+)
+
+// An archive holds stored transactions with their raw JSON and program links as JSON lines,
+// optionally gzip compressed. A new deployment imports it instead of fetching the history again.
+// Transactions whose raw JSON was pruned are exported without it and fetched again after the import.
+
+// batchSize is the number of transactions read or written per query.
+const batchSize = 500
+
+// maxLine bounds a single transaction of an archive.
+const maxLine = 64 << 20
+
+// Record is one transaction of an archive.
+type Record struct {
+	Signature string          `json:"signature"`
+	Slot      uint64          `json:"slot"`
+	BlockTime int64           `json:"block_time"`
+	Finalized bool            `json:"finalized"`
+	Programs  []string        `json:"programs"`
+	JsonTx    json.RawMessage `json:"json_tx,omitempty"` // missing if pruned
+}
+
+// Writer writes the records of an archive.
+type Writer struct {
+	buf *bufio.Writer
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+// NewWriter returns a writer of an archive, gzip compressed if compress is set.
+func NewWriter(w io.Writer, compress bool) *Writer {
+	aw := &Writer{}
+	if compress {
+		aw.gz = gzip.NewWriter(w)
+		w = aw.gz
+	}
+	aw.buf = bufio.NewWriter(w)
+	aw.enc = json.NewEncoder(aw.buf)
+	return aw
+}
+
+// Write appends a record.
+func (w *Writer) Write(record *Record) error {
+	return w.enc.Encode(record)
+}
+
+// Close flushes the archive; it doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return nil
+}
+
+// Reader reads the records of an archive.
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewReader returns a reader of an archive; gzip compression is detected.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("[archive] failed to read archive: %w", err)
+	}
+	r = br
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		if r, err = gzip.NewReader(br); err != nil {
+			return nil, fmt.Errorf("[archive] invalid gzip stream: %w", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLine)
+	return &Reader{scanner: scanner}, nil
+}
+
+// Next returns the next record, or io.EOF at the end of the archive.
+func (r *Reader) Next() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			return nil, fmt.Errorf("[archive] line %d: %w", r.line, err)
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("[archive] failed to read line %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+// Export writes the transactions stored in the slot range with their raw JSON, in slot order.
+func Export(ctx context.Context, db *storage.Gorm, w *Writer, fromSlot, toSlot uint64) (int, error) {
+	filter := storage.TransactionFilter{FromSlot: &fromSlot, ToSlot: &toSlot, Limit: batchSize}
+	exported := 0
+	for {
+		txs, err := db.ListTransactions(ctx, filter)
+		if err != nil {
+			return exported, fmt.Errorf("[archive] %w", err)
+		}
+		signatures := make([]string, len(txs))
+		for i, tx := range txs {
+			signatures[i] = tx.Signature
+		}
+		links, err := db.GetTransactionPrograms(ctx, signatures)
+		if err != nil {
+			return exported, fmt.Errorf("[archive] %w", err)
+		}
+
+		for _, tx := range txs {
+			raw, err := db.GetRawTransaction(ctx, tx.Signature)
+			if err != nil {
+				return exported, fmt.Errorf("[archive] failed to load raw transaction %s: %w", tx.Signature, err)
+			}
+			record := &Record{
+				Signature: tx.Signature,
+				Slot:      tx.Slot,
+				BlockTime: tx.BlockTime,
+				Finalized: tx.Finalized,
+				Programs:  links[tx.Signature],
+				JsonTx:    raw,
+			}
+			if err := w.Write(record); err != nil {
+				return exported, fmt.Errorf("[archive] failed to write transaction %s: %w", tx.Signature, err)
+			}
+			exported++
+		}
+		if len(txs) < batchSize {
+			return exported, nil
+		}
+		last := txs[len(txs)-1]
+		filter.After = &storage.TransactionCursor{Slot: last.Slot, Signature: last.Signature}
+	}
+}
+
+// Import stores the transactions of an archive as unparsed, linked to the given programs,
+// and returns how many were read and how many were new. Transactions without raw JSON are stored
+// unfetched, so the fetcher downloads them again. Transactions already stored are kept as they are;
+// links to other programs are skipped.
+func Import(ctx context.Context, db *storage.Gorm, r *Reader, programs []string) (read, imported int, err error) {
+	known := make(map[string]bool, len(programs))
+	for _, program := range programs {
+		known[program] = true
+	}
+
+	batch := make([]core.Transaction, 0, batchSize)
+	links := make(map[string][]string)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.ImportTransactions(ctx, batch, links)
+		if err != nil {
+			return fmt.Errorf("[archive] %w", err)
+		}
+		imported += int(n)
+		batch = batch[:0]
+		links = make(map[string][]string)
+		return nil
+	}
+
+	for {
+		record, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return read, imported, err
+		}
+		read++
+
+		var programs []string
+		for _, program := range record.Programs {
+			if known[program] {
+				programs = append(programs, program)
+			}
+		}
+		// Transactions of programs that aren't indexed here would never be parsed
+		if len(programs) == 0 {
+			continue
+		}
+		tx := core.Transaction{
+			Signature: record.Signature,
+			Slot:      record.Slot,
+			BlockTime: record.BlockTime,
+			Finalized: record.Finalized,
+		}
+		if len(record.JsonTx) > 0 && string(record.JsonTx) != "null" {
+			tx.JsonTx = []byte(record.JsonTx)
+		}
+		batch = append(batch, tx)
+		links[record.Signature] = programs
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return read, imported, err
+			}
+		}
+	}
+	return read, imported, flush()
+}
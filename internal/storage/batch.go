@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"time"
+)
+
+// entityBatch buffers the entity saves of one database transaction, so that consecutive saves to
+// a table are written as one upsert. Saves to different tables keep their order, so a row is
+// never written before one it references; an entity saved again within a run is replaced in it.
+type entityBatch struct {
+	runs     []*entityRun
+	versions map[entityKey]entityVersion // the last version of each entity saved with a slot
+	order    []entityKey                 // entities with a version, in the order first saved
+}
+
+// entityRun is a sequence of saves to the same table.
+type entityRun struct {
+	schema *schema.Schema
+	rows   []map[string]any
+	byID   map[string]int // row index by id
+}
+
+type entityKey struct {
+	table, id string
+}
+
+type entityVersion struct {
+	slot uint64
+	data []byte
+}
+
+// save buffers an entity as the column values Postgres would store, so later changes to the model
+// don't change what is written.
+func (b *entityBatch) save(ctx context.Context, db *gorm.DB, model any) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return fmt.Errorf("failed to parse model %T: %w", model, err)
+	}
+	sch := stmt.Schema
+
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if err := generic.SetForeignKeys(ctx, sch, value); err != nil {
+		return err
+	}
+	now := time.Now()
+	row := make(map[string]any, len(sch.DBNames))
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		fieldValue := field.ReflectValueOf(ctx, value)
+		// Set on the model as a Create would
+		if field.AutoUpdateTime > 0 || field.AutoCreateTime > 0 && fieldValue.IsZero() {
+			if err := field.Set(ctx, value, now); err != nil {
+				return fmt.Errorf("failed to set %s.%s: %w", sch.Table, field.DBName, err)
+			}
+		}
+		// A zero value with a default in the model is inserted as the default, as a Create would
+		if field.DefaultValueInterface != nil && fieldValue.IsZero() {
+			row[field.DBName] = field.DefaultValueInterface
+			continue
+		}
+		v, err := columnValue(fieldValue)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s.%s: %w", sch.Table, field.DBName, err)
+		}
+		row[field.DBName] = v
+	}
+	id, ok := row["id"]
+	if !ok || id == nil {
+		return fmt.Errorf("entity %s has no id", sch.Table)
+	}
+	key := entityKey{table: sch.Table, id: fmt.Sprint(id)}
+
+	var run *entityRun
+	if n := len(b.runs); n > 0 && b.runs[n-1].schema.Table == sch.Table {
+		run = b.runs[n-1]
+	} else {
+		run = &entityRun{schema: sch, byID: make(map[string]int)}
+		b.runs = append(b.runs, run)
+	}
+	if i, ok := run.byID[key.id]; ok {
+		run.rows[i] = row
+	} else {
+		run.byID[key.id] = len(run.rows)
+		run.rows = append(run.rows, row)
+	}
+
+	if slot, ok := generic.SlotFromContext(ctx); ok && generic.VersioningEnabled() {
+		_, _, data, err := generic.VersionData(ctx, db, model)
+		if err != nil {
+			return err
+		}
+		if b.versions == nil {
+			b.versions = make(map[entityKey]entityVersion)
+		}
+		if _, ok := b.versions[key]; !ok {
+			b.order = append(b.order, key)
+		}
+		b.versions[key] = entityVersion{slot: slot, data: data}
+	}
+	return nil
+}
+
+// load sets the last buffered state of the model's entity on it and reports whether there is one.
+func (b *entityBatch) load(ctx context.Context, db *gorm.DB, model generic.Identifiable) (bool, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return false, fmt.Errorf("failed to parse model %T: %w", model, err)
+	}
+	for i := len(b.runs) - 1; i >= 0; i-- {
+		run := b.runs[i]
+		if run.schema.Table != stmt.Schema.Table {
+			continue
+		}
+		j, ok := run.byID[model.GetID()]
+		if !ok {
+			continue
+		}
+		value := reflect.ValueOf(model).Elem()
+		for _, field := range run.schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if err := field.Set(ctx, value, run.rows[j][field.DBName]); err != nil {
+				return false, fmt.Errorf("failed to set %s.%s: %w", run.schema.Table, field.DBName, err)
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// flush writes the buffered entities, one upsert per run, then their versions, and empties the batch.
+func (b *entityBatch) flush(ctx context.Context, db *gorm.DB) error {
+	for _, run := range b.runs {
+		// Like the UpdateAll of SaveToDB: the id and the creation time are kept
+		var update []string
+		for _, field := range run.schema.Fields {
+			if field.DBName != "" && !field.PrimaryKey && field.AutoCreateTime == 0 {
+				update = append(update, field.DBName)
+			}
+		}
+		if err := db.WithContext(ctx).
+			Table(run.schema.Table).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns(update),
+			}).
+			Create(&run.rows).Error; err != nil {
+			return fmt.Errorf("failed to save %d %s: %w", len(run.rows), run.schema.Table, err)
+		}
+	}
+	for _, key := range b.order {
+		version := b.versions[key]
+		if err := generic.WriteVersion(ctx, db, key.table, key.id, version.slot, version.data); err != nil {
+			return err
+		}
+	}
+	*b = entityBatch{}
+	return nil
+}
+
+// columnValue returns the value Postgres would store for a field: that of its driver.Valuer,
+// or the plain value.
+func columnValue(v reflect.Value) (any, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if v.CanAddr() {
+		if valuer, ok := v.Addr().Interface().(driver.Valuer); ok {
+			return valuer.Value()
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		return columnValue(v.Elem())
+	}
+	return v.Interface(), nil
+}
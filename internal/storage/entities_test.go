@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// entityStores returns the memory store and a Gorm buffering saves as within Atomically,
+// whose loads of saved entities never reach the database.
+func entityStores(t *testing.T) map[string]generic.EntityStore {
+	t.Helper()
+	db, err := gorm.Open(nil, &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]generic.EntityStore{
+		"memory": memory.New(),
+		"gorm":   storage.NewBufferedGorm(db),
+	}
+}
+
+func TestEntityStoresSaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	for name, db := range entityStores(t) {
+		t.Run(name, func(t *testing.T) {
+			token := subgraph.Token{ID: "mint", Decimals: *types.NewBigIntFromInt64(6), Symbol: "USDC"}
+			if err := token.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			vault := subgraph.Vault{ID: "vault", Token: &token, TotalIdle: *types.NewBigIntFromInt64(100)}
+			if err := vault.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			// What is stored doesn't change with the model
+			vault.TotalIdle = *types.NewBigIntFromInt64(200)
+
+			loaded := subgraph.Vault{ID: "vault"}
+			ok, err := db.LoadEntity(ctx, &loaded)
+			if err != nil || !ok {
+				t.Fatalf("failed to load the vault: %v", err)
+			}
+			if loaded.TotalIdle.String() != "100" {
+				t.Errorf("expected total idle 100, got %s", loaded.TotalIdle)
+			}
+			// The foreign key of a set belongs-to association is saved, the association is not loaded
+			if loaded.TokenID != "mint" || loaded.Token != nil || loaded.ShareTokenID != "" {
+				t.Errorf("expected token id mint only, got %q, %v, %q", loaded.TokenID, loaded.Token, loaded.ShareTokenID)
+			}
+
+			loaded.TotalIdle = *types.NewBigIntFromInt64(300)
+			if err := loaded.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			again := subgraph.Vault{ID: "vault"}
+			if ok, err := db.LoadEntity(ctx, &again); err != nil || !ok {
+				t.Fatalf("failed to load the vault: %v", err)
+			}
+			if again.TotalIdle.String() != "300" || again.TokenID != "mint" {
+				t.Errorf("expected the saved vault to be replaced, got total idle %s, token %q", again.TotalIdle, again.TokenID)
+			}
+
+			// A zero value is stored as the model's default
+			stats := subgraph.TokenStats{ID: "stats", VaultID: "vault"}
+			if err := stats.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			loadedStats := subgraph.TokenStats{ID: "stats"}
+			if ok, err := db.LoadEntity(ctx, &loadedStats); err != nil || !ok {
+				t.Fatalf("failed to load the token stats: %v", err)
+			}
+			if loadedStats.Interval != "hour" {
+				t.Errorf("expected the default interval hour, got %q", loadedStats.Interval)
+			}
+		})
+	}
+}
+
+func TestEntityStoresSetTimes(t *testing.T) {
+	ctx := context.Background()
+	for name, db := range entityStores(t) {
+		t.Run(name, func(t *testing.T) {
+			meta := subgraph.Meta{ID: 1, Deployment: "test"}
+			if err := meta.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			if meta.CreatedAt.IsZero() || meta.UpdatedAt.IsZero() {
+				t.Fatalf("expected the times to be set, got %v and %v", meta.CreatedAt, meta.UpdatedAt)
+			}
+			created, updated := meta.CreatedAt, meta.UpdatedAt
+			if err := meta.Save(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+			if !meta.CreatedAt.Equal(created) || meta.UpdatedAt.Before(updated) {
+				t.Errorf("expected the creation time %v kept and the update time %v advanced, got %v and %v",
+					created, updated, meta.CreatedAt, meta.UpdatedAt)
+			}
+		})
+	}
+}
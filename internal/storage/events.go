@@ -8,11 +8,11 @@ import (
 )
 
 // SaveEvent inserts or updates an event record in the database.
-// If a conflict occurs on (transaction_signature, log_index), it updates all fields.
+// If a conflict occurs on (transaction_signature, log_index, slot), it updates all fields.
 func (g *Gorm) SaveEvent(ctx context.Context, ev core.Event) error {
 	tx := g.DB.WithContext(ctx).
 		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "transaction_signature"}, {Name: "log_index"}},
+			Columns:   []clause.Column{{Name: "transaction_signature"}, {Name: "log_index"}, {Name: "slot"}},
 			UpdateAll: true,
 		}).
 		Create(&ev)
@@ -24,6 +24,45 @@ func (g *Gorm) SaveEvent(ctx context.Context, ev core.Event) error {
 	return nil
 }
 
+// eventBatch is the number of events inserted per statement by SaveEvents.
+const eventBatch = 1000
+
+// SaveEvents inserts or updates events in as few statements as possible, e.g. all events of a
+// transaction at once. Like with SaveEvent, the last of several events with the same
+// (transaction_signature, log_index) wins.
+func (g *Gorm) SaveEvents(ctx context.Context, events []core.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// A statement can't update the same row twice
+	type key struct {
+		signature string
+		logIndex  int
+	}
+	positions := make(map[key]int, len(events))
+	unique := make([]core.Event, 0, len(events))
+	for _, ev := range events {
+		k := key{ev.TransactionSignature, ev.LogIndex}
+		if i, ok := positions[k]; ok {
+			unique[i] = ev
+			continue
+		}
+		positions[k] = len(unique)
+		unique = append(unique, ev)
+	}
+
+	if err := g.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "transaction_signature"}, {Name: "log_index"}, {Name: "slot"}},
+			UpdateAll: true,
+		}).
+		CreateInBatches(&unique, eventBatch).Error; err != nil {
+		return fmt.Errorf("failed to insert or update events: %w", err)
+	}
+	return nil
+}
+
 // MarkMapped marks a specific event as "mapped" by setting the mapped flag to true,
 // identified by its transaction signature and event name.
 func (g *Gorm) MarkMapped(ctx context.Context, signature, eventName string) error {
@@ -33,6 +72,17 @@ func (g *Gorm) MarkMapped(ctx context.Context, signature, eventName string) erro
 		Update("mapped", true).Error
 }
 
+// HasEvents reports whether any event was decoded from the transaction.
+func (g *Gorm) HasEvents(ctx context.Context, signature string) (bool, error) {
+	var found bool
+	if err := g.DB.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM core.events WHERE transaction_signature = ?)", signature).
+		Scan(&found).Error; err != nil {
+		return false, fmt.Errorf("failed to check events of %s: %w", signature, err)
+	}
+	return found, nil
+}
+
 // LoadOrderedEvents returns all events sorted in canonical order:
 // by block_time, transaction_signature, and log_index.
 func (g *Gorm) LoadOrderedEvents(ctx context.Context) ([]core.Event, error) {
@@ -48,7 +98,8 @@ func (g *Gorm) LoadOrderedEvents(ctx context.Context) ([]core.Event, error) {
 	return events, nil
 }
 
-// LoadEventsBySlotCursor loads events for the next N slots after a given starting slot.
+// LoadEventsBySlotCursor loads events for the next N slots after a given starting slot, ordered by
+// slot, block index of their transaction, transaction signature and log index.
 // It first retrieves a list of slot numbers, then fetches all events belonging to those slots.
 func (g *Gorm) LoadEventsBySlotCursor(ctx context.Context, fromSlot uint64, slotCount int) ([]core.Event, error) {
 	var slots []uint64
@@ -68,14 +119,66 @@ func (g *Gorm) LoadEventsBySlotCursor(ctx context.Context, fromSlot uint64, slot
 		return nil, nil
 	}
 
+	// Transactions of a slot are replayed in block order, as the real-time path parses them
 	var events []core.Event
 	if err := g.DB.WithContext(ctx).
-		Model(&core.Event{}).
-		Where("slot IN ?", slots).
-		Order("slot ASC, transaction_signature ASC, log_index ASC").
+		Table("core.events AS e").
+		Select("e.*").
+		Joins("LEFT JOIN core.transactions t ON t.signature = e.transaction_signature AND t.slot = e.slot").
+		Where("e.slot IN ?", slots).
+		Order("e.slot ASC, t.block_index ASC NULLS LAST, e.transaction_signature ASC, e.log_index ASC").
 		Find(&events).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch events for slots: %w", err)
 	}
 
 	return events, nil
 }
+
+// EventFilter narrows ListEvents; zero values disable a condition.
+type EventFilter struct {
+	Signature string
+	Name      string
+	FromSlot  *uint64
+	ToSlot    *uint64
+	After     *EventCursor
+	Limit     int
+}
+
+// EventCursor is the canonical position of the last returned event.
+type EventCursor struct {
+	Slot      uint64
+	Signature string
+	LogIndex  int
+}
+
+// ListEvents returns decoded events in canonical order (slot, transaction_signature, log_index),
+// starting after the cursor.
+func (g *Gorm) ListEvents(ctx context.Context, filter EventFilter) ([]core.Event, error) {
+	q := g.DB.WithContext(ctx).Model(&core.Event{})
+
+	if filter.Signature != "" {
+		q = q.Where("transaction_signature = ?", filter.Signature)
+	}
+	if filter.Name != "" {
+		q = q.Where("name = ?", filter.Name)
+	}
+	if filter.FromSlot != nil {
+		q = q.Where("slot >= ?", *filter.FromSlot)
+	}
+	if filter.ToSlot != nil {
+		q = q.Where("slot <= ?", *filter.ToSlot)
+	}
+	if filter.After != nil {
+		q = q.Where("(slot, transaction_signature, log_index) > (?, ?, ?)",
+			filter.After.Slot, filter.After.Signature, filter.After.LogIndex)
+	}
+
+	var events []core.Event
+	if err := q.
+		Order("slot ASC, transaction_signature ASC, log_index ASC").
+		Limit(filter.Limit).
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	return events, nil
+}
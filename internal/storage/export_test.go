@@ -0,0 +1,9 @@
+package storage
+
+import "gorm.io/gorm"
+
+// NewBufferedGorm returns a Gorm that buffers entity saves as within Atomically. Loads of
+// entities that are not buffered and flushes go to db.
+func NewBufferedGorm(db *gorm.DB) *Gorm {
+	return &Gorm{DB: db, entities: &entityBatch{}}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/storage/migrations"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"gorm.io/gorm/clause"
 
@@ -15,6 +17,8 @@ import (
 // Gorm wraps a GORM database instance.
 type Gorm struct {
 	DB *gorm.DB
+
+	entities *entityBatch // buffered entity saves, within Atomically only
 }
 
 // InitGorm establishes a connection to the PostgreSQL database using configuration values.
@@ -38,6 +42,9 @@ func InitGorm() (*Gorm, error) {
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("db ping failed: %w", err)
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	// Create the core schema if it doesn't exist
 	for _, schema := range []string{"core"} {
@@ -49,18 +56,24 @@ func InitGorm() (*Gorm, error) {
 	return &Gorm{DB: db}, nil
 }
 
-// InitCoreModels runs migrations for the core models used in the indexer,
-// sets initial health status, and stores configured program addresses in the database.
-func InitCoreModels(ctx context.Context, db *Gorm, resume bool) error {
-	if err := db.DB.AutoMigrate(
-		&core.Transaction{},
-		&core.Program{},
-		&core.Event{},
-		&core.IndexerHealth{},
-	); err != nil {
-		return fmt.Errorf("migration failed: %w", err)
+// MigrateSchema brings the database schema up to date with the embedded SQL migrations and returns
+// those it applied. With postgres.auto_migrate the subgraph tables are then updated from the models,
+// which is meant for development only. The core tables always come from the migrations: they are
+// partitioned by slot, which the partition maintenance and the retention depend on.
+func MigrateSchema(ctx context.Context, db *Gorm) ([]migrations.Migration, error) {
+	applied, err := migrations.Up(ctx, db.DB, 0)
+	if err != nil {
+		return applied, err
 	}
+	if config.App.Postgres.AutoMigrate {
+		return applied, autoMigrate(db.DB.WithContext(ctx))
+	}
+	return applied, nil
+}
 
+// InitCoreModels sets initial health status and stores configured program addresses in the database.
+// The schema must have been migrated.
+func InitCoreModels(ctx context.Context, db *Gorm, resume bool) error {
 	if !resume {
 		if err := truncateEvents(db.DB); err != nil {
 			return fmt.Errorf("failed to truncate events: %w", err)
@@ -71,9 +84,19 @@ func InitCoreModels(ctx context.Context, db *Gorm, resume bool) error {
 		return fmt.Errorf("failed to set initial health status: %v", err)
 	}
 
+	// Programs from the config are (re)activated with their configured settings;
+	// programs added at runtime stay as they are.
 	for _, program := range config.App.Programs {
-		if err := db.SaveProgram(ctx, program); err != nil {
-			return fmt.Errorf("failed to save program address %s: %v", program, err)
+		if err := db.UpsertProgram(ctx, core.Program{
+			ID:                 program.Address,
+			IDL:                program.IDL,
+			StartSlot:          program.StartSlot,
+			Commitment:         program.Commitment,
+			DecodeInstructions: program.DecodeInstructions,
+			Mapper:             program.Mapper,
+			FetchMode:          program.FetchMode,
+		}); err != nil {
+			return fmt.Errorf("failed to save program address %s: %v", program.Address, err)
 		}
 	}
 	return nil
@@ -93,54 +116,21 @@ func truncateEvents(db *gorm.DB) error {
 	return nil
 }
 
-// InitSubgraphModels runs migrations for subgraph-specific models.
-// It also creates and validates the `latest_report_id` foreign key.
+// InitSubgraphModels enables entity versioning and, unless resuming, removes the subgraph entities.
+// The schema must have been migrated.
 func InitSubgraphModels(ctx context.Context, db *Gorm, resume bool) error {
-	if err := db.DB.AutoMigrate(
-		&subgraph.Meta{},
-		&subgraph.BlockInfo{},
-		&subgraph.Account{},
-		&subgraph.AccountVaultPosition{},
-		&subgraph.Accountant{},
-		&subgraph.Deposit{},
-		&subgraph.DeployFunds{},
-		&subgraph.DTFReport{},
-		&subgraph.FreeFunds{},
-		&subgraph.ShareToken{},
-		&subgraph.ShareTokenData{},
-		&subgraph.ShareTokenTransfer{},
-		&subgraph.Strategy{},
-		&subgraph.StrategyHistoricalApr{},
-		&subgraph.StrategyReport{},
-		&subgraph.StrategyReportEvent{},
-		&subgraph.StrategyReportResult{},
-		&subgraph.Token{},
-		&subgraph.TokenAccount{},
-		&subgraph.TokenBurn{},
-		&subgraph.TokenMint{},
-		&subgraph.TokenStats{},
-		&subgraph.TokenWallet{},
-		&subgraph.Vault{},
-		&subgraph.VaultHistoricalApr{},
-		&subgraph.Withdrawal{},
-		&subgraph.WithdrawalRequest{},
-	); err != nil {
-		return fmt.Errorf("migration failed: %w", err)
-	}
+	generic.EnableVersioning(config.App.VersionedEntities)
 
 	if !resume {
-		if err := truncateSubgraphTables(db.DB); err != nil {
+		if err := TruncateSubgraphTables(ctx, db); err != nil {
 			return fmt.Errorf("failed to truncate subgraph tables: %w", err)
 		}
 	}
-
-	if err := migrateWithLatestReport(db.DB); err != nil {
-		return fmt.Errorf("migration latest_report_id failed: %w", err)
-	}
 	return nil
 }
 
-func truncateSubgraphTables(db *gorm.DB) error {
+// TruncateSubgraphTables removes all subgraph entities, e.g. before replaying events.
+func TruncateSubgraphTables(ctx context.Context, db *Gorm) error {
 	tables := []string{
 		"_meta",
 		"_block_info",
@@ -155,6 +145,7 @@ func truncateSubgraphTables(db *gorm.DB) error {
 		"share_token_data",
 		"share_token_transfers",
 		"strategies",
+		"strategy_day_data",
 		"strategy_historical_aprs",
 		"strategy_reports",
 		"strategy_report_events",
@@ -166,13 +157,16 @@ func truncateSubgraphTables(db *gorm.DB) error {
 		"token_stats",
 		"token_wallets",
 		"vaults",
+		"vault_day_data",
 		"vault_historical_aprs",
+		"vault_hour_data",
 		"withdrawals",
 		"withdrawal_requests",
+		"entity_versions",
 	}
 
 	for _, table := range tables {
-		if err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE;", table)).Error; err != nil {
+		if err := db.DB.WithContext(ctx).Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE;", table)).Error; err != nil {
 			return fmt.Errorf("failed to truncate %s: %w", table, err)
 		}
 	}
@@ -180,27 +174,42 @@ func truncateSubgraphTables(db *gorm.DB) error {
 	return nil
 }
 
-// migrateWithLatestReport ensures that the `latest_report_id` column and foreign key
-// are added to the `strategies` table.
-func migrateWithLatestReport(db *gorm.DB) error {
-	if !db.Migrator().HasColumn(&subgraph.Strategy{}, "latest_report_id") {
-		if err := db.Migrator().AddColumn(&subgraph.Strategy{}, "LatestReportID"); err != nil {
-			return fmt.Errorf("add column latest_report_id: %w", err)
-		}
-	}
-
-	if !db.Migrator().HasConstraint(&subgraph.Strategy{}, "fk_strategies_latest_report") {
-		const fk = `
-			ALTER TABLE strategies
-			  ADD CONSTRAINT fk_strategies_latest_report
-			  FOREIGN KEY (latest_report_id)
-				REFERENCES strategy_reports(id)
-				ON UPDATE CASCADE
-				ON DELETE SET NULL;
-`
-		if err := db.Exec(fk).Error; err != nil {
-			return fmt.Errorf("create fk strategies.latest_report_id: %w", err)
-		}
+// autoMigrate updates the subgraph tables from the models, e.g. to try a new entity field before
+// writing its migration.
+func autoMigrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(
+		&subgraph.Meta{},
+		&subgraph.BlockInfo{},
+		&subgraph.Account{},
+		&subgraph.AccountVaultPosition{},
+		&subgraph.Accountant{},
+		&subgraph.Deposit{},
+		&subgraph.DeployFunds{},
+		&subgraph.DTFReport{},
+		&subgraph.FreeFunds{},
+		&subgraph.ShareToken{},
+		&subgraph.ShareTokenData{},
+		&subgraph.ShareTokenTransfer{},
+		&subgraph.Strategy{},
+		&subgraph.StrategyDayData{},
+		&subgraph.StrategyHistoricalApr{},
+		&subgraph.StrategyReport{},
+		&subgraph.StrategyReportEvent{},
+		&subgraph.StrategyReportResult{},
+		&subgraph.Token{},
+		&subgraph.TokenAccount{},
+		&subgraph.TokenBurn{},
+		&subgraph.TokenMint{},
+		&subgraph.TokenStats{},
+		&subgraph.TokenWallet{},
+		&subgraph.Vault{},
+		&subgraph.VaultDayData{},
+		&subgraph.VaultHistoricalApr{},
+		&subgraph.VaultHourData{},
+		&subgraph.Withdrawal{},
+		&subgraph.WithdrawalRequest{},
+	); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
 	}
 	return nil
 }
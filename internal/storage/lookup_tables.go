@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveLookupTable inserts or replaces the stored snapshot of an Address Lookup Table.
+// A snapshot is only replaced by one fetched at the same or a later slot.
+func (g *Gorm) SaveLookupTable(ctx context.Context, lut *core.LookupTable) error {
+	err := g.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "address"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"addresses",
+				"last_extended_slot",
+				"last_extended_slot_start_index",
+				"deactivation_slot",
+				"fetched_slot",
+				"updated_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "core.lookup_tables.fetched_slot <= EXCLUDED.fetched_slot"},
+			}},
+		}).
+		Create(lut).Error
+	if err != nil {
+		return fmt.Errorf("failed to save lookup table %s: %w", lut.Address, err)
+	}
+	return nil
+}
+
+// GetLookupTable returns the stored snapshot of an Address Lookup Table.
+// Returns nil if the table has never been fetched.
+func (g *Gorm) GetLookupTable(ctx context.Context, address string) (*core.LookupTable, error) {
+	var lut core.LookupTable
+	err := g.DB.WithContext(ctx).
+		First(&lut, "address = ?", address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch lookup table %s: %w", address, err)
+	}
+	return &lut, nil
+}
@@ -0,0 +1,396 @@
+package memory
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"gorm.io/gorm/schema"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// entityKey identifies an entity across tables.
+type entityKey struct {
+	table string
+	id    string
+}
+
+// version is the state of an entity from a slot on, until the slot of the next version.
+type version struct {
+	slot uint64
+	row  map[string]any
+}
+
+// table holds the rows of one entity table as their column values, which is what Postgres would
+// store, so that a loaded entity never shares memory with a saved one.
+type table struct {
+	rows  map[string]map[string]any // columns by id
+	order []string                  // ids in insertion order
+}
+
+// LoadEntity loads the entity with the model's id and the given relations,
+// or initializes the model and returns false if there is none.
+func (s *Store) LoadEntity(ctx context.Context, model generic.Identifiable, preloads ...string) (bool, error) {
+	sch, err := s.schema(model)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.table(sch.Table).rows[model.GetID()]
+	if !ok {
+		model.Init()
+		return false, nil
+	}
+	value := reflect.ValueOf(model).Elem()
+	if err := setRow(ctx, sch, value, row); err != nil {
+		return false, err
+	}
+	for _, name := range preloads {
+		rel, ok := sch.Relationships.Relations[name]
+		if !ok {
+			return false, fmt.Errorf("[memory] %s has no relation %s", sch.Table, name)
+		}
+		if err := s.preload(ctx, rel, value); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// SaveEntity inserts or replaces the entity by id. Creation times are kept, update times set,
+// and zero values of columns with a default stored as the default. With versioning enabled and a
+// slot in the context, it records the entity as its version at the slot, like storage.Gorm does.
+func (s *Store) SaveEntity(ctx context.Context, model any) error {
+	sch, err := s.schema(model)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if err := generic.SetForeignKeys(ctx, sch, value); err != nil {
+		return fmt.Errorf("[memory] %w", err)
+	}
+	row := make(map[string]any, len(sch.DBNames))
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		fieldValue := field.ReflectValueOf(ctx, value)
+		// A zero value with a default in the model is stored as the default, as a Create would
+		if field.DefaultValueInterface != nil && fieldValue.IsZero() {
+			row[field.DBName] = field.DefaultValueInterface
+			continue
+		}
+		v, err := columnValue(fieldValue)
+		if err != nil {
+			return fmt.Errorf("[memory] failed to encode %s.%s: %w", sch.Table, field.DBName, err)
+		}
+		row[field.DBName] = v
+	}
+	id, ok := row["id"]
+	if !ok || id == nil {
+		return fmt.Errorf("[memory] entity %s has no id", sch.Table)
+	}
+	key := fmt.Sprint(id)
+
+	t := s.table(sch.Table)
+	stored, exists := t.rows[key]
+	now := time.Now()
+	for _, field := range sch.Fields {
+		switch {
+		case field.AutoCreateTime > 0 && exists:
+			row[field.DBName] = stored[field.DBName]
+		case field.AutoCreateTime > 0 && isZero(row[field.DBName]), field.AutoUpdateTime > 0:
+			row[field.DBName] = now
+		default:
+			continue
+		}
+		if err := field.Set(ctx, value, row[field.DBName]); err != nil {
+			return fmt.Errorf("[memory] failed to set %s.%s: %w", sch.Table, field.DBName, err)
+		}
+	}
+
+	if !exists {
+		t.order = append(t.order, key)
+	}
+	t.rows[key] = row
+
+	if slot, ok := generic.SlotFromContext(ctx); ok && generic.VersioningEnabled() {
+		k := entityKey{table: sch.Table, id: key}
+		versions := s.versions[k]
+		// A second change within the same slot overwrites that slot's version
+		if n := len(versions); n > 0 && versions[n-1].slot == slot {
+			versions = versions[:n-1]
+		}
+		s.versions[k] = append(slices.Clip(versions), version{slot: slot, row: row})
+	}
+	return nil
+}
+
+// LoadEntityAt loads the state the entity with the model's id had at the slot, or initializes the
+// model and returns false if it didn't exist then.
+func (s *Store) LoadEntityAt(ctx context.Context, model generic.Identifiable, slot uint64) (bool, error) {
+	sch, err := s.schema(model)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions := s.versions[entityKey{table: sch.Table, id: model.GetID()}]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].slot <= slot {
+			return true, setRow(ctx, sch, reflect.ValueOf(model).Elem(), versions[i].row)
+		}
+	}
+	model.Init()
+	return false, nil
+}
+
+// FindEntities loads the entities of dest's element type matching all conditions into dest,
+// a pointer to a slice, in insertion order.
+func (s *Store) FindEntities(ctx context.Context, dest any, conds ...generic.Cond) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("[memory] dest must be a pointer to a slice, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	sch, err := s.schema(reflect.New(structType).Interface())
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.match(sch.Table, conds)
+	if err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(slice.Type(), 0, len(rows))
+	for _, row := range rows {
+		elem := reflect.New(structType)
+		if err := setRow(ctx, sch, elem.Elem(), row); err != nil {
+			return err
+		}
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+		result = reflect.Append(result, elem)
+	}
+	slice.Set(result)
+	return nil
+}
+
+// CountEntities counts the distinct non-null values of a column among the entities of the model's
+// type matching all conditions.
+func (s *Store) CountEntities(ctx context.Context, model any, distinct string, conds ...generic.Cond) (int64, error) {
+	sch, err := s.schema(model)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.match(sch.Table, conds)
+	if err != nil {
+		return 0, err
+	}
+	values := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if v := row[distinct]; v != nil {
+			values[fmt.Sprint(v)] = struct{}{}
+		}
+	}
+	return int64(len(values)), nil
+}
+
+func (s *Store) schema(model any) (*schema.Schema, error) {
+	sch, err := schema.Parse(model, &s.schemas, schema.NamingStrategy{})
+	if err != nil {
+		return nil, fmt.Errorf("[memory] failed to parse model %T: %w", model, err)
+	}
+	return sch, nil
+}
+
+func (s *Store) table(name string) *table {
+	t, ok := s.tables[name]
+	if !ok {
+		t = &table{rows: make(map[string]map[string]any)}
+		s.tables[name] = t
+	}
+	return t
+}
+
+// match returns the rows of a table matching all conditions, in insertion order.
+func (s *Store) match(name string, conds []generic.Cond) ([]map[string]any, error) {
+	t := s.table(name)
+	var rows []map[string]any
+	for _, id := range t.order {
+		row := t.rows[id]
+		ok, err := matches(row, conds)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// preload sets a has-one, has-many or belongs-to relation of the owner from the stored rows.
+func (s *Store) preload(ctx context.Context, rel *schema.Relationship, owner reflect.Value) error {
+	var conds []generic.Cond
+	for _, ref := range rel.References {
+		switch {
+		case ref.OwnPrimaryKey:
+			v, err := columnValue(ref.PrimaryKey.ReflectValueOf(ctx, owner))
+			if err != nil {
+				return err
+			}
+			conds = append(conds, generic.Eq(ref.ForeignKey.DBName, v))
+		case ref.PrimaryValue != "":
+			conds = append(conds, generic.Eq(ref.ForeignKey.DBName, ref.PrimaryValue))
+		default:
+			v, err := columnValue(ref.ForeignKey.ReflectValueOf(ctx, owner))
+			if err != nil {
+				return err
+			}
+			conds = append(conds, generic.Eq(ref.PrimaryKey.DBName, v))
+		}
+	}
+	rows, err := s.match(rel.FieldSchema.Table, conds)
+	if err != nil {
+		return err
+	}
+
+	field := rel.Field.ReflectValueOf(ctx, owner)
+	field.Set(reflect.Zero(field.Type()))
+	for _, row := range rows {
+		elem := reflect.New(rel.FieldSchema.ModelType)
+		if err := setRow(ctx, rel.FieldSchema, elem.Elem(), row); err != nil {
+			return err
+		}
+		switch field.Kind() {
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.Ptr {
+				elem = elem.Elem()
+			}
+			field.Set(reflect.Append(field, elem))
+		case reflect.Ptr:
+			field.Set(elem)
+			return nil
+		default:
+			field.Set(elem.Elem())
+			return nil
+		}
+	}
+	return nil
+}
+
+// setRow sets the columns of a stored row on a model value.
+func setRow(ctx context.Context, sch *schema.Schema, value reflect.Value, row map[string]any) error {
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if err := field.Set(ctx, value, row[field.DBName]); err != nil {
+			return fmt.Errorf("[memory] failed to set %s.%s: %w", sch.Table, field.DBName, err)
+		}
+	}
+	return nil
+}
+
+// columnValue returns the value Postgres would store for a field: that of its driver.Valuer,
+// or a copy of the plain value.
+func columnValue(v reflect.Value) (any, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if v.CanAddr() {
+		if valuer, ok := v.Addr().Interface().(driver.Valuer); ok {
+			return valuer.Value()
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		return columnValue(v.Elem())
+	}
+	return v.Interface(), nil
+}
+
+func isZero(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// matches reports whether a row satisfies all conditions. Like in SQL, comparisons with NULL fail.
+func matches(row map[string]any, conds []generic.Cond) (bool, error) {
+	for _, c := range conds {
+		value, err := columnValue(reflect.ValueOf(c.Value))
+		if err != nil {
+			return false, err
+		}
+		if row[c.Column] == nil || value == nil {
+			return false, nil
+		}
+		n := compare(row[c.Column], value)
+		var ok bool
+		switch c.Op {
+		case "=":
+			ok = n == 0
+		case "<>":
+			ok = n != 0
+		case "<":
+			ok = n < 0
+		case "<=":
+			ok = n <= 0
+		case ">":
+			ok = n > 0
+		case ">=":
+			ok = n >= 0
+		default:
+			return false, fmt.Errorf("[memory] unsupported operator %q", c.Op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// compare orders two column values: numerically if both are numbers, e.g. a numeric column
+// stored as its text, chronologically if both are times, and by their text otherwise.
+func compare(a, b any) int {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+	sa, sb := fmt.Sprint(a), fmt.Sprint(b)
+	if fa, ok := new(big.Float).SetString(sa); ok {
+		if fb, ok := new(big.Float).SetString(sb); ok {
+			return fa.Cmp(fb)
+		}
+	}
+	return strings.Compare(sa, sb)
+}
@@ -0,0 +1,249 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"testing"
+)
+
+func saveDeposits(t *testing.T, s *Store, amounts map[string]int64, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		deposit := subgraph.Deposit{ID: id, VaultID: "vault", AccountID: "account-" + id, TokenAmount: *types.NewBigIntFromInt64(amounts[id])}
+		if err := deposit.Save(context.Background(), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func depositIDs(deposits []subgraph.Deposit) []string {
+	var ids []string
+	for _, d := range deposits {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+func TestLoadEntityInitializesMissing(t *testing.T) {
+	s := New()
+	vault := subgraph.Vault{ID: "vault", TokenID: "mint", TotalIdle: *types.NewBigIntFromInt64(5)}
+	ok, err := s.LoadEntity(context.Background(), &vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// As LoadFromDB: a missing entity is initialized and keeps its id
+	if ok || vault.ID != "vault" || vault.TokenID != "" || vault.TotalIdle.String() != "0" {
+		t.Errorf("expected an initialized missing vault, got %v, %+v", ok, vault)
+	}
+}
+
+func TestLoadEntityPreloads(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	for _, entity := range []generic.Identifiable{
+		&subgraph.Vault{ID: "vault"},
+		&subgraph.Strategy{ID: "first", VaultID: "vault"},
+		&subgraph.Strategy{ID: "other", VaultID: "other"},
+		&subgraph.Strategy{ID: "second", VaultID: "vault"},
+	} {
+		if err := s.SaveEntity(ctx, entity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vault := subgraph.Vault{ID: "vault"}
+	if ok, err := vault.Load(ctx, s); err != nil || !ok {
+		t.Fatalf("failed to load the vault: %v", err)
+	}
+	if len(vault.Strategies) != 2 || vault.Strategies[0].ID != "first" || vault.Strategies[1].ID != "second" {
+		t.Errorf("expected strategies first and second, got %v", vault.Strategies)
+	}
+	if vault.Deposits != nil {
+		t.Errorf("expected no deposits, got %v", vault.Deposits)
+	}
+	if _, err := s.LoadEntity(ctx, &vault, "Missing"); err == nil {
+		t.Error("expected an error for an unknown relation")
+	}
+}
+
+func TestFindEntitiesConditions(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	saveDeposits(t, s, map[string]int64{"a": 100, "b": 9, "c": 10, "d": 10}, "a", "b", "c", "d")
+
+	tests := []struct {
+		name  string
+		conds []generic.Cond
+		want  []string
+	}{
+		{"all in insertion order", nil, []string{"a", "b", "c", "d"}},
+		// Numeric columns compare as numbers, not as their text
+		{"greater or equal", []generic.Cond{generic.Gte("token_amount", types.NewBigIntFromInt64(10))}, []string{"a", "c", "d"}},
+		{"less", []generic.Cond{generic.Lt("token_amount", types.NewBigIntFromInt64(10))}, []string{"b"}},
+		{"all conditions", []generic.Cond{generic.Eq("token_amount", "10"), {Column: "id", Op: "<>", Value: "c"}}, []string{"d"}},
+		{"text", []generic.Cond{generic.Eq("vault_id", "vault")}, []string{"a", "b", "c", "d"}},
+		{"unknown column is NULL", []generic.Cond{{Column: "missing", Op: "<>", Value: "x"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deposits []subgraph.Deposit
+			if err := s.FindEntities(ctx, &deposits, tt.conds...); err != nil {
+				t.Fatal(err)
+			}
+			got := depositIDs(deposits)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	var deposits []*subgraph.Deposit
+	if err := s.FindEntities(ctx, &deposits, generic.Cond{Column: "id", Op: "like", Value: "a"}); err == nil {
+		t.Error("expected an error for an unsupported operator")
+	}
+	if err := s.FindEntities(ctx, deposits); err == nil {
+		t.Error("expected an error for a dest that is not a pointer to a slice")
+	}
+}
+
+func TestFindEntitiesNull(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	report := "report"
+	for _, strategy := range []*subgraph.Strategy{{ID: "reported", LatestReportID: &report}, {ID: "new"}} {
+		if err := strategy.Save(ctx, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// As in SQL, a comparison with NULL is never true
+	var strategies []subgraph.Strategy
+	if err := s.FindEntities(ctx, &strategies, generic.Cond{Column: "latest_report_id", Op: "<>", Value: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(strategies) != 1 || strategies[0].ID != "reported" {
+		t.Errorf("expected the reported strategy only, got %v", strategies)
+	}
+	count, err := s.CountEntities(ctx, &subgraph.Strategy{}, "latest_report_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 non-null report id, got %d", count)
+	}
+}
+
+func TestCountEntitiesDistinct(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	saveDeposits(t, s, map[string]int64{"a": 1, "b": 2, "c": 2}, "a", "b", "c")
+
+	for _, tt := range []struct {
+		distinct string
+		conds    []generic.Cond
+		want     int64
+	}{
+		{"id", nil, 3},
+		{"vault_id", nil, 1},
+		{"token_amount", nil, 2},
+		{"account_id", []generic.Cond{generic.Gte("token_amount", 2)}, 2},
+	} {
+		count, err := s.CountEntities(ctx, &subgraph.Deposit{}, tt.distinct, tt.conds...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != tt.want {
+			t.Errorf("expected %d distinct %s, got %d", tt.want, tt.distinct, count)
+		}
+	}
+}
+
+func TestAtomicallyRestoresOnError(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	saveDeposits(t, s, map[string]int64{"a": 1}, "a")
+
+	failed := errors.New("failed")
+	err := s.Atomically(ctx, func(tx storage.ParseStore) error {
+		saveDeposits(t, s, map[string]int64{"a": 2, "b": 3}, "a", "b")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	var deposits []subgraph.Deposit
+	if err := s.FindEntities(ctx, &deposits); err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 1 || deposits[0].TokenAmount.String() != "1" {
+		t.Errorf("expected deposit a with amount 1 only, got %v", deposits)
+	}
+
+	if err := s.Atomically(ctx, func(tx storage.ParseStore) error {
+		saveDeposits(t, s, map[string]int64{"b": 3}, "b")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := s.CountEntities(ctx, &subgraph.Deposit{}, "id"); count != 2 {
+		t.Errorf("expected 2 deposits, got %d", count)
+	}
+}
+
+func TestLoadEntityAt(t *testing.T) {
+	generic.EnableVersioning(true)
+	defer generic.EnableVersioning(false)
+
+	ctx := context.Background()
+	s := New()
+	save := func(slot uint64, idle int64) {
+		t.Helper()
+		vault := subgraph.Vault{ID: "vault", TotalIdle: *types.NewBigIntFromInt64(idle)}
+		if err := vault.Save(generic.WithSlot(ctx, slot), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save(10, 100)
+	save(20, 200)
+	save(20, 250) // overwrites the version of slot 20
+	save(30, 300)
+	// Without a slot no version is recorded
+	unversioned := subgraph.Vault{ID: "vault", TotalIdle: *types.NewBigIntFromInt64(400)}
+	if err := unversioned.Save(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		slot uint64
+		ok   bool
+		want string
+	}{
+		{5, false, "0"},
+		{10, true, "100"},
+		{19, true, "100"},
+		{20, true, "250"},
+		{1000, true, "300"},
+	} {
+		vault := subgraph.Vault{ID: "vault"}
+		ok, err := vault.LoadAt(ctx, s, tt.slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tt.ok || vault.TotalIdle.String() != tt.want {
+			t.Errorf("expected total idle %s at slot %d, got %v, %s", tt.want, tt.slot, ok, vault.TotalIdle)
+		}
+	}
+
+	current := subgraph.Vault{ID: "vault"}
+	if ok, err := current.Load(ctx, s); err != nil || !ok || current.TotalIdle.String() != "400" {
+		t.Errorf("expected the current total idle 400, got %v, %s: %v", ok, current.TotalIdle, err)
+	}
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"slices"
+	"time"
+)
+
+type eventKey struct {
+	signature string
+	logIndex  int
+	slot      uint64
+}
+
+// SaveEvents inserts or updates events by (transaction_signature, log_index, slot).
+func (s *Store) SaveEvents(ctx context.Context, events []core.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, ev := range events {
+		k := eventKey{ev.TransactionSignature, ev.LogIndex, ev.Slot}
+		if stored, ok := s.events[k]; ok {
+			ev.CreatedAt = stored.CreatedAt
+		} else {
+			ev.CreatedAt = now
+		}
+		ev.UpdatedAt = now
+		ev.JsonEv = slices.Clone(ev.JsonEv)
+		s.events[k] = ev
+	}
+	return nil
+}
+
+// HasEvents reports whether any event was decoded from the transaction.
+func (s *Store) HasEvents(ctx context.Context, signature string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.events {
+		if k.signature == signature {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LoadEventsBySlotCursor loads events for the next N slots after a given starting slot,
+// in canonical order.
+func (s *Store) LoadEventsBySlotCursor(ctx context.Context, fromSlot uint64, slotCount int) ([]core.Event, error) {
+	var (
+		events []core.Event
+		slots  int
+	)
+	// Events() is sorted by slot, so the slots are consecutive runs
+	for _, ev := range s.Events() {
+		if ev.Slot <= fromSlot {
+			continue
+		}
+		if len(events) == 0 || events[len(events)-1].Slot != ev.Slot {
+			if slots == slotCount {
+				break
+			}
+			slots++
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// Events returns all stored events in canonical order: by slot, block index of their transaction,
+// transaction_signature and log_index.
+func (s *Store) Events() []core.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]core.Event, 0, len(s.events))
+	for _, ev := range s.events {
+		events = append(events, ev)
+	}
+	blockIndex := func(ev core.Event) *uint32 {
+		if tx, ok := s.transactions[ev.TransactionSignature]; ok && tx.Slot == ev.Slot {
+			return tx.BlockIndex
+		}
+		return nil
+	}
+	slices.SortFunc(events, func(a, b core.Event) int {
+		return cmp.Or(
+			cmp.Compare(a.Slot, b.Slot),
+			compareBlockIndex(blockIndex(a), blockIndex(b)),
+			cmp.Compare(a.TransactionSignature, b.TransactionSignature),
+			cmp.Compare(a.LogIndex, b.LogIndex),
+		)
+	})
+	return events
+}
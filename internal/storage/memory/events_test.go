@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"testing"
+)
+
+func TestLoadEventsBySlotCursorInBlockOrder(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	for _, tx := range []*core.Transaction{
+		{Signature: "a", Slot: 5, BlockIndex: utils.Ptr(uint32(2))},
+		{Signature: "b", Slot: 5, BlockIndex: utils.Ptr(uint32(1))},
+		{Signature: "c", Slot: 5},
+		{Signature: "d", Slot: 4},
+	} {
+		if err := s.SaveTransaction(ctx, tx, "program"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SaveEvents(ctx, []core.Event{
+		{TransactionSignature: "c", Slot: 5, LogIndex: 2000},
+		{TransactionSignature: "a", Slot: 5, LogIndex: 2001},
+		{TransactionSignature: "a", Slot: 5, LogIndex: 2000},
+		{TransactionSignature: "b", Slot: 5, LogIndex: 2000},
+		{TransactionSignature: "d", Slot: 4, LogIndex: 2000},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := s.LoadEventsBySlotCursor(ctx, 4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unknown block positions come last, as in Postgres
+	want := []struct {
+		signature string
+		logIndex  int
+	}{{"b", 2000}, {"a", 2000}, {"a", 2001}, {"c", 2000}}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %v", len(want), events)
+	}
+	for i, ev := range events {
+		if ev.TransactionSignature != want[i].signature || ev.LogIndex != want[i].logIndex {
+			t.Fatalf("expected %v, got %v", want, events)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"gorm.io/gorm"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Store keeps transactions, events, subgraph entities and the health status in memory, so that
+// parsing and mapping can be tested without a database. It follows the semantics of storage.Gorm
+// with these differences:
+//   - raw payloads are kept as given in JsonTx, without the configured encoding;
+//   - every program a transaction is linked to counts as active;
+//   - entity versions are recorded, but can't be rolled back.
+type Store struct {
+	mu sync.Mutex
+
+	transactions map[string]*core.Transaction
+	links        map[string][]string // program ids by transaction signature
+	lookupTables map[string]core.LookupTable
+	events       map[eventKey]core.Event
+	tables       map[string]*table // subgraph entities by table name
+	versions     map[entityKey][]version
+	schemas      sync.Map
+
+	health *core.IndexerHealth
+}
+
+var (
+	_ storage.TransactionStore = (*Store)(nil)
+	_ storage.EventStore       = (*Store)(nil)
+	_ storage.EntityStore      = (*Store)(nil)
+	_ storage.VersionStore     = (*Store)(nil)
+	_ storage.HealthStore      = (*Store)(nil)
+	_ storage.ParseStore       = (*Store)(nil)
+)
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{
+		transactions: make(map[string]*core.Transaction),
+		links:        make(map[string][]string),
+		lookupTables: make(map[string]core.LookupTable),
+		events:       make(map[eventKey]core.Event),
+		tables:       make(map[string]*table),
+		versions:     make(map[entityKey][]version),
+	}
+}
+
+// Atomically runs fn with the store and restores its previous state if fn returns an error.
+// Writes of other goroutines meanwhile are restored too, so tests run one writer at a time.
+func (s *Store) Atomically(ctx context.Context, fn func(tx storage.ParseStore) error) error {
+	s.mu.Lock()
+	saved := s.clone()
+	s.mu.Unlock()
+
+	if err := fn(s); err != nil {
+		s.mu.Lock()
+		s.transactions, s.links, s.lookupTables, s.events, s.tables, s.versions =
+			saved.transactions, saved.links, saved.lookupTables, saved.events, saved.tables, saved.versions
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// clone copies the stored state, which the methods only replace and never change in place,
+// except for the transactions.
+func (s *Store) clone() *Store {
+	c := New()
+	for signature, tx := range s.transactions {
+		stored := *tx
+		c.transactions[signature] = &stored
+	}
+	for signature, programs := range s.links {
+		c.links[signature] = slices.Clone(programs)
+	}
+	maps.Copy(c.lookupTables, s.lookupTables)
+	maps.Copy(c.events, s.events)
+	for name, t := range s.tables {
+		c.tables[name] = &table{rows: maps.Clone(t.rows), order: slices.Clone(t.order)}
+	}
+	for key, versions := range s.versions {
+		c.versions[key] = slices.Clone(versions)
+	}
+	return c
+}
+
+// SetHealth updates the indexer health status.
+func (s *Store) SetHealth(ctx context.Context, status, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.health = &core.IndexerHealth{ID: 1, Status: status, Reason: reason}
+	return nil
+}
+
+// GetHealth returns the indexer health status and reason, gorm.ErrRecordNotFound if none was set.
+func (s *Store) GetHealth(ctx context.Context) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.health == nil {
+		return "", "", gorm.ErrRecordNotFound
+	}
+	return s.health.Status, s.health.Reason, nil
+}
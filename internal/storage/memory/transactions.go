@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"slices"
+	"time"
+)
+
+// SaveTransaction inserts a transaction if it doesn't exist, and associates it with a program.
+func (s *Store) SaveTransaction(ctx context.Context, tx *core.Transaction, programID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transactions[tx.Signature]; !ok {
+		stored := *tx
+		stored.JsonTx = bytes.Clone(tx.JsonTx)
+		stored.Programs = nil
+		stored.Events = nil
+		if stored.CreatedAt.IsZero() {
+			stored.CreatedAt = time.Now()
+		}
+		s.transactions[tx.Signature] = &stored
+	}
+	if !slices.Contains(s.links[tx.Signature], programID) {
+		s.links[tx.Signature] = append(s.links[tx.Signature], programID)
+	}
+	return nil
+}
+
+// GetTransaction returns a transaction with its programs but without its raw JSON, or nil if unknown.
+func (s *Store) GetTransaction(ctx context.Context, signature string) (*core.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.transactions[signature]
+	if !ok {
+		return nil, nil
+	}
+	tx := *stored
+	tx.JsonTx = nil
+	tx.RawZstd = nil
+	for _, id := range s.links[signature] {
+		tx.Programs = append(tx.Programs, core.Program{ID: id, Active: true})
+	}
+	return &tx, nil
+}
+
+// GetRawTransaction returns the raw JSON transaction bytes for a given signature.
+// Returns nil if the transaction is not found or has no raw payload.
+func (s *Store) GetRawTransaction(ctx context.Context, signature string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[signature]
+	if !ok || len(tx.JsonTx) == 0 {
+		return nil, nil
+	}
+	return bytes.Clone(tx.JsonTx), nil
+}
+
+// GetOrderedNoParsedSignatures returns signatures of transactions (optionally only unparsed)
+// that are associated with a program, ordered by block_time.
+func (s *Store) GetOrderedNoParsedSignatures(ctx context.Context, resume bool) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var txs []*core.Transaction
+	for signature, tx := range s.transactions {
+		if len(s.links[signature]) == 0 || resume && tx.Parsed {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	slices.SortFunc(txs, func(a, b *core.Transaction) int {
+		return cmp.Or(
+			cmp.Compare(a.BlockTime, b.BlockTime),
+			cmp.Compare(a.Slot, b.Slot),
+			compareBlockIndex(a.BlockIndex, b.BlockIndex),
+			cmp.Compare(a.Signature, b.Signature),
+		)
+	})
+
+	signatures := make([]string, len(txs))
+	for i, tx := range txs {
+		signatures[i] = tx.Signature
+	}
+	return signatures, nil
+}
+
+// compareBlockIndex orders unknown block positions last, like Postgres orders NULLs.
+func compareBlockIndex(a, b *uint32) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return cmp.Compare(*a, *b)
+	}
+}
+
+// MarkParsed sets the `parsed` flag of a transaction to true, which also removes it from the real-time queue.
+func (s *Store) MarkParsed(ctx context.Context, signature string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tx, ok := s.transactions[signature]; ok {
+		tx.Parsed = true
+		tx.Pending = false
+	}
+	return nil
+}
+
+// IsParsed checks whether a transaction has already been parsed.
+func (s *Store) IsParsed(ctx context.Context, signature string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[signature]
+	return ok && tx.Parsed, nil
+}
+
+// SaveLookupTable inserts or replaces the stored snapshot of an Address Lookup Table.
+// A snapshot is only replaced by one fetched at the same or a later slot.
+func (s *Store) SaveLookupTable(ctx context.Context, lut *core.LookupTable) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.lookupTables[lut.Address]; ok && stored.FetchedSlot > lut.FetchedSlot {
+		return nil
+	}
+	stored := *lut
+	stored.Addresses = bytes.Clone(lut.Addresses)
+	s.lookupTables[lut.Address] = stored
+	return nil
+}
+
+// GetLookupTable returns the stored snapshot of an Address Lookup Table.
+// Returns nil if the table has never been fetched.
+func (s *Store) GetLookupTable(ctx context.Context, address string) (*core.LookupTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.lookupTables[address]
+	if !ok {
+		return nil, nil
+	}
+	stored.Addresses = bytes.Clone(stored.Addresses)
+	return &stored, nil
+}
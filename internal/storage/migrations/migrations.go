@@ -0,0 +1,253 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"gorm.io/gorm"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Migrations are embedded SQL files named <version>_<name>.up.sql and <version>_<name>.down.sql.
+// Each is applied in a database transaction together with its row in schema_migrations, so a
+// failed migration leaves nothing behind.
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockKey is the advisory lock that serializes migrations of concurrently starting indexers.
+const lockKey = 4_811_227_013
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, nil if it is pending.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type applied struct {
+	Version   int       `gorm:"column:version"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// All returns the embedded migrations in version order.
+func All() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("[migrations] failed to list migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("[migrations] invalid migration file name %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		data, err := files.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("[migrations] failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("[migrations] version %d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("[migrations] version %d needs both an up and a down file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// Statuses returns every embedded migration with when it was applied.
+func Statuses(ctx context.Context, db *gorm.DB) ([]Status, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+	done, err := appliedVersions(db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(migrations))
+	for i, m := range migrations {
+		statuses[i] = Status{Migration: m}
+		if at, ok := done[m.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies the pending migrations up to the target version, all of them if target is 0,
+// and returns those it applied.
+func Up(ctx context.Context, db *gorm.DB, target int) ([]Migration, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+	if target == 0 && len(migrations) > 0 {
+		target = migrations[len(migrations)-1].Version
+	}
+
+	var ran []Migration
+	for _, m := range migrations {
+		if m.Version > target {
+			break
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			done, err := lock(tx)
+			if err != nil {
+				return err
+			}
+			if newest := latest(done); newest > migrations[len(migrations)-1].Version {
+				return fmt.Errorf("[migrations] database schema version %d is newer than this build", newest)
+			}
+			if _, ok := done[m.Version]; ok {
+				return nil
+			}
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return fmt.Errorf("[migrations] %04d_%s failed: %w", m.Version, m.Name, err)
+			}
+			if err := tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name).Error; err != nil {
+				return fmt.Errorf("[migrations] failed to record %04d_%s: %w", m.Version, m.Name, err)
+			}
+			ran = append(ran, m)
+			return nil
+		})
+		if err != nil {
+			return ran, err
+		}
+	}
+	return ran, nil
+}
+
+// Down reverts the given number of the latest applied migrations and returns those it reverted.
+func Down(ctx context.Context, db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for range steps {
+		done := false
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			versions, err := lock(tx)
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				done = true
+				return nil
+			}
+			version := latest(versions)
+			i := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == version })
+			if i < 0 {
+				return fmt.Errorf("[migrations] applied version %d is unknown to this build", version)
+			}
+			m := migrations[i]
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return fmt.Errorf("[migrations] reverting %04d_%s failed: %w", m.Version, m.Name, err)
+			}
+			if err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error; err != nil {
+				return fmt.Errorf("[migrations] failed to unrecord %04d_%s: %w", m.Version, m.Name, err)
+			}
+			reverted = append(reverted, m)
+			return nil
+		})
+		if err != nil {
+			return reverted, err
+		}
+		if done {
+			break
+		}
+	}
+	return reverted, nil
+}
+
+// ensureTable creates schema_migrations if it doesn't exist.
+func ensureTable(ctx context.Context, db *gorm.DB) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version    bigint PRIMARY KEY,
+				name       text NOT NULL,
+				applied_at timestamptz NOT NULL DEFAULT now()
+			)`).Error
+	})
+	if err != nil {
+		return fmt.Errorf("[migrations] failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// lock takes the migration lock for the rest of the transaction and returns the applied versions.
+func lock(tx *gorm.DB) (map[int]time.Time, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+		return nil, fmt.Errorf("[migrations] failed to lock: %w", err)
+	}
+	return appliedVersions(tx)
+}
+
+func appliedVersions(db *gorm.DB) (map[int]time.Time, error) {
+	var rows []applied
+	if err := db.Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("[migrations] failed to load applied migrations: %w", err)
+	}
+	done := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row.AppliedAt
+	}
+	return done, nil
+}
+
+func latest(versions map[int]time.Time) int {
+	newest := 0
+	for version := range versions {
+		newest = max(newest, version)
+	}
+	return newest
+}
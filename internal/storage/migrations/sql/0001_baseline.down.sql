@@ -0,0 +1,34 @@
+-- Drops every table of the baseline, in reverse order of creation.
+
+DROP TABLE IF EXISTS "withdrawal_requests" CASCADE;
+DROP TABLE IF EXISTS "withdrawals" CASCADE;
+DROP TABLE IF EXISTS "vault_historical_aprs" CASCADE;
+DROP TABLE IF EXISTS "token_wallets" CASCADE;
+DROP TABLE IF EXISTS "token_stats" CASCADE;
+DROP TABLE IF EXISTS "token_mints" CASCADE;
+DROP TABLE IF EXISTS "token_burns" CASCADE;
+DROP TABLE IF EXISTS "token_accounts" CASCADE;
+DROP TABLE IF EXISTS "strategy_report_results" CASCADE;
+DROP TABLE IF EXISTS "strategy_report_events" CASCADE;
+DROP TABLE IF EXISTS "strategy_reports" CASCADE;
+DROP TABLE IF EXISTS "strategy_historical_aprs" CASCADE;
+DROP TABLE IF EXISTS "share_token_transfers" CASCADE;
+DROP TABLE IF EXISTS "share_token_data" CASCADE;
+DROP TABLE IF EXISTS "share_tokens" CASCADE;
+DROP TABLE IF EXISTS "free_funds" CASCADE;
+DROP TABLE IF EXISTS "deploy_funds" CASCADE;
+DROP TABLE IF EXISTS "strategies" CASCADE;
+DROP TABLE IF EXISTS "dtf_reports" CASCADE;
+DROP TABLE IF EXISTS "deposits" CASCADE;
+DROP TABLE IF EXISTS "account_vault_positions" CASCADE;
+DROP TABLE IF EXISTS "vaults" CASCADE;
+DROP TABLE IF EXISTS "accountants" CASCADE;
+DROP TABLE IF EXISTS "tokens" CASCADE;
+DROP TABLE IF EXISTS "accounts" CASCADE;
+DROP TABLE IF EXISTS "_meta" CASCADE;
+DROP TABLE IF EXISTS "_block_info" CASCADE;
+DROP TABLE IF EXISTS "core"."indexer_health" CASCADE;
+DROP TABLE IF EXISTS "core"."events" CASCADE;
+DROP TABLE IF EXISTS "core"."program_transactions" CASCADE;
+DROP TABLE IF EXISTS "core"."programs" CASCADE;
+DROP TABLE IF EXISTS "core"."transactions" CASCADE;
@@ -0,0 +1,416 @@
+-- Baseline: the schema AutoMigrate created before migrations were versioned. Tables and indexes are
+-- created only if missing, as a database of that version already has them. Everything added since is
+-- in the following migrations.
+
+CREATE SCHEMA IF NOT EXISTS core;
+
+CREATE TABLE IF NOT EXISTS "core"."transactions" (
+  "signature" text,
+  "slot" bigint,
+  "block_time" bigint,
+  "json_tx" JSONB,
+  "parsed" boolean DEFAULT false,
+  "created_at" timestamptz,
+  PRIMARY KEY ("signature")
+);
+
+CREATE TABLE IF NOT EXISTS "core"."programs" (
+  "id" text,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "core"."program_transactions" (
+  "program_id" text,
+  "transaction_signature" text,
+  PRIMARY KEY ("program_id","transaction_signature"),
+  CONSTRAINT "fk_core_program_transactions_program" FOREIGN KEY ("program_id") REFERENCES "core"."programs"("id") ON DELETE CASCADE,
+  CONSTRAINT "fk_core_program_transactions_transaction" FOREIGN KEY ("transaction_signature") REFERENCES "core"."transactions"("signature") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "core"."events" (
+  "transaction_signature" text,
+  "log_index" bigint,
+  "block_time" bigint,
+  "slot" bigint,
+  "name" text,
+  "json_ev" JSONB,
+  "mapped" boolean,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("transaction_signature","log_index"),
+  CONSTRAINT "fk_core_transactions_events" FOREIGN KEY ("transaction_signature") REFERENCES "core"."transactions"("signature") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "core"."indexer_health" (
+  "id" bigserial,
+  "status" text NOT NULL DEFAULT 'healthy',
+  "reason" text,
+  "updated_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "_block_info" (
+  "id" bigserial,
+  "hash" text,
+  "number" bigint,
+  "parent_hash" text,
+  "timestamp" bigint,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "_meta" (
+  "id" bigserial,
+  "deployment" text,
+  "has_indexing_errors" boolean,
+  "error_message" text,
+  "block_id" bigint,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk__meta_block" FOREIGN KEY ("block_id") REFERENCES "_block_info"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "accounts" (
+  "id" text,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "tokens" (
+  "id" text,
+  "decimals" numeric,
+  "name" text,
+  "symbol" text,
+  "current_price" numeric,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "accountants" (
+  "id" text,
+  "entry_fee" numeric,
+  "redemption_fee" numeric,
+  "performance_fees" numeric,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vaults" (
+  "id" text,
+  "token_id" text,
+  "share_token_id" text,
+  "deposit_limit" numeric,
+  "shutdown" boolean,
+  "total_debt" numeric,
+  "total_idle" numeric,
+  "min_total_idle" numeric,
+  "total_share" numeric,
+  "apr" numeric(38,20),
+  "shares_supply" numeric,
+  "balance_tokens" numeric,
+  "balance_tokens_idle" numeric,
+  "activation" numeric,
+  "performance_fees" numeric,
+  "total_allocation" numeric(38,20),
+  "accountant_id" text,
+  "min_user_deposit" numeric,
+  "user_deposit" numeric,
+  "user_deposit_limit" numeric,
+  "kyc_verified_only" boolean,
+  "direct_withdraw_enabled" boolean,
+  "direct_deposit_enabled" boolean,
+  "whitelisted_only" boolean,
+  "profit_max_unlock_time" numeric,
+  "current_share_price" numeric,
+  "last_update" numeric,
+  "total_priority_fees" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vaults_token" FOREIGN KEY ("token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_vaults_share_token" FOREIGN KEY ("share_token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_vaults_accountant" FOREIGN KEY ("accountant_id") REFERENCES "accountants"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "account_vault_positions" (
+  "id" text,
+  "vault_id" text,
+  "account_id" text,
+  "token_id" text,
+  "share_token_id" text,
+  "balance_shares" numeric,
+  "balance_tokens" numeric,
+  "balance_position" numeric,
+  "balance_profit" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_account_vault_positions_share_token" FOREIGN KEY ("share_token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_accounts_vault_positions" FOREIGN KEY ("account_id") REFERENCES "accounts"("id"),
+  CONSTRAINT "fk_account_vault_positions_vault" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id"),
+  CONSTRAINT "fk_account_vault_positions_token" FOREIGN KEY ("token_id") REFERENCES "tokens"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "deposits" (
+  "id" text,
+  "timestamp" numeric,
+  "block_number" numeric,
+  "account_id" text,
+  "vault_id" text,
+  "token_amount" numeric,
+  "shares_minted" numeric,
+  "token_id" text,
+  "share_token_id" text,
+  "share_price" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vaults_deposits" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id"),
+  CONSTRAINT "fk_deposits_token" FOREIGN KEY ("token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_deposits_share_token" FOREIGN KEY ("share_token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_accounts_deposits" FOREIGN KEY ("account_id") REFERENCES "accounts"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "dtf_reports" (
+  "id" text,
+  "total_assets" numeric,
+  "timestamp" numeric,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "strategies" (
+  "id" text,
+  "vault_id" text,
+  "strategy_type" text,
+  "amount" numeric,
+  "total_assets" numeric,
+  "deposit_limit" numeric,
+  "deposit_period_ends" numeric,
+  "lock_period_ends" numeric,
+  "current_debt" numeric,
+  "max_debt" numeric,
+  "apr" numeric(38,20),
+  "activation" numeric,
+  "delegated_assets" numeric,
+  "latest_report_id" text,
+  "reports_count" numeric,
+  "performance_fees" numeric,
+  "dtf_report_id" text,
+  "total_allocation" numeric(38,20),
+  "total_allocation_in_precent" numeric(38,20),
+  "effective_invested_amount" numeric,
+  "profit_or_loss" numeric(38,20),
+  "profit_or_loss_in_precent" numeric(38,20),
+  "asset_id" text,
+  "removed" boolean,
+  "removed_timestamp" numeric,
+  "underlying_mint" text,
+  "underlying_token_acc" text,
+  "underlying_decimals" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vaults_strategies" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id"),
+  CONSTRAINT "fk_strategies_asset" FOREIGN KEY ("asset_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_strategies_dtf_report" FOREIGN KEY ("dtf_report_id") REFERENCES "dtf_reports"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "deploy_funds" (
+  "id" text,
+  "strategy_id" text,
+  "amount" numeric,
+  "timestamp" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategies_deploy_funds" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "free_funds" (
+  "id" text,
+  "strategy_id" text,
+  "amount" numeric,
+  "timestamp" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategies_free_funds" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "share_tokens" (
+  "id" text,
+  "total_minted" numeric(38,20),
+  "total_burnt" numeric(38,20),
+  "total_transfer_in" numeric(38,20),
+  "total_transfer_out" numeric(38,20),
+  "current_price" numeric,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "share_token_data" (
+  "id" text,
+  "vault_id" text,
+  "timestamp" numeric,
+  "share_price" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_share_token_data_vault" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_vault_ts" ON "share_token_data" ("vault_id","timestamp");
+
+CREATE TABLE IF NOT EXISTS "share_token_transfers" (
+  "id" text,
+  "mint_id" text,
+  "authority_id" text,
+  "to_id" text,
+  "from_id" text,
+  "amount" numeric(38,20),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_share_tokens_transfer_out" FOREIGN KEY ("from_id") REFERENCES "share_tokens"("id"),
+  CONSTRAINT "fk_share_token_transfers_mint" FOREIGN KEY ("mint_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_share_tokens_transfer_in" FOREIGN KEY ("to_id") REFERENCES "share_tokens"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "strategy_historical_aprs" (
+  "id" text,
+  "timestamp" numeric,
+  "apr" numeric(38,20),
+  "strategy_id" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategies_historical_apr" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "strategy_reports" (
+  "id" text,
+  "timestamp" numeric,
+  "block_number" numeric,
+  "transaction_hash" text,
+  "strategy_id" text,
+  "gain" numeric,
+  "loss" numeric,
+  "current_debt" numeric,
+  "protocol_fees" numeric,
+  "total_fees" numeric,
+  "total_shares" numeric,
+  "vault_key" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategies_reports" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "strategy_report_events" (
+  "id" text,
+  "timestamp" numeric,
+  "block_number" numeric,
+  "transaction_hash" text,
+  "strategy_id" text,
+  "share_price" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategies_reports_events" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "strategy_report_results" (
+  "id" text,
+  "timestamp" numeric,
+  "block_number" numeric,
+  "current_report_id" text,
+  "previous_report_id" text,
+  "start_timestamp" numeric,
+  "end_timestamp" numeric,
+  "duration" numeric(38,20),
+  "duration_pr" numeric(38,20),
+  "apr" numeric(38,20),
+  "transaction_hash" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategy_report_results_previous_report" FOREIGN KEY ("previous_report_id") REFERENCES "strategy_reports"("id"),
+  CONSTRAINT "fk_strategy_reports_results" FOREIGN KEY ("current_report_id") REFERENCES "strategy_reports"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "token_accounts" (
+  "id" text,
+  "mint_id" text,
+  "owner_id" text,
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "token_burns" (
+  "id" text,
+  "mint_id" text,
+  "from_id" text,
+  "amount" numeric(38,20),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_token_burns_mint" FOREIGN KEY ("mint_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_share_tokens_burn" FOREIGN KEY ("from_id") REFERENCES "share_tokens"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "token_mints" (
+  "id" text,
+  "mint_id" text,
+  "to_id" text,
+  "amount" numeric(38,20),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_token_mints_mint" FOREIGN KEY ("mint_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_share_tokens_mint" FOREIGN KEY ("to_id") REFERENCES "share_tokens"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "token_stats" (
+  "id" text,
+  "timestamp" numeric,
+  "vault_id" text,
+  "share_price" numeric(38,20),
+  "interval" text NOT NULL DEFAULT 'hour',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_token_stats_vault" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "token_wallets" (
+  "id" text,
+  "authority_id" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_accounts_token_accounts" FOREIGN KEY ("authority_id") REFERENCES "accounts"("id"),
+  CONSTRAINT "fk_accounts_share_accounts" FOREIGN KEY ("authority_id") REFERENCES "accounts"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vault_historical_aprs" (
+  "id" text,
+  "timestamp" numeric,
+  "apr" numeric(38,20),
+  "vault_id" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vaults_historical_apr" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "withdrawals" (
+  "id" text,
+  "timestamp" numeric,
+  "block_number" numeric,
+  "account_id" text,
+  "vault_id" text,
+  "token_amount" numeric,
+  "shares_burnt" numeric,
+  "token_id" text,
+  "share_token_id" text,
+  "share_price" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_withdrawals_token" FOREIGN KEY ("token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_withdrawals_share_token" FOREIGN KEY ("share_token_id") REFERENCES "tokens"("id"),
+  CONSTRAINT "fk_vaults_withdrawals" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id"),
+  CONSTRAINT "fk_accounts_withdrawals" FOREIGN KEY ("account_id") REFERENCES "accounts"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "withdrawal_requests" (
+  "id" text,
+  "user" text,
+  "vault_id" text,
+  "index" numeric,
+  "recipient" text,
+  "shares" numeric,
+  "amount" numeric,
+  "max_loss" numeric,
+  "fee_shares" numeric,
+  "open" boolean,
+  "status" text,
+  "timestamp" numeric,
+  "priority_fees" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vaults_withdrawal_requests" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+-- strategies and strategy_reports reference each other, so this key is added once both exist
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_strategies_latest_report') THEN
+    ALTER TABLE "strategies"
+      ADD CONSTRAINT "fk_strategies_latest_report"
+      FOREIGN KEY ("latest_report_id")
+        REFERENCES "strategy_reports"("id")
+        ON UPDATE CASCADE
+        ON DELETE SET NULL;
+  END IF;
+END $$;
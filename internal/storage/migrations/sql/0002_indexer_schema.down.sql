@@ -0,0 +1,28 @@
+-- Drops the columns and tables added after the baseline.
+
+DROP TABLE IF EXISTS "entity_versions" CASCADE;
+DROP TABLE IF EXISTS "vault_hour_data" CASCADE;
+DROP TABLE IF EXISTS "vault_day_data" CASCADE;
+DROP TABLE IF EXISTS "strategy_day_data" CASCADE;
+DROP TABLE IF EXISTS "core"."watermarks" CASCADE;
+DROP TABLE IF EXISTS "core"."lookup_tables" CASCADE;
+
+ALTER TABLE "core"."programs"
+  DROP COLUMN IF EXISTS "updated_at",
+  DROP COLUMN IF EXISTS "active",
+  DROP COLUMN IF EXISTS "fetch_mode",
+  DROP COLUMN IF EXISTS "mapper",
+  DROP COLUMN IF EXISTS "decode_instructions",
+  DROP COLUMN IF EXISTS "commitment",
+  DROP COLUMN IF EXISTS "start_slot",
+  DROP COLUMN IF EXISTS "idl";
+
+DROP INDEX IF EXISTS "core"."idx_transactions_pending";
+
+ALTER TABLE "core"."transactions"
+  DROP COLUMN IF EXISTS "pending",
+  DROP COLUMN IF EXISTS "finalized",
+  DROP COLUMN IF EXISTS "block_index",
+  DROP COLUMN IF EXISTS "raw_pruned_at",
+  DROP COLUMN IF EXISTS "raw_object",
+  DROP COLUMN IF EXISTS "raw_zstd";
@@ -0,0 +1,117 @@
+-- Adds the columns and tables introduced after the baseline. Columns are added only if missing, as a
+-- database migrated by AutoMigrate in between may have some of them already.
+
+ALTER TABLE "core"."transactions"
+  ADD COLUMN IF NOT EXISTS "raw_zstd" bytea,
+  ADD COLUMN IF NOT EXISTS "raw_object" text,
+  ADD COLUMN IF NOT EXISTS "raw_pruned_at" timestamptz,
+  ADD COLUMN IF NOT EXISTS "block_index" bigint,
+  ADD COLUMN IF NOT EXISTS "finalized" boolean DEFAULT false,
+  ADD COLUMN IF NOT EXISTS "pending" boolean DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS "idx_transactions_pending" ON "core"."transactions" ("pending") WHERE pending;
+
+ALTER TABLE "core"."programs"
+  ADD COLUMN IF NOT EXISTS "idl" text,
+  ADD COLUMN IF NOT EXISTS "start_slot" bigint NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS "commitment" text NOT NULL DEFAULT 'confirmed',
+  ADD COLUMN IF NOT EXISTS "decode_instructions" boolean NOT NULL DEFAULT true,
+  ADD COLUMN IF NOT EXISTS "mapper" text NOT NULL DEFAULT 'vaults',
+  ADD COLUMN IF NOT EXISTS "fetch_mode" text NOT NULL DEFAULT 'signatures',
+  ADD COLUMN IF NOT EXISTS "active" boolean NOT NULL DEFAULT true,
+  ADD COLUMN IF NOT EXISTS "updated_at" timestamptz;
+
+CREATE TABLE IF NOT EXISTS "core"."lookup_tables" (
+  "address" text,
+  "addresses" JSONB,
+  "last_extended_slot" bigint,
+  "last_extended_slot_start_index" bigint,
+  "deactivation_slot" bigint,
+  "fetched_slot" bigint,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("address")
+);
+
+CREATE TABLE IF NOT EXISTS "core"."watermarks" (
+  "name" text,
+  "value" bigint,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "strategy_day_data" (
+  "id" text,
+  "strategy_id" text,
+  "vault_id" text,
+  "timestamp" numeric,
+  "current_debt" numeric,
+  "total_assets" numeric,
+  "gain" numeric,
+  "loss" numeric,
+  "apr" numeric(38,20),
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_strategy_day_data_strategy" FOREIGN KEY ("strategy_id") REFERENCES "strategies"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_strategy_day_data_timestamp" ON "strategy_day_data" ("timestamp");
+
+CREATE INDEX IF NOT EXISTS "idx_strategy_day_data_strategy_id" ON "strategy_day_data" ("strategy_id");
+
+CREATE TABLE IF NOT EXISTS "vault_day_data" (
+  "id" text,
+  "vault_id" text,
+  "timestamp" numeric,
+  "total_debt" numeric,
+  "total_idle" numeric,
+  "total_share" numeric,
+  "share_price" numeric,
+  "deposit_volume" numeric,
+  "withdraw_volume" numeric,
+  "deposit_count" numeric,
+  "withdraw_count" numeric,
+  "unique_depositors" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vault_day_data_vault" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_vault_day_data_timestamp" ON "vault_day_data" ("timestamp");
+
+CREATE INDEX IF NOT EXISTS "idx_vault_day_data_vault_id" ON "vault_day_data" ("vault_id");
+
+CREATE TABLE IF NOT EXISTS "vault_hour_data" (
+  "id" text,
+  "vault_id" text,
+  "timestamp" numeric,
+  "total_debt" numeric,
+  "total_idle" numeric,
+  "total_share" numeric,
+  "share_price" numeric,
+  "deposit_volume" numeric,
+  "withdraw_volume" numeric,
+  "deposit_count" numeric,
+  "withdraw_count" numeric,
+  "unique_depositors" numeric,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_vault_hour_data_vault" FOREIGN KEY ("vault_id") REFERENCES "vaults"("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_vault_hour_data_timestamp" ON "vault_hour_data" ("timestamp");
+
+CREATE INDEX IF NOT EXISTS "idx_vault_hour_data_vault_id" ON "vault_hour_data" ("vault_id");
+
+CREATE TABLE IF NOT EXISTS "entity_versions" (
+  "vid" bigserial,
+  "entity" text NOT NULL,
+  "entity_id" text NOT NULL,
+  "slot_from" bigint NOT NULL,
+  "slot_to" bigint,
+  "data" JSONB,
+  PRIMARY KEY ("vid")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_entity_versions_slot_to" ON "entity_versions" ("slot_to");
+
+CREATE INDEX IF NOT EXISTS "idx_entity_versions_slot_from" ON "entity_versions" ("slot_from");
+
+CREATE INDEX IF NOT EXISTS "idx_entity_versions_lookup" ON "entity_versions" ("entity","entity_id","slot_from");
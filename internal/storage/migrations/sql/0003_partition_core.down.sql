@@ -0,0 +1,34 @@
+-- Moves core.transactions and core.events back into single tables with the baseline keys. Of rows that
+-- only differ in slot, the one with the highest slot is kept.
+
+CREATE TABLE core.transactions_unpartitioned (LIKE core.transactions INCLUDING DEFAULTS);
+INSERT INTO core.transactions_unpartitioned
+SELECT DISTINCT ON (signature) * FROM core.transactions ORDER BY signature, slot DESC;
+
+CREATE TABLE core.events_unpartitioned (LIKE core.events INCLUDING DEFAULTS);
+INSERT INTO core.events_unpartitioned
+SELECT DISTINCT ON (transaction_signature, log_index) * FROM core.events ORDER BY transaction_signature, log_index, slot DESC;
+
+DROP TABLE core.transactions CASCADE;
+DROP TABLE core.events CASCADE;
+DROP FUNCTION IF EXISTS core.create_slot_partitions(text, bigint);
+DROP INDEX IF EXISTS core.idx_program_transactions_signature;
+
+ALTER TABLE core.transactions_unpartitioned RENAME TO transactions;
+ALTER TABLE core.transactions ALTER COLUMN slot DROP NOT NULL;
+ALTER TABLE core.transactions ADD CONSTRAINT transactions_pkey PRIMARY KEY ("signature");
+CREATE INDEX idx_transactions_pending ON core.transactions ("pending") WHERE pending;
+
+ALTER TABLE core.events_unpartitioned RENAME TO events;
+ALTER TABLE core.events ALTER COLUMN slot DROP NOT NULL;
+ALTER TABLE core.events ADD CONSTRAINT events_pkey PRIMARY KEY ("transaction_signature","log_index");
+
+DELETE FROM core.program_transactions pt
+WHERE NOT EXISTS (SELECT 1 FROM core.transactions t WHERE t.signature = pt.transaction_signature);
+DELETE FROM core.events e
+WHERE NOT EXISTS (SELECT 1 FROM core.transactions t WHERE t.signature = e.transaction_signature);
+
+ALTER TABLE core.program_transactions ADD CONSTRAINT fk_core_program_transactions_transaction
+  FOREIGN KEY ("transaction_signature") REFERENCES core.transactions("signature") ON DELETE CASCADE;
+ALTER TABLE core.events ADD CONSTRAINT fk_core_transactions_events
+  FOREIGN KEY ("transaction_signature") REFERENCES core.transactions("signature") ON DELETE CASCADE;
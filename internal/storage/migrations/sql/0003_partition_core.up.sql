@@ -0,0 +1,125 @@
+-- Partitions core.transactions and core.events by slot range and adds the indexes of their hot queries.
+-- A primary key of a partitioned table must contain the partition key, so the signature alone is no
+-- longer unique to the database. Nothing can reference it anymore; the indexer keeps it unique when
+-- inserting, and deletes events and program links explicitly.
+
+ALTER TABLE core.program_transactions DROP CONSTRAINT IF EXISTS fk_core_program_transactions_transaction;
+ALTER TABLE core.events DROP CONSTRAINT IF EXISTS fk_core_transactions_events;
+
+ALTER TABLE core.transactions RENAME TO transactions_unpartitioned;
+ALTER TABLE core.transactions_unpartitioned RENAME CONSTRAINT transactions_pkey TO transactions_unpartitioned_pkey;
+ALTER INDEX core.idx_transactions_pending RENAME TO idx_transactions_unpartitioned_pending;
+ALTER TABLE core.events RENAME TO events_unpartitioned;
+ALTER TABLE core.events_unpartitioned RENAME CONSTRAINT events_pkey TO events_unpartitioned_pkey;
+
+CREATE TABLE core.transactions (
+  "signature" text NOT NULL,
+  "slot" bigint NOT NULL,
+  "block_time" bigint,
+  "json_tx" JSONB,
+  "raw_zstd" bytea,
+  "raw_object" text,
+  "raw_pruned_at" timestamptz,
+  "block_index" bigint,
+  "parsed" boolean DEFAULT false,
+  "finalized" boolean DEFAULT false,
+  "pending" boolean DEFAULT false,
+  "created_at" timestamptz,
+  PRIMARY KEY ("signature","slot")
+) PARTITION BY RANGE ("slot");
+
+CREATE TABLE core.transactions_default PARTITION OF core.transactions DEFAULT;
+
+-- GetOrderedNoParsedSignatures and the retention
+CREATE INDEX idx_transactions_parsed_block_time ON core.transactions ("parsed","block_time","slot");
+-- Slot ranges and the (slot, signature) cursor of ListTransactions
+CREATE INDEX idx_transactions_slot ON core.transactions ("slot","signature");
+-- The real-time queue
+CREATE INDEX idx_transactions_pending ON core.transactions ("pending") WHERE pending;
+-- GetOrderedNoRawSignatures
+CREATE INDEX idx_transactions_missing_raw ON core.transactions ("block_time","slot")
+  WHERE json_tx IS NULL AND raw_zstd IS NULL AND raw_object IS NULL AND raw_pruned_at IS NULL;
+
+CREATE TABLE core.events (
+  "transaction_signature" text NOT NULL,
+  "log_index" bigint NOT NULL,
+  "block_time" bigint,
+  "slot" bigint NOT NULL,
+  "name" text,
+  "json_ev" JSONB,
+  "mapped" boolean,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("transaction_signature","log_index","slot")
+) PARTITION BY RANGE ("slot");
+
+CREATE TABLE core.events_default PARTITION OF core.events DEFAULT;
+
+-- LoadEventsBySlotCursor, in canonical order
+CREATE INDEX idx_events_slot ON core.events ("slot","transaction_signature","log_index");
+CREATE INDEX idx_events_name ON core.events ("name");
+
+-- Lookups by program_id are served by the primary key, lookups by transaction by this one
+CREATE INDEX IF NOT EXISTS idx_program_transactions_signature ON core.program_transactions ("transaction_signature");
+
+-- create_slot_partitions creates the missing partitions of a table partitioned by slot: those for the
+-- slots from the lowest start slot of the active programs to one partition past both upto and the
+-- highest stored slot, and those for rows in the default partition, which are moved into them.
+-- It returns how many it created.
+CREATE OR REPLACE FUNCTION core.create_slot_partitions(parent text, upto bigint) RETURNS integer
+LANGUAGE plpgsql AS $$
+DECLARE
+    size    CONSTANT bigint := 10000000; -- about 46 days of slots
+    created integer := 0;
+    low     bigint;
+    high    bigint;
+    bucket  bigint;
+    part    text;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('core.create_slot_partitions'));
+
+    SELECT coalesce(min(start_slot), 0) / size INTO low FROM core.programs WHERE active;
+    EXECUTE format('SELECT coalesce(max(slot), 0) FROM core.%I', parent) INTO high;
+    high := greatest(greatest(high, upto) / size, low) + 1;
+
+    FOR bucket IN EXECUTE format(
+        'SELECT generate_series($1, $2) UNION SELECT DISTINCT slot / $3 FROM core.%I', parent || '_default')
+        USING low, high, size
+    LOOP
+        part := format('%s_p%s', parent, bucket);
+        CONTINUE WHEN to_regclass(format('core.%I', part)) IS NOT NULL;
+
+        -- The default partition must not hold rows of a partition attached next to it
+        EXECUTE format('CREATE TABLE core.%I (LIKE core.%I INCLUDING DEFAULTS)', part, parent);
+        EXECUTE format(
+            'WITH moved AS (DELETE FROM core.%I WHERE slot >= $1 AND slot < $2 RETURNING *) INSERT INTO core.%I SELECT * FROM moved',
+            parent || '_default', part)
+            USING bucket * size, (bucket + 1) * size;
+        EXECUTE format('ALTER TABLE core.%I ATTACH PARTITION core.%I FOR VALUES FROM (%s) TO (%s)',
+            parent, part, bucket * size, (bucket + 1) * size);
+        created := created + 1;
+    END LOOP;
+    RETURN created;
+END $$;
+
+SELECT core.create_slot_partitions('transactions', (SELECT coalesce(max(slot), 0) FROM core.transactions_unpartitioned));
+SELECT core.create_slot_partitions('events', (SELECT coalesce(max(slot), 0) FROM core.events_unpartitioned));
+
+INSERT INTO core.transactions (
+  signature, slot, block_time, json_tx, raw_zstd, raw_object, raw_pruned_at, block_index, parsed, finalized, pending, created_at
+)
+SELECT signature, coalesce(slot, 0), block_time, json_tx, raw_zstd, raw_object, raw_pruned_at, block_index, parsed, finalized, pending, created_at
+FROM core.transactions_unpartitioned;
+
+INSERT INTO core.events (
+  transaction_signature, log_index, block_time, slot, name, json_ev, mapped, created_at, updated_at
+)
+SELECT transaction_signature, log_index, block_time, coalesce(slot, 0), name, json_ev, mapped, created_at, updated_at
+FROM core.events_unpartitioned;
+
+DROP TABLE core.transactions_unpartitioned;
+DROP TABLE core.events_unpartitioned;
+
+-- Rows below the start slots of the active programs landed in the default partitions
+SELECT core.create_slot_partitions('transactions', 0);
+SELECT core.create_slot_partitions('events', 0);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_deposits_vault_ts";
@@ -0,0 +1,3 @@
+-- Serves the count of distinct depositors of a vault in a snapshot bucket, run on every deposit.
+-- account_id is included so the count is answered from the index alone.
+CREATE INDEX IF NOT EXISTS "idx_deposits_vault_ts" ON "deposits" ("vault_id","timestamp","account_id");
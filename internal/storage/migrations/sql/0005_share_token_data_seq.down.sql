@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_share_token_data_seq";
+ALTER TABLE "share_token_data" DROP COLUMN IF EXISTS "seq";
+DELETE FROM "core"."watermarks" WHERE "name" LIKE 'aggregator:%';
@@ -0,0 +1,7 @@
+-- Numbers share token data in insert order, so the aggregator can find the buckets touched since its
+-- last run whatever the timestamps of the new rows; backfills insert rows older than the latest.
+-- Existing rows are numbered by the column's creation and the aggregator watermarks, which held
+-- timestamps, are reset so the next run recomputes every bucket once.
+ALTER TABLE "share_token_data" ADD COLUMN IF NOT EXISTS "seq" bigint GENERATED BY DEFAULT AS IDENTITY;
+CREATE INDEX IF NOT EXISTS "idx_share_token_data_seq" ON "share_token_data" ("seq");
+DELETE FROM "core"."watermarks" WHERE "name" LIKE 'aggregator:%';
@@ -0,0 +1,19 @@
+-- Keeps a signature unique across the slot partitions of core.transactions, whose primary key has to
+-- contain the slot. Inserts claim the signature here first in the same database transaction, so a
+-- concurrent insert of the same signature waits for it and skips the signature. Lookups by signature
+-- read the slot here, so only the partition of that slot is scanned.
+CREATE TABLE IF NOT EXISTS core.transaction_signatures (
+  "signature" text NOT NULL,
+  "slot" bigint NOT NULL,
+  PRIMARY KEY ("signature")
+);
+
+-- Of rows that only differ in slot, the one with the highest slot is kept, as when reverting 0003
+INSERT INTO core.transaction_signatures (signature, slot)
+SELECT DISTINCT ON (signature) signature, slot FROM core.transactions ORDER BY signature, slot DESC
+ON CONFLICT DO NOTHING;
+
+DELETE FROM core.events e USING core.transaction_signatures s
+WHERE s.signature = e.transaction_signature AND s.slot <> e.slot;
+DELETE FROM core.transactions t USING core.transaction_signatures s
+WHERE s.signature = t.signature AND s.slot <> t.slot;
@@ -0,0 +1,2 @@
+-- The transactions marked finalized can't be told apart from those finalized by the reconciler, so
+-- they are left as they are.
@@ -0,0 +1,6 @@
+-- Transactions stored before the reconciler first ran were taken as final. They are marked finalized,
+-- so the reconciler doesn't check the whole history on its first run. Once it has recorded a finalized
+-- watermark, unfinalized transactions are tracked by it and left as they are.
+UPDATE "core"."transactions" SET "finalized" = true
+WHERE NOT "finalized"
+  AND NOT EXISTS (SELECT 1 FROM "core"."watermarks" WHERE "name" = 'finalized_slot');
@@ -0,0 +1 @@
+ALTER TABLE "core"."events" DROP COLUMN IF EXISTS "kind";
@@ -0,0 +1,5 @@
+-- Stores whether an event was emitted in the program logs or is a decoded token instruction, so a
+-- replay maps it the same way as the parser. Events stored before were told apart by their log index:
+-- the parser numbers instructions from 1000 and program events from 2000.
+ALTER TABLE "core"."events" ADD COLUMN IF NOT EXISTS "kind" text NOT NULL DEFAULT 'event';
+UPDATE "core"."events" SET "kind" = 'instruction' WHERE "log_index" < 2000;
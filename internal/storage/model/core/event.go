@@ -5,12 +5,21 @@ import (
 	"time"
 )
 
+// Event kinds tell how a stored event is mapped into the subgraph.
+const (
+	EventKindProgram     = "event"       // an event emitted in the program logs
+	EventKindInstruction = "instruction" // a decoded token instruction
+)
+
+// Event is a decoded event. The table is partitioned by slot with the primary key
+// (transaction_signature, log_index, slot).
 type Event struct {
 	TransactionSignature string         `gorm:"column:transaction_signature;primaryKey"`
 	LogIndex             int            `gorm:"column:log_index;primaryKey"`
 	BlockTime            int64          `gorm:"column:block_time"`
 	Slot                 uint64         `gorm:"column:slot"`
 	Name                 string         `gorm:"column:name"`
+	Kind                 string         `gorm:"column:kind;not null;default:event"`
 	JsonEv               datatypes.JSON `gorm:"column:json_ev;type:jsonb"`
 	Mapped               bool           `gorm:"column:mapped"`
 	CreatedAt            time.Time      `gorm:"column:created_at;autoCreateTime"`
@@ -0,0 +1,24 @@
+package core
+
+import (
+	"gorm.io/datatypes"
+	"time"
+)
+
+// LookupTable is a persisted snapshot of an on-chain Address Lookup Table.
+// Addresses are append-only, so a snapshot taken at FetchedSlot stays valid
+// for older transactions as long as the referenced index already existed.
+type LookupTable struct {
+	Address                    string         `gorm:"primaryKey;column:address"`
+	Addresses                  datatypes.JSON `gorm:"column:addresses;type:jsonb"`
+	LastExtendedSlot           uint64         `gorm:"column:last_extended_slot"`
+	LastExtendedSlotStartIndex int            `gorm:"column:last_extended_slot_start_index"`
+	DeactivationSlot           *uint64        `gorm:"column:deactivation_slot"` // nil while the table is active
+	FetchedSlot                uint64         `gorm:"column:fetched_slot"`
+	CreatedAt                  time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt                  time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (LookupTable) TableName() string {
+	return "core.lookup_tables"
+}
@@ -4,10 +4,20 @@ import (
 	"time"
 )
 
+// Program is an indexed on-chain program together with its indexing settings.
+// Inactive programs keep their transactions but are no longer fetched or subscribed to.
 type Program struct {
-	ID        string        `gorm:"primaryKey;column:id"`
-	Txns      []Transaction `gorm:"many2many:core.program_transactions;joinForeignKey:program_id;joinReferences:transaction_signature;constraint:OnDelete:CASCADE;" gorm:"column:txns"`
-	CreatedAt time.Time     `gorm:"column:created_at;autoCreateTime"`
+	ID                 string        `gorm:"primaryKey;column:id"`
+	IDL                string        `gorm:"column:idl"`
+	StartSlot          uint64        `gorm:"column:start_slot;not null;default:0"`
+	Commitment         string        `gorm:"column:commitment;not null;default:confirmed"`
+	DecodeInstructions bool          `gorm:"column:decode_instructions;not null;default:true"`
+	Mapper             string        `gorm:"column:mapper;not null;default:vaults"`
+	FetchMode          string        `gorm:"column:fetch_mode;not null;default:signatures"`
+	Active             bool          `gorm:"column:active;not null;default:true"`
+	Txns               []Transaction `gorm:"many2many:core.program_transactions;joinForeignKey:program_id;joinReferences:transaction_signature;constraint:OnDelete:CASCADE;" gorm:"column:txns"`
+	CreatedAt          time.Time     `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt          time.Time     `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (Program) TableName() string {
@@ -5,17 +5,36 @@ import (
 	"time"
 )
 
+// Transaction is a stored transaction. The table is partitioned by slot with the primary key
+// (signature, slot).
 type Transaction struct {
-	Signature string         `gorm:"primaryKey;column:signature"`
-	Slot      uint64         `gorm:"column:slot"`
-	BlockTime int64          `gorm:"column:block_time"`
-	JsonTx    datatypes.JSON `gorm:"column:json_tx;type:jsonb"`
-	Parsed    bool           `gorm:"column:parsed;default:false"`
-	Programs  []Program      `gorm:"many2many:core.program_transactions;joinForeignKey:transaction_signature;joinReferences:program_id;constraint:OnDelete:CASCADE;" gorm:"column:programs"`
-	Events    []Event        `gorm:"foreignKey:TransactionSignature;references:Signature;constraint:OnDelete:CASCADE" gorm:"column:events"`
-	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
+	Signature  string         `gorm:"primaryKey;column:signature"`
+	Slot       uint64         `gorm:"column:slot"`
+	BlockTime  int64          `gorm:"column:block_time"`
+	JsonTx     datatypes.JSON `gorm:"column:json_tx;type:jsonb"`
+	RawZstd    []byte         `gorm:"column:raw_zstd;type:bytea"` // raw payload in the zstd format
+	RawObject  *string        `gorm:"column:raw_object"`          // object key of the raw payload in the directory format
+	RawPruned  *time.Time     `gorm:"column:raw_pruned_at"`       // the raw payload was removed by the retention
+	BlockIndex *uint32        `gorm:"column:block_index"`         // position in its block, known when fetched with getBlock
+	Parsed     bool           `gorm:"column:parsed;default:false"`
+	Finalized  bool           `gorm:"column:finalized;default:false"`
+	Pending    bool           `gorm:"column:pending;default:false;index:idx_transactions_pending,where:pending"` // queued by the real-time path until parsed
+	Programs   []Program      `gorm:"many2many:core.program_transactions;joinForeignKey:transaction_signature;joinReferences:program_id;constraint:OnDelete:CASCADE;" gorm:"column:programs"`
+	Events     []Event        `gorm:"foreignKey:TransactionSignature;references:Signature;constraint:OnDelete:CASCADE" gorm:"column:events"`
+	CreatedAt  time.Time      `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (Transaction) TableName() string {
 	return "core.transactions"
 }
+
+// TransactionSignature holds the slot a transaction is stored at. It keeps the signature unique across
+// the slot partitions of core.transactions and routes lookups by signature to one partition.
+type TransactionSignature struct {
+	Signature string `gorm:"primaryKey;column:signature"`
+	Slot      uint64 `gorm:"column:slot;not null"`
+}
+
+func (TransactionSignature) TableName() string {
+	return "core.transaction_signatures"
+}
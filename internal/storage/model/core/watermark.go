@@ -0,0 +1,14 @@
+package core
+
+import "time"
+
+// Watermark stores a named progress marker, e.g. the highest slot known to be finalized.
+type Watermark struct {
+	Name      string    `gorm:"primaryKey;column:name"`
+	Value     uint64    `gorm:"column:value"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Watermark) TableName() string {
+	return "core.watermarks"
+}
@@ -3,9 +3,12 @@ package generic
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"reflect"
 )
 
 type Identifiable interface {
@@ -13,29 +16,64 @@ type Identifiable interface {
 	Init()
 }
 
-func Load[T Identifiable](ctx context.Context, db *gorm.DB, model T) (bool, error) {
-	err := db.WithContext(ctx).
-		Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)}).
-		Where("id = ?", model.GetID()).
-		First(model).Error
+// EntityStore loads, saves and counts subgraph entities. storage.Gorm keeps them in Postgres,
+// memory.Store in memory for tests.
+type EntityStore interface {
+	// LoadEntity loads the entity with the model's id and the given relations,
+	// or initializes the model and returns false if there is none.
+	LoadEntity(ctx context.Context, model Identifiable, preloads ...string) (bool, error)
+	// SaveEntity inserts or replaces the entity by id, recording a version of it
+	// when versioning is enabled and the context carries a slot.
+	SaveEntity(ctx context.Context, model any) error
+	// FindEntities loads the entities of dest's element type matching all conditions into dest,
+	// a pointer to a slice.
+	FindEntities(ctx context.Context, dest any, conds ...Cond) error
+	// CountEntities counts the distinct values of a column among the entities of the model's type
+	// matching all conditions.
+	CountEntities(ctx context.Context, model any, distinct string, conds ...Cond) (int64, error)
+}
 
-	switch {
-	case errors.Is(err, gorm.ErrRecordNotFound):
-		model.Init()
-		return false, nil
-	case err != nil:
-		return false, err
-	default:
-		return true, nil
-	}
+// Cond compares a column with a value.
+type Cond struct {
+	Column string
+	Op     string // One of =, <>, <, <=, >, >=
+	Value  any
+}
+
+// Eq returns the condition column = value.
+func Eq(column string, value any) Cond {
+	return Cond{Column: column, Op: "=", Value: value}
+}
+
+// Gte returns the condition column >= value.
+func Gte(column string, value any) Cond {
+	return Cond{Column: column, Op: ">=", Value: value}
+}
+
+// Lt returns the condition column < value.
+func Lt(column string, value any) Cond {
+	return Cond{Column: column, Op: "<", Value: value}
+}
+
+func Load[T Identifiable](ctx context.Context, db EntityStore, model T) (bool, error) {
+	return db.LoadEntity(ctx, model)
 }
 
 func LoadWithPreloads[T Identifiable](
 	ctx context.Context,
-	db *gorm.DB,
+	db EntityStore,
 	model T,
 	preloads ...string,
 ) (bool, error) {
+	return db.LoadEntity(ctx, model, preloads...)
+}
+
+func Save[T any](ctx context.Context, db EntityStore, model T) error {
+	return db.SaveEntity(ctx, model)
+}
+
+// LoadFromDB is LoadEntity on Postgres.
+func LoadFromDB(ctx context.Context, db *gorm.DB, model Identifiable, preloads ...string) (bool, error) {
 	q := db.WithContext(ctx).
 		Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)})
 	for _, field := range preloads {
@@ -54,11 +92,81 @@ func LoadWithPreloads[T Identifiable](
 	}
 }
 
-func Save[T any](ctx context.Context, db *gorm.DB, model T) error {
-	return db.WithContext(ctx).
+// SaveToDB is SaveEntity on Postgres.
+func SaveToDB(ctx context.Context, db *gorm.DB, model any) error {
+	if err := db.WithContext(ctx).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "id"}},
 			UpdateAll: true,
 		}).
-		Create(model).Error
+		Create(model).Error; err != nil {
+		return err
+	}
+
+	if slot, ok := SlotFromContext(ctx); ok && VersioningEnabled() {
+		return saveVersion(ctx, db, model, slot)
+	}
+	return nil
+}
+
+// SetForeignKeys sets the foreign keys of the model's belongs-to associations that are set to
+// their ids, as the Create of SaveToDB does. The associated entities themselves are not saved.
+func SetForeignKeys(ctx context.Context, sch *schema.Schema, value reflect.Value) error {
+	for _, rel := range sch.Relationships.BelongsTo {
+		assoc := rel.Field.ReflectValueOf(ctx, value)
+		if assoc.Kind() == reflect.Ptr && assoc.IsNil() {
+			continue
+		}
+		assoc = reflect.Indirect(assoc)
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey || ref.PrimaryKey == nil {
+				continue
+			}
+			id, zero := ref.PrimaryKey.ValueOf(ctx, assoc)
+			if zero {
+				continue
+			}
+			if err := ref.ForeignKey.Set(ctx, value, id); err != nil {
+				return fmt.Errorf("failed to set %s.%s: %w", sch.Table, ref.ForeignKey.DBName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// FindInDB is FindEntities on Postgres.
+func FindInDB(ctx context.Context, db *gorm.DB, dest any, conds ...Cond) error {
+	q, err := where(db.WithContext(ctx), conds)
+	if err != nil {
+		return err
+	}
+	return q.Find(dest).Error
+}
+
+// CountInDB is CountEntities on Postgres.
+func CountInDB(ctx context.Context, db *gorm.DB, model any, distinct string, conds ...Cond) (int64, error) {
+	q, err := where(db.WithContext(ctx).Model(model), conds)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := q.Distinct(distinct).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func where(q *gorm.DB, conds []Cond) (*gorm.DB, error) {
+	for _, c := range conds {
+		switch c.Op {
+		case "=", "<>", "<", "<=", ">", ">=":
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", c.Op)
+		}
+		q = q.Where(clause.Expr{
+			SQL:  "? " + c.Op + " ?",
+			Vars: []any{clause.Column{Name: c.Column}, c.Value},
+		})
+	}
+	return q, nil
 }
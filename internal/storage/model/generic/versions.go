@@ -0,0 +1,256 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// EntityVersion is one historical state of a subgraph entity, valid for the slots
+// in [SlotFrom, SlotTo). The open version (SlotTo == nil) is the current state.
+// Data holds the entity columns as a JSON object keyed by column name.
+type EntityVersion struct {
+	VID      uint64         `gorm:"primaryKey;autoIncrement;column:vid"`
+	Entity   string         `gorm:"column:entity;not null;index:idx_entity_versions_lookup,priority:1"`
+	EntityID string         `gorm:"column:entity_id;not null;index:idx_entity_versions_lookup,priority:2"`
+	SlotFrom uint64         `gorm:"column:slot_from;not null;index:idx_entity_versions_lookup,priority:3;index:idx_entity_versions_slot_from"`
+	SlotTo   *uint64        `gorm:"column:slot_to;index:idx_entity_versions_slot_to"`
+	Data     datatypes.JSON `gorm:"column:data;type:jsonb"`
+}
+
+func (EntityVersion) TableName() string {
+	return "entity_versions"
+}
+
+type slotKey struct{}
+
+var versioned atomic.Bool
+
+// EnableVersioning switches the versioned storage mode on or off.
+// When enabled, every Save performed with a slot in the context also records a version row.
+func EnableVersioning(enabled bool) {
+	versioned.Store(enabled)
+}
+
+// VersioningEnabled reports whether the versioned storage mode is on.
+func VersioningEnabled() bool {
+	return versioned.Load()
+}
+
+// WithSlot returns a context carrying the slot that entity changes belong to.
+func WithSlot(ctx context.Context, slot uint64) context.Context {
+	return context.WithValue(ctx, slotKey{}, slot)
+}
+
+// SlotFromContext returns the slot set by WithSlot, if any.
+func SlotFromContext(ctx context.Context) (uint64, bool) {
+	slot, ok := ctx.Value(slotKey{}).(uint64)
+	return slot, ok
+}
+
+// saveVersion closes the open version of the entity at slot and opens a new one.
+// A second change within the same slot overwrites that slot's version in place.
+func saveVersion(ctx context.Context, db *gorm.DB, model any, slot uint64) error {
+	entity, entityID, data, err := VersionData(ctx, db, model)
+	if err != nil {
+		return err
+	}
+	return WriteVersion(ctx, db, entity, entityID, slot, data)
+}
+
+// VersionData returns the table, id and columns of an entity as its versions record them.
+func VersionData(ctx context.Context, db *gorm.DB, model any) (entity, entityID string, data []byte, err error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", "", nil, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(model))
+	columns := make(map[string]any, len(stmt.Schema.DBNames))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		v, _ := field.ValueOf(ctx, value)
+		columns[field.DBName] = v
+	}
+	id, ok := columns["id"]
+	if !ok {
+		return "", "", nil, fmt.Errorf("entity %s has no id column", stmt.Schema.Table)
+	}
+	data, err = json.Marshal(columns)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to encode %s: %w", stmt.Schema.Table, err)
+	}
+	return stmt.Schema.Table, fmt.Sprint(id), data, nil
+}
+
+// WriteVersion records data from VersionData as the version of an entity opened at slot, closing
+// the open version, or overwriting it if it was opened at the same slot.
+func WriteVersion(ctx context.Context, db *gorm.DB, entity, entityID string, slot uint64, data []byte) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&EntityVersion{}).
+			Where("entity = ? AND entity_id = ? AND slot_to IS NULL AND slot_from = ?", entity, entityID, slot).
+			Update("data", datatypes.JSON(data))
+		if res.Error != nil {
+			return fmt.Errorf("failed to update version of %s %s: %w", entity, entityID, res.Error)
+		}
+		if res.RowsAffected > 0 {
+			return nil
+		}
+
+		if err := tx.Model(&EntityVersion{}).
+			Where("entity = ? AND entity_id = ? AND slot_to IS NULL", entity, entityID).
+			Update("slot_to", slot).Error; err != nil {
+			return fmt.Errorf("failed to close version of %s %s: %w", entity, entityID, err)
+		}
+
+		return tx.Create(&EntityVersion{
+			Entity:   entity,
+			EntityID: entityID,
+			SlotFrom: slot,
+			Data:     datatypes.JSON(data),
+		}).Error
+	})
+}
+
+// VersionStore reads the recorded versions of subgraph entities. storage.Gorm reads them from
+// entity_versions, memory.Store from memory for tests.
+type VersionStore interface {
+	// LoadEntityAt loads the state the entity with the model's id had at the slot, or initializes the
+	// model and returns false if it didn't exist then.
+	LoadEntityAt(ctx context.Context, model Identifiable, slot uint64) (bool, error)
+}
+
+// LoadAt loads the state of an entity as of the given slot from its version history.
+// Returns false (and initializes the model) if the entity did not exist at that slot.
+func LoadAt[T Identifiable](ctx context.Context, db VersionStore, model T, slot uint64) (bool, error) {
+	return db.LoadEntityAt(ctx, model, slot)
+}
+
+// VersionsAt returns a query of the entities of a table as of the given slot, with the columns of
+// the table, to be used as its source: db.Table("(?) AS vaults", VersionsAt(db, "vaults", slot)).
+func VersionsAt(db *gorm.DB, table string, slot uint64) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Table("entity_versions").
+		Select(fmt.Sprintf("(jsonb_populate_record(NULL::%s, data)).*", db.Statement.Quote(table))).
+		Where("entity = ? AND slot_from <= ? AND (slot_to IS NULL OR slot_to > ?)", table, slot, slot)
+}
+
+// LoadAtFromDB is LoadEntityAt on Postgres.
+func LoadAtFromDB(ctx context.Context, db *gorm.DB, model Identifiable, slot uint64) (bool, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return false, fmt.Errorf("failed to parse model: %w", err)
+	}
+	table := stmt.Schema.Table
+
+	res := db.WithContext(ctx).
+		Table("(?) AS "+stmt.Quote(table), VersionsAt(db, table, slot)).
+		Where("id = ?", model.GetID()).
+		Limit(1).
+		Find(model)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		model.Init()
+		return false, nil
+	}
+	return true, nil
+}
+
+// Rollback reverts every entity change recorded after the given slot.
+// Versions opened after the slot are deleted, versions closed after it are reopened,
+// and the current entity tables are restored from the reopened versions.
+func Rollback(ctx context.Context, db *gorm.DB, slot uint64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		type touchedEntity struct {
+			Entity   string
+			EntityID string
+			FirstVID uint64
+		}
+		var touched []touchedEntity
+		if err := tx.Raw(`
+			SELECT entity, entity_id, MIN(vid) AS first_vid
+			FROM entity_versions
+			WHERE (entity, entity_id) IN (
+				SELECT entity, entity_id FROM entity_versions WHERE slot_from > ? OR slot_to > ?
+			)
+			GROUP BY entity, entity_id`, slot, slot).
+			Scan(&touched).Error; err != nil {
+			return fmt.Errorf("failed to collect entities changed after slot %d: %w", slot, err)
+		}
+
+		if err := tx.Where("slot_from > ?", slot).Delete(&EntityVersion{}).Error; err != nil {
+			return fmt.Errorf("failed to delete versions after slot %d: %w", slot, err)
+		}
+		if err := tx.Model(&EntityVersion{}).
+			Where("slot_to > ?", slot).
+			Update("slot_to", nil).Error; err != nil {
+			return fmt.Errorf("failed to reopen versions after slot %d: %w", slot, err)
+		}
+
+		// Restore parents before children and delete children before parents,
+		// approximating creation order by the first version id.
+		sort.Slice(touched, func(i, j int) bool { return touched[i].FirstVID < touched[j].FirstVID })
+
+		var removed []touchedEntity
+		for _, t := range touched {
+			var data datatypes.JSON
+			if err := tx.Model(&EntityVersion{}).
+				Select("data").
+				Where("entity = ? AND entity_id = ? AND slot_to IS NULL", t.Entity, t.EntityID).
+				Limit(1).
+				Scan(&data).Error; err != nil {
+				return fmt.Errorf("failed to load version of %s %s: %w", t.Entity, t.EntityID, err)
+			}
+			if len(data) == 0 {
+				removed = append(removed, t)
+				continue
+			}
+			if err := restoreEntity(tx, t.Entity, data); err != nil {
+				return fmt.Errorf("failed to restore %s %s: %w", t.Entity, t.EntityID, err)
+			}
+		}
+
+		for i := len(removed) - 1; i >= 0; i-- {
+			t := removed[i]
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id::text = ?", tx.Statement.Quote(t.Entity)), t.EntityID).Error; err != nil {
+				return fmt.Errorf("failed to delete %s %s: %w", t.Entity, t.EntityID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// restoreEntity upserts a row of the given table from a version's column data.
+func restoreEntity(tx *gorm.DB, table string, data datatypes.JSON) error {
+	var columns map[string]json.RawMessage
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return err
+	}
+
+	assignments := make([]string, 0, len(columns))
+	for column := range columns {
+		if column == "id" {
+			continue
+		}
+		quoted := tx.Statement.Quote(column)
+		assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", quoted, quoted))
+	}
+	sort.Strings(assignments)
+
+	quotedTable := tx.Statement.Quote(table)
+	query := fmt.Sprintf(
+		"INSERT INTO %s SELECT (jsonb_populate_record(NULL::%s, ?::jsonb)).* ON CONFLICT (id) DO UPDATE SET %s",
+		quotedTable, quotedTable, strings.Join(assignments, ", "),
+	)
+	return tx.Exec(query, string(data)).Error
+}
@@ -3,7 +3,6 @@ package subgraph
 import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
-	"gorm.io/gorm"
 )
 
 type Account struct {
@@ -28,10 +27,10 @@ func (a *Account) GetID() string {
 	return a.ID
 }
 
-func (a *Account) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (a *Account) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, a)
 }
 
-func (a *Account) Save(ctx context.Context, db *gorm.DB) error {
+func (a *Account) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, a)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type AccountVaultPosition struct {
@@ -43,10 +42,15 @@ func (p *AccountVaultPosition) GetID() string {
 	return p.ID
 }
 
-func (p *AccountVaultPosition) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (p *AccountVaultPosition) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, p)
 }
 
-func (p *AccountVaultPosition) Save(ctx context.Context, db *gorm.DB) error {
+func (p *AccountVaultPosition) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, p)
 }
+
+// LoadAt loads the state of the account vault position as of the given slot (versioned storage mode only).
+func (p *AccountVaultPosition) LoadAt(ctx context.Context, db generic.VersionStore, slot uint64) (bool, error) {
+	return generic.LoadAt(ctx, db, p, slot)
+}
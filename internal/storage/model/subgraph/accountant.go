@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Accountant struct {
@@ -28,10 +27,10 @@ func (a *Accountant) GetID() string {
 	return a.ID
 }
 
-func (a *Accountant) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (a *Accountant) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, a)
 }
 
-func (a *Accountant) Save(ctx context.Context, db *gorm.DB) error {
+func (a *Accountant) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, a)
 }
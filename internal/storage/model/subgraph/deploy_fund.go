@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type DeployFunds struct {
@@ -30,10 +29,10 @@ func (d *DeployFunds) GetID() string {
 	return d.ID
 }
 
-func (d *DeployFunds) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (d *DeployFunds) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, d)
 }
 
-func (d *DeployFunds) Save(ctx context.Context, db *gorm.DB) error {
+func (d *DeployFunds) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, d)
 }
@@ -4,24 +4,23 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Deposit struct {
-	ID           string       `gorm:"primaryKey;column:id"`    // Transaction-Log
-	Timestamp    types.BigInt `gorm:"column:timestamp"`        // Timestamp of update (BigInt)
-	BlockNumber  types.BigInt `gorm:"column:block_number"`     // Block number of update (BigInt)
-	Account      *Account     `gorm:"foreignKey:AccountID"`    // Account making Deposit
-	AccountID    string       `gorm:"column:account_id"`       // Account ID
-	Vault        *Vault       `gorm:"foreignKey:VaultID"`      // Vault deposited into
-	VaultID      string       `gorm:"column:vault_id"`         // Vault ID
-	TokenAmount  types.BigInt `gorm:"column:token_amount"`     // Number of Tokens deposited into Vault (BigInt)
-	SharesMinted types.BigInt `gorm:"column:shares_minted"`    // Number of new Vault Shares minted (BigInt)
-	Token        *Token       `gorm:"foreignKey:TokenID"`      // Token this Vault will accrue
-	TokenID      string       `gorm:"column:token_id"`         // Token ID
-	ShareToken   *Token       `gorm:"foreignKey:ShareTokenID"` // Token representing Shares in the Vault
-	ShareTokenID string       `gorm:"column:share_token_id"`   // Share Token ID
-	SharePrice   types.BigInt `gorm:"column:share_price"`      // Share price (BigInt)
+	ID           string       `gorm:"primaryKey;column:id"`                                     // Transaction-Log
+	Timestamp    types.BigInt `gorm:"column:timestamp;index:idx_deposits_vault_ts,priority:2"`  // Timestamp of update (BigInt)
+	BlockNumber  types.BigInt `gorm:"column:block_number"`                                      // Block number of update (BigInt)
+	Account      *Account     `gorm:"foreignKey:AccountID"`                                     // Account making Deposit
+	AccountID    string       `gorm:"column:account_id;index:idx_deposits_vault_ts,priority:3"` // Account ID
+	Vault        *Vault       `gorm:"foreignKey:VaultID"`                                       // Vault deposited into
+	VaultID      string       `gorm:"column:vault_id;index:idx_deposits_vault_ts,priority:1"`   // Vault ID
+	TokenAmount  types.BigInt `gorm:"column:token_amount"`                                      // Number of Tokens deposited into Vault (BigInt)
+	SharesMinted types.BigInt `gorm:"column:shares_minted"`                                     // Number of new Vault Shares minted (BigInt)
+	Token        *Token       `gorm:"foreignKey:TokenID"`                                       // Token this Vault will accrue
+	TokenID      string       `gorm:"column:token_id"`                                          // Token ID
+	ShareToken   *Token       `gorm:"foreignKey:ShareTokenID"`                                  // Token representing Shares in the Vault
+	ShareTokenID string       `gorm:"column:share_token_id"`                                    // Share Token ID
+	SharePrice   types.BigInt `gorm:"column:share_price"`                                       // Share price (BigInt)
 }
 
 func (Deposit) TableName() string {
@@ -48,10 +47,10 @@ func (d *Deposit) GetID() string {
 	return d.ID
 }
 
-func (d *Deposit) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (d *Deposit) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, d)
 }
 
-func (d *Deposit) Save(ctx context.Context, db *gorm.DB) error {
+func (d *Deposit) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, d)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type DTFReport struct {
@@ -26,10 +25,10 @@ func (d *DTFReport) GetID() string {
 	return d.ID
 }
 
-func (d *DTFReport) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (d *DTFReport) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, d)
 }
 
-func (d *DTFReport) Save(ctx context.Context, db *gorm.DB) error {
+func (d *DTFReport) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, d)
 }
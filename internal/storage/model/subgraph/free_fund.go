@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type FreeFunds struct {
@@ -30,10 +29,10 @@ func (f *FreeFunds) GetID() string {
 	return f.ID
 }
 
-func (f *FreeFunds) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (f *FreeFunds) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, f)
 }
 
-func (f *FreeFunds) Save(ctx context.Context, db *gorm.DB) error {
+func (f *FreeFunds) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, f)
 }
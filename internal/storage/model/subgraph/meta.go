@@ -3,8 +3,6 @@ package subgraph
 import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -20,13 +18,8 @@ func (BlockInfo) TableName() string {
 	return "_block_info"
 }
 
-func (b *BlockInfo) Save(ctx context.Context, db *gorm.DB) error {
-	return db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "id"}},
-			UpdateAll: true,
-		}).
-		Create(b).Error
+func (b *BlockInfo) Save(ctx context.Context, db generic.EntityStore) error {
+	return generic.Save(ctx, db, b)
 }
 
 type Meta struct {
@@ -44,6 +37,6 @@ func (*Meta) TableName() string {
 	return "_meta"
 }
 
-func (m *Meta) Save(ctx context.Context, db *gorm.DB) error {
+func (m *Meta) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, m)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type ShareToken struct {
@@ -37,10 +36,10 @@ func (s *ShareToken) GetID() string {
 	return s.ID
 }
 
-func (s *ShareToken) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *ShareToken) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *ShareToken) Save(ctx context.Context, db *gorm.DB) error {
+func (s *ShareToken) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
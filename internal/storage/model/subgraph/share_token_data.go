@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type ShareTokenData struct {
@@ -30,10 +29,10 @@ func (s *ShareTokenData) GetID() string {
 	return s.ID
 }
 
-func (s *ShareTokenData) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *ShareTokenData) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *ShareTokenData) Save(ctx context.Context, db *gorm.DB) error {
+func (s *ShareTokenData) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
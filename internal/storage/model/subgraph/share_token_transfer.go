@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type ShareTokenTransfer struct {
@@ -36,10 +35,10 @@ func (s *ShareTokenTransfer) GetID() string {
 	return s.ID
 }
 
-func (s *ShareTokenTransfer) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *ShareTokenTransfer) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *ShareTokenTransfer) Save(ctx context.Context, db *gorm.DB) error {
+func (s *ShareTokenTransfer) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
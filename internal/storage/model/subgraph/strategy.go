@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Strategy struct {
@@ -79,10 +78,15 @@ func (s *Strategy) GetID() string {
 	return s.ID
 }
 
-func (s *Strategy) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *Strategy) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *Strategy) Save(ctx context.Context, db *gorm.DB) error {
+func (s *Strategy) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
+
+// LoadAt loads the state of the strategy as of the given slot (versioned storage mode only).
+func (s *Strategy) LoadAt(ctx context.Context, db generic.VersionStore, slot uint64) (bool, error) {
+	return generic.LoadAt(ctx, db, s, slot)
+}
@@ -0,0 +1,48 @@
+package subgraph
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+)
+
+type StrategyDayData struct {
+	ID          string           `gorm:"primaryKey;column:id"`     // Strategy-Day ID
+	Strategy    *Strategy        `gorm:"foreignKey:StrategyID"`    // Strategy
+	StrategyID  string           `gorm:"column:strategy_id;index"` // Strategy ID
+	VaultID     string           `gorm:"column:vault_id"`          // Vault ID
+	Timestamp   types.BigInt     `gorm:"column:timestamp;index"`   // Bucket start timestamp (BigInt)
+	CurrentDebt types.BigInt     `gorm:"column:current_debt"`      // Current debt at the end of the day (BigInt)
+	TotalAssets types.BigInt     `gorm:"column:total_assets"`      // Total assets at the end of the day (BigInt)
+	Gain        types.BigInt     `gorm:"column:gain"`              // Gain reported during the day (BigInt)
+	Loss        types.BigInt     `gorm:"column:loss"`              // Loss reported during the day (BigInt)
+	Apr         types.BigDecimal `gorm:"column:apr"`               // APR at the end of the day (BigDecimal)
+}
+
+func (StrategyDayData) TableName() string {
+	return "strategy_day_data"
+}
+
+func (d *StrategyDayData) Init() {
+	d.Strategy = nil
+	d.StrategyID = ""
+	d.VaultID = ""
+	d.Timestamp.Zero()
+	d.CurrentDebt.Zero()
+	d.TotalAssets.Zero()
+	d.Gain.Zero()
+	d.Loss.Zero()
+	d.Apr.Zero()
+}
+
+func (d *StrategyDayData) GetID() string {
+	return d.ID
+}
+
+func (d *StrategyDayData) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
+	return generic.Load(ctx, db, d)
+}
+
+func (d *StrategyDayData) Save(ctx context.Context, db generic.EntityStore) error {
+	return generic.Save(ctx, db, d)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	types2 "github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type StrategyHistoricalApr struct {
@@ -31,10 +30,10 @@ func (s *StrategyHistoricalApr) GetID() string {
 	return s.ID
 }
 
-func (s *StrategyHistoricalApr) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *StrategyHistoricalApr) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *StrategyHistoricalApr) Save(ctx context.Context, db *gorm.DB) error {
+func (s *StrategyHistoricalApr) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type StrategyReport struct {
@@ -48,10 +47,10 @@ func (s *StrategyReport) GetID() string {
 	return s.ID
 }
 
-func (s *StrategyReport) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *StrategyReport) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *StrategyReport) Save(ctx context.Context, db *gorm.DB) error {
+func (s *StrategyReport) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type StrategyReportEvent struct {
@@ -34,10 +33,10 @@ func (s *StrategyReportEvent) GetID() string {
 	return s.ID
 }
 
-func (s *StrategyReportEvent) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *StrategyReportEvent) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *StrategyReportEvent) Save(ctx context.Context, db *gorm.DB) error {
+func (s *StrategyReportEvent) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
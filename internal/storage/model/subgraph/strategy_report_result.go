@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type StrategyReportResult struct {
@@ -46,10 +45,10 @@ func (s *StrategyReportResult) GetID() string {
 	return s.ID
 }
 
-func (s *StrategyReportResult) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (s *StrategyReportResult) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, s)
 }
 
-func (s *StrategyReportResult) Save(ctx context.Context, db *gorm.DB) error {
+func (s *StrategyReportResult) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, s)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Token struct {
@@ -30,10 +29,10 @@ func (t *Token) GetID() string {
 	return t.ID
 }
 
-func (t *Token) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (t *Token) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, t)
 }
 
-func (t *Token) Save(ctx context.Context, db *gorm.DB) error {
+func (t *Token) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, t)
 }
@@ -3,7 +3,6 @@ package subgraph
 import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
-	"gorm.io/gorm"
 )
 
 type TokenAccount struct {
@@ -25,10 +24,10 @@ func (t *TokenAccount) GetID() string {
 	return t.ID
 }
 
-func (t *TokenAccount) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (t *TokenAccount) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, t)
 }
 
-func (t *TokenAccount) Save(ctx context.Context, db *gorm.DB) error {
+func (t *TokenAccount) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, t)
 }
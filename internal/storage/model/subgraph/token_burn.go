@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type TokenBurn struct {
@@ -31,10 +30,10 @@ func (t *TokenBurn) GetID() string {
 	return t.ID
 }
 
-func (t *TokenBurn) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (t *TokenBurn) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, t)
 }
 
-func (t *TokenBurn) Save(ctx context.Context, db *gorm.DB) error {
+func (t *TokenBurn) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, t)
 }
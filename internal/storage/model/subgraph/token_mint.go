@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type TokenMint struct {
@@ -31,10 +30,10 @@ func (t *TokenMint) GetID() string {
 	return t.ID
 }
 
-func (t *TokenMint) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (t *TokenMint) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, t)
 }
 
-func (t *TokenMint) Save(ctx context.Context, db *gorm.DB) error {
+func (t *TokenMint) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, t)
 }
@@ -3,7 +3,6 @@ package subgraph
 import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
-	"gorm.io/gorm"
 )
 
 type TokenWallet struct {
@@ -25,10 +24,10 @@ func (t *TokenWallet) GetID() string {
 	return t.ID
 }
 
-func (t *TokenWallet) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (t *TokenWallet) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, t)
 }
 
-func (t *TokenWallet) Save(ctx context.Context, db *gorm.DB) error {
+func (t *TokenWallet) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, t)
 }
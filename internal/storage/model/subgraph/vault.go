@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Vault struct {
@@ -102,7 +101,7 @@ func (v *Vault) GetID() string {
 	return v.ID
 }
 
-func (v *Vault) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (v *Vault) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.LoadWithPreloads(ctx, db, v,
 		"Strategies",
 		"Deposits",
@@ -112,15 +111,20 @@ func (v *Vault) Load(ctx context.Context, db *gorm.DB) (bool, error) {
 	)
 }
 
-func (v *Vault) Save(ctx context.Context, db *gorm.DB) error {
+func (v *Vault) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, v)
 }
 
+// LoadAt loads the state of the vault as of the given slot (versioned storage mode only).
+func (v *Vault) LoadAt(ctx context.Context, db generic.VersionStore, slot uint64) (bool, error) {
+	return generic.LoadAt(ctx, db, v, slot)
+}
+
 // GetShareTokenMints returns a list of share token mints from the database.
-func GetShareTokenMints(ctx context.Context, db *gorm.DB) ([]string, error) {
+func GetShareTokenMints(ctx context.Context, db generic.EntityStore) ([]string, error) {
 	var vaults []Vault
 
-	if err := db.WithContext(ctx).Select("share_token_id").Find(&vaults).Error; err != nil {
+	if err := db.FindEntities(ctx, &vaults); err != nil {
 		return nil, fmt.Errorf("failed to fetch share token mints: %w", err)
 	}
 
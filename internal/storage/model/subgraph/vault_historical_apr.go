@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type VaultHistoricalApr struct {
@@ -30,10 +29,10 @@ func (v *VaultHistoricalApr) GetID() string {
 	return v.ID
 }
 
-func (v *VaultHistoricalApr) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (v *VaultHistoricalApr) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, v)
 }
 
-func (v *VaultHistoricalApr) Save(ctx context.Context, db *gorm.DB) error {
+func (v *VaultHistoricalApr) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, v)
 }
@@ -0,0 +1,90 @@
+package subgraph
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+)
+
+// VaultSnapshot holds the per-bucket vault metrics shared by VaultHourData and VaultDayData.
+type VaultSnapshot struct {
+	VaultID          string       `gorm:"column:vault_id;index"`    // Vault ID
+	Timestamp        types.BigInt `gorm:"column:timestamp;index"`   // Bucket start timestamp (BigInt)
+	TotalDebt        types.BigInt `gorm:"column:total_debt"`        // Total debt at the end of the bucket (BigInt)
+	TotalIdle        types.BigInt `gorm:"column:total_idle"`        // Total idle at the end of the bucket (BigInt)
+	TotalShare       types.BigInt `gorm:"column:total_share"`       // Total shares at the end of the bucket (BigInt)
+	SharePrice       types.BigInt `gorm:"column:share_price"`       // Share price at the end of the bucket (BigInt)
+	DepositVolume    types.BigInt `gorm:"column:deposit_volume"`    // Tokens deposited during the bucket (BigInt)
+	WithdrawVolume   types.BigInt `gorm:"column:withdraw_volume"`   // Tokens withdrawn during the bucket (BigInt)
+	DepositCount     types.BigInt `gorm:"column:deposit_count"`     // Number of deposits during the bucket (BigInt)
+	WithdrawCount    types.BigInt `gorm:"column:withdraw_count"`    // Number of withdrawals during the bucket (BigInt)
+	UniqueDepositors types.BigInt `gorm:"column:unique_depositors"` // Distinct depositing accounts during the bucket (BigInt)
+}
+
+func (s *VaultSnapshot) initSnapshot() {
+	s.VaultID = ""
+	s.Timestamp.Zero()
+	s.TotalDebt.Zero()
+	s.TotalIdle.Zero()
+	s.TotalShare.Zero()
+	s.SharePrice.Zero()
+	s.DepositVolume.Zero()
+	s.WithdrawVolume.Zero()
+	s.DepositCount.Zero()
+	s.WithdrawCount.Zero()
+	s.UniqueDepositors.Zero()
+}
+
+type VaultHourData struct {
+	ID            string `gorm:"primaryKey;column:id"` // Vault-Hour ID
+	Vault         *Vault `gorm:"foreignKey:VaultID"`   // Vault
+	VaultSnapshot `gorm:"embedded"`
+}
+
+func (VaultHourData) TableName() string {
+	return "vault_hour_data"
+}
+
+func (d *VaultHourData) Init() {
+	d.Vault = nil
+	d.initSnapshot()
+}
+
+func (d *VaultHourData) GetID() string {
+	return d.ID
+}
+
+func (d *VaultHourData) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
+	return generic.Load(ctx, db, d)
+}
+
+func (d *VaultHourData) Save(ctx context.Context, db generic.EntityStore) error {
+	return generic.Save(ctx, db, d)
+}
+
+type VaultDayData struct {
+	ID            string `gorm:"primaryKey;column:id"` // Vault-Day ID
+	Vault         *Vault `gorm:"foreignKey:VaultID"`   // Vault
+	VaultSnapshot `gorm:"embedded"`
+}
+
+func (VaultDayData) TableName() string {
+	return "vault_day_data"
+}
+
+func (d *VaultDayData) Init() {
+	d.Vault = nil
+	d.initSnapshot()
+}
+
+func (d *VaultDayData) GetID() string {
+	return d.ID
+}
+
+func (d *VaultDayData) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
+	return generic.Load(ctx, db, d)
+}
+
+func (d *VaultDayData) Save(ctx context.Context, db generic.EntityStore) error {
+	return generic.Save(ctx, db, d)
+}
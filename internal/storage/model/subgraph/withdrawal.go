@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type Withdrawal struct {
@@ -54,10 +53,10 @@ func (w *Withdrawal) GetID() string {
 	return w.ID
 }
 
-func (w *Withdrawal) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (w *Withdrawal) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, w)
 }
 
-func (w *Withdrawal) Save(ctx context.Context, db *gorm.DB) error {
+func (w *Withdrawal) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, w)
 }
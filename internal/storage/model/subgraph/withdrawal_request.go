@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
-	"gorm.io/gorm"
 )
 
 type WithdrawalRequest struct {
@@ -50,10 +49,10 @@ func (w *WithdrawalRequest) GetID() string {
 	return w.ID
 }
 
-func (w *WithdrawalRequest) Load(ctx context.Context, db *gorm.DB) (bool, error) {
+func (w *WithdrawalRequest) Load(ctx context.Context, db generic.EntityStore) (bool, error) {
 	return generic.Load(ctx, db, w)
 }
 
-func (w *WithdrawalRequest) Save(ctx context.Context, db *gorm.DB) error {
+func (w *WithdrawalRequest) Save(ctx context.Context, db generic.EntityStore) error {
 	return generic.Save(ctx, db, w)
 }
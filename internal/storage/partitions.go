@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// partitioned lists the core tables partitioned by slot.
+var partitioned = []string{"transactions", "events"}
+
+// CreatePartitions creates the missing slot partitions of the core tables, up to one partition past
+// upto or the highest stored slot, and moves the rows that landed in a default partition into theirs.
+// It returns how many partitions it created.
+func (g *Gorm) CreatePartitions(ctx context.Context, upto uint64) (int, error) {
+	total := 0
+	for _, table := range partitioned {
+		var created int
+		if err := g.DB.WithContext(ctx).
+			Raw("SELECT core.create_slot_partitions(?, ?)", table, upto).
+			Scan(&created).Error; err != nil {
+			return total, fmt.Errorf("failed to create partitions of core.%s: %w", table, err)
+		}
+		total += created
+	}
+	return total, nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+)
+
+// Pending transactions form the durable real-time queue: signatures received from a subscription are
+// stored right away, then fetched and parsed in block order, and leave the queue once parsed.
+
+// EnqueuePending stores a signature received in real time as pending and associates it with its program.
+// The block index orders it within its slot and is nil if not known yet.
+// A transaction that is already stored is only associated.
+func (g *Gorm) EnqueuePending(ctx context.Context, signature string, slot uint64, blockIndex *uint32, programID string) error {
+	transaction := core.Transaction{
+		Signature:  signature,
+		Slot:       slot,
+		BlockIndex: blockIndex,
+		Pending:    true,
+	}
+	if err := g.SaveTransaction(ctx, &transaction, programID); err != nil {
+		return fmt.Errorf("failed to enqueue %s: %w", signature, err)
+	}
+	return nil
+}
+
+// EnqueueFetched stores a transaction received in real time together with its raw payload as pending,
+// ready to be parsed without fetching it.
+func (g *Gorm) EnqueueFetched(ctx context.Context, signature string, slot uint64, blockIndex *uint32, blockTime int64, raw []byte, programID string) error {
+	if err := g.EnqueuePending(ctx, signature, slot, blockIndex, programID); err != nil {
+		return err
+	}
+	if err := g.UpdateTransactionRaw(ctx, signature, slot, blockTime, raw); err != nil {
+		return fmt.Errorf("failed to enqueue %s: %w", signature, err)
+	}
+	return nil
+}
+
+// SetBlockIndex stores the position of a transaction in its block.
+func (g *Gorm) SetBlockIndex(ctx context.Context, signature string, blockIndex uint32) error {
+	if err := whereSignature(g.DB.WithContext(ctx).Model(&core.Transaction{}), signature).
+		Update("block_index", blockIndex).Error; err != nil {
+		return fmt.Errorf("failed to set block index of %s: %w", signature, err)
+	}
+	return nil
+}
+
+// GetPendingUnfetched returns the signature, slot and block index of up to limit pending transactions
+// without a raw transaction, in block order.
+func (g *Gorm) GetPendingUnfetched(ctx context.Context, limit int) ([]core.Transaction, error) {
+	var txs []core.Transaction
+	if err := g.DB.WithContext(ctx).
+		Model(&core.Transaction{}).
+		Select("signature, slot, block_index").
+		Where("pending AND NOT parsed AND " + missingRaw).
+		Order("slot ASC, block_index ASC NULLS LAST, signature ASC").
+		Limit(limit).
+		Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load pending transactions to fetch: %w", err)
+	}
+	return txs, nil
+}
+
+// GetPendingFetched returns up to limit pending signatures that are ready to be parsed, in block order.
+// It stops below the lowest pending transaction that is not fetched yet, so the real-time path
+// never parses out of order.
+func (g *Gorm) GetPendingFetched(ctx context.Context, limit int) ([]string, error) {
+	var signatures []string
+	if err := g.DB.WithContext(ctx).
+		Raw(`
+		SELECT signature
+		FROM core.transactions
+		WHERE pending AND NOT parsed AND `+hasRaw+`
+		  AND slot < COALESCE((
+		      SELECT MIN(slot) FROM core.transactions
+		      WHERE pending AND NOT parsed AND `+missingRaw+`
+		  ), 9223372036854775807)
+		ORDER BY slot ASC, block_index ASC NULLS LAST, signature ASC
+		LIMIT ?
+	`, limit).
+		Scan(&signatures).Error; err != nil {
+		return nil, fmt.Errorf("failed to load pending transactions to parse: %w", err)
+	}
+	return signatures, nil
+}
@@ -2,10 +2,12 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
 	"gorm.io/gorm/clause"
 )
 
+// SaveProgram registers a program with the default settings unless it is already known.
 func (g *Gorm) SaveProgram(ctx context.Context, address string) error {
 	return g.DB.WithContext(ctx).
 		Clauses(clause.OnConflict{DoNothing: true}).
@@ -13,3 +15,85 @@ func (g *Gorm) SaveProgram(ctx context.Context, address string) error {
 			ID: address,
 		}).Error
 }
+
+// UpsertProgram stores a program with its settings and marks it active.
+func (g *Gorm) UpsertProgram(ctx context.Context, program core.Program) error {
+	program.Active = true
+	err := g.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"idl", "start_slot", "commitment", "decode_instructions", "mapper", "fetch_mode", "active", "updated_at",
+			}),
+		}).
+		Select("*").
+		Omit("Txns").
+		Create(&program).Error
+	if err != nil {
+		return fmt.Errorf("failed to save program %s: %w", program.ID, err)
+	}
+	return nil
+}
+
+// GetActivePrograms returns the programs that are indexed, ordered by address.
+func (g *Gorm) GetActivePrograms(ctx context.Context) ([]core.Program, error) {
+	var programs []core.Program
+	if err := g.DB.WithContext(ctx).
+		Where("active").
+		Order("id").
+		Find(&programs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch active programs: %w", err)
+	}
+	return programs, nil
+}
+
+// DeactivateProgram stops indexing a program and reports whether it was active.
+// Its transactions and events are kept.
+func (g *Gorm) DeactivateProgram(ctx context.Context, address string) (bool, error) {
+	res := g.DB.WithContext(ctx).
+		Model(&core.Program{}).
+		Where("id = ? AND active", address).
+		Update("active", false)
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to deactivate program %s: %w", address, res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ProgramProgress summarizes what has been indexed for a program.
+type ProgramProgress struct {
+	ProgramID       string `gorm:"column:program_id" json:"program_id"`
+	LatestSignature string `gorm:"column:latest_signature" json:"latest_signature"`
+	LatestSlot      uint64 `gorm:"column:latest_slot" json:"latest_slot"`
+	Transactions    int64  `gorm:"column:transactions" json:"transactions"`
+	Unparsed        int64  `gorm:"column:unparsed" json:"unparsed"`
+}
+
+// GetProgramProgress returns the indexing progress of every known program.
+func (g *Gorm) GetProgramProgress(ctx context.Context) ([]ProgramProgress, error) {
+	query := `
+		SELECT
+			p.id AS program_id,
+			COALESCE((
+				SELECT t2.signature
+				FROM core.transactions t2
+				JOIN core.program_transactions pt2 ON pt2.transaction_signature = t2.signature
+				WHERE pt2.program_id = p.id
+				ORDER BY t2.slot DESC, t2.block_time DESC
+				LIMIT 1
+			), '') AS latest_signature,
+			COALESCE(MAX(t.slot), 0) AS latest_slot,
+			COUNT(t.signature) AS transactions,
+			COUNT(t.signature) FILTER (WHERE NOT t.parsed) AS unparsed
+		FROM core.programs p
+		LEFT JOIN core.program_transactions pt ON pt.program_id = p.id
+		LEFT JOIN core.transactions t ON t.signature = pt.transaction_signature
+		GROUP BY p.id
+		ORDER BY p.id`
+
+	var progress []ProgramProgress
+	if err := g.DB.WithContext(ctx).Raw(query).Scan(&progress).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch program progress: %w", err)
+	}
+	return progress, nil
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/klauspost/compress/zstd"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// The raw payload of a transaction is stored in the configured format and read back from whichever
+// column holds it, so changing the format only affects payloads stored afterwards.
+
+// hasRaw matches transactions with a stored raw payload, in any format.
+const hasRaw = "(json_tx IS NOT NULL OR raw_zstd IS NOT NULL OR raw_object IS NOT NULL)"
+
+// missingRaw matches transactions whose raw payload was never stored. Pruned payloads are not
+// missing, they are fetched again on demand.
+const missingRaw = "(json_tx IS NULL AND raw_zstd IS NULL AND raw_object IS NULL AND raw_pruned_at IS NULL)"
+
+var (
+	encoder, _ = zstd.NewWriter(nil) // safe for concurrent EncodeAll
+	decoder, _ = zstd.NewReader(nil) // safe for concurrent DecodeAll
+)
+
+// rawColumns returns the column values storing a raw payload in the configured format; the columns
+// of the other formats are cleared.
+func rawColumns(signature string, raw []byte) (map[string]interface{}, error) {
+	columns := map[string]interface{}{
+		"json_tx":       nil,
+		"raw_zstd":      nil,
+		"raw_object":    nil,
+		"raw_pruned_at": nil,
+	}
+	switch config.App.RawStorage.Format {
+	case config.RawZstd:
+		columns["raw_zstd"] = encoder.EncodeAll(raw, nil)
+	case config.RawDirectory:
+		key, err := writeObject(signature, raw)
+		if err != nil {
+			return nil, err
+		}
+		columns["raw_object"] = key
+	default:
+		columns["json_tx"] = datatypes.JSON(raw)
+	}
+	return columns, nil
+}
+
+// encodeRaw moves the JsonTx of a transaction about to be inserted into the configured format.
+func encodeRaw(tx *core.Transaction) error {
+	if len(tx.JsonTx) == 0 {
+		return nil
+	}
+	switch config.App.RawStorage.Format {
+	case config.RawZstd:
+		tx.RawZstd = encoder.EncodeAll(tx.JsonTx, nil)
+	case config.RawDirectory:
+		key, err := writeObject(tx.Signature, tx.JsonTx)
+		if err != nil {
+			return err
+		}
+		tx.RawObject = &key
+	default:
+		return nil
+	}
+	tx.JsonTx = nil
+	return nil
+}
+
+// decodeRaw returns the raw payload of a loaded transaction, or nil if it has none.
+func decodeRaw(tx *core.Transaction) ([]byte, error) {
+	switch {
+	case len(tx.JsonTx) > 0:
+		return tx.JsonTx, nil
+	case tx.RawZstd != nil:
+		raw, err := decoder.DecodeAll(tx.RawZstd, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress raw transaction %s: %w", tx.Signature, err)
+		}
+		return raw, nil
+	case tx.RawObject != nil:
+		return readObject(*tx.RawObject)
+	}
+	return nil, nil
+}
+
+// objectKey returns the key of a raw payload in the object store; payloads are spread over
+// directories by the first characters of their signature.
+func objectKey(signature string) string {
+	return path.Join(signature[:min(2, len(signature))], signature+".json.zst")
+}
+
+// writeObject stores a compressed raw payload in the object store and returns its key.
+// The file is renamed into place, so a reader never sees it half written.
+func writeObject(signature string, raw []byte) (string, error) {
+	key := objectKey(signature)
+	name := filepath.Join(config.App.RawStorage.Directory, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create object %s: %w", key, err)
+	}
+	_, err = f.Write(encoder.EncodeAll(raw, nil))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write object %s: %w", key, err)
+	}
+	return key, nil
+}
+
+// readObject returns the raw payload stored under a key of the object store.
+func readObject(key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(config.App.RawStorage.Directory, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	raw, err := decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress object %s: %w", key, err)
+	}
+	return raw, nil
+}
+
+// PruneRawTransactions removes the raw payloads of up to limit parsed and finalized transactions with
+// a block time before the cutoff, oldest first, and returns how many were pruned. Signature, slot and
+// block time are kept, so a pruned payload can be fetched again for a reparse.
+func (g *Gorm) PruneRawTransactions(ctx context.Context, before int64, limit int) (int, error) {
+	var txs []core.Transaction
+	err := g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := db.Model(&core.Transaction{}).
+			Select("signature, raw_object").
+			Where("parsed AND finalized AND NOT pending AND block_time < ? AND "+hasRaw, before).
+			Order("block_time ASC").
+			Limit(limit).
+			Find(&txs).Error; err != nil {
+			return fmt.Errorf("failed to load transactions to prune: %w", err)
+		}
+		if len(txs) == 0 {
+			return nil
+		}
+
+		signatures := make([]string, len(txs))
+		for i, tx := range txs {
+			signatures[i] = tx.Signature
+		}
+		if err := whereSignatures(db.Model(&core.Transaction{}), signatures).
+			Updates(map[string]interface{}{
+				"json_tx":       nil,
+				"raw_zstd":      nil,
+				"raw_object":    nil,
+				"raw_pruned_at": time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to prune raw transactions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Objects are removed once no row refers to them anymore
+	var errs []error
+	for _, tx := range txs {
+		if tx.RawObject == nil {
+			continue
+		}
+		name := filepath.Join(config.App.RawStorage.Directory, filepath.FromSlash(*tx.RawObject))
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to remove object %s: %w", *tx.RawObject, err))
+		}
+	}
+	return len(txs), errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"gorm.io/gorm"
+)
+
+// The stores are the narrow views of the storage that the parsing, mapping and health checking
+// logic depends on. Gorm implements them on Postgres, memory.Store in memory for tests.
+
+// TransactionStore keeps the fetched transactions, their parse state and the address lookup tables
+// needed to decode them.
+type TransactionStore interface {
+	SaveTransaction(ctx context.Context, tx *core.Transaction, programID string) error
+	GetTransaction(ctx context.Context, signature string) (*core.Transaction, error)
+	GetRawTransaction(ctx context.Context, signature string) ([]byte, error)
+	GetOrderedNoParsedSignatures(ctx context.Context, resume bool) ([]string, error)
+	MarkParsed(ctx context.Context, signature string) error
+	IsParsed(ctx context.Context, signature string) (bool, error)
+	SaveLookupTable(ctx context.Context, lut *core.LookupTable) error
+	GetLookupTable(ctx context.Context, address string) (*core.LookupTable, error)
+}
+
+// EventStore keeps the events decoded from transactions.
+type EventStore interface {
+	SaveEvents(ctx context.Context, events []core.Event) error
+	HasEvents(ctx context.Context, signature string) (bool, error)
+	LoadEventsBySlotCursor(ctx context.Context, fromSlot uint64, slotCount int) ([]core.Event, error)
+}
+
+// EntityStore keeps the subgraph entities.
+type EntityStore = generic.EntityStore
+
+// VersionStore reads the recorded versions of the subgraph entities.
+type VersionStore = generic.VersionStore
+
+// ParseStore is the storage that parsing a stored transaction and mapping its events needs.
+type ParseStore interface {
+	TransactionStore
+	EventStore
+	EntityStore
+	// Atomically runs fn with a view of the store whose writes are applied together if fn returns
+	// nil and discarded if it returns an error.
+	Atomically(ctx context.Context, fn func(tx ParseStore) error) error
+}
+
+// HealthStore keeps the health status of the indexer.
+type HealthStore interface {
+	SetHealth(ctx context.Context, status, reason string) error
+	GetHealth(ctx context.Context) (string, string, error)
+}
+
+var (
+	_ TransactionStore = (*Gorm)(nil)
+	_ EventStore       = (*Gorm)(nil)
+	_ EntityStore      = (*Gorm)(nil)
+	_ VersionStore     = (*Gorm)(nil)
+	_ HealthStore      = (*Gorm)(nil)
+	_ ParseStore       = (*Gorm)(nil)
+)
+
+// Atomically runs fn in one database transaction. Entity saves are buffered and written as one
+// upsert per table when fn returns or before entities are read from the database.
+func (g *Gorm) Atomically(ctx context.Context, fn func(tx ParseStore) error) error {
+	if err := g.flushEntities(ctx); err != nil {
+		return err
+	}
+	return g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		tx := &Gorm{DB: db, entities: &entityBatch{}}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.flushEntities(ctx)
+	})
+}
+
+// flushEntities writes the buffered entity saves, if any.
+func (g *Gorm) flushEntities(ctx context.Context) error {
+	if g.entities == nil {
+		return nil
+	}
+	return g.entities.flush(ctx, g.DB)
+}
+
+// LoadEntity loads a subgraph entity with the given relations.
+func (g *Gorm) LoadEntity(ctx context.Context, model generic.Identifiable, preloads ...string) (bool, error) {
+	if g.entities != nil && len(preloads) == 0 {
+		if ok, err := g.entities.load(ctx, g.DB, model); ok || err != nil {
+			return ok, err
+		}
+	}
+	if err := g.flushEntities(ctx); err != nil {
+		return false, err
+	}
+	return generic.LoadFromDB(ctx, g.DB, model, preloads...)
+}
+
+// SaveEntity upserts a subgraph entity by id.
+func (g *Gorm) SaveEntity(ctx context.Context, model any) error {
+	if g.entities != nil {
+		return g.entities.save(ctx, g.DB, model)
+	}
+	return generic.SaveToDB(ctx, g.DB, model)
+}
+
+// FindEntities loads the subgraph entities matching the conditions into dest.
+func (g *Gorm) FindEntities(ctx context.Context, dest any, conds ...generic.Cond) error {
+	if err := g.flushEntities(ctx); err != nil {
+		return err
+	}
+	return generic.FindInDB(ctx, g.DB, dest, conds...)
+}
+
+// LoadEntityAt loads the state a subgraph entity had at the slot from its versions.
+func (g *Gorm) LoadEntityAt(ctx context.Context, model generic.Identifiable, slot uint64) (bool, error) {
+	if err := g.flushEntities(ctx); err != nil {
+		return false, err
+	}
+	return generic.LoadAtFromDB(ctx, g.DB, model, slot)
+}
+
+// CountEntities counts the distinct values of a column among the matching subgraph entities.
+func (g *Gorm) CountEntities(ctx context.Context, model any, distinct string, conds ...generic.Cond) (int64, error) {
+	if err := g.flushEntities(ctx); err != nil {
+		return 0, err
+	}
+	return generic.CountInDB(ctx, g.DB, model, distinct, conds...)
+}
@@ -2,43 +2,246 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/Tsisar/solana-indexer/internal/config"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
-	"gorm.io/datatypes"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"slices"
 )
 
 // SaveTransaction inserts a transaction if it doesn't exist, and associates it with a program via M2M.
 // It uses ON CONFLICT DO NOTHING to avoid duplicates.
 func (g *Gorm) SaveTransaction(ctx context.Context, tx *core.Transaction, programID string) error {
-	// Insert the transaction if not already present
-	if err := g.DB.WithContext(ctx).
-		Clauses(clause.OnConflict{DoNothing: true}).
-		Create(tx).Error; err != nil {
-		return fmt.Errorf("failed to insert transaction: %w", err)
+	if err := encodeRaw(tx); err != nil {
+		return err
 	}
 
-	// Load the program by ID
-	var prog core.Program
-	if err := g.DB.WithContext(ctx).
-		First(&prog, "id = ?", programID).Error; err != nil {
-		return fmt.Errorf("program not found: %w", err)
+	return g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		// Insert the transaction if not already present
+		txs, err := claimSignatures(db, []core.Transaction{*tx})
+		if err != nil {
+			return err
+		}
+		if len(txs) > 0 {
+			if err := db.Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(tx).Error; err != nil {
+				return fmt.Errorf("failed to insert transaction: %w", err)
+			}
+		}
+		if err := linkPrograms(db, []core.Transaction{*tx}, map[string][]string{tx.Signature: {programID}}); err != nil {
+			return fmt.Errorf("failed to associate transaction with program: %w", err)
+		}
+		return nil
+	})
+}
+
+// SaveSignatures inserts the transactions of a program's signatures that don't exist yet and links
+// them all to the program, and returns how many were new. Rows are streamed with COPY into a staging
+// table and merged from there, in batches of signatureBatch per database transaction.
+func (g *Gorm) SaveSignatures(ctx context.Context, txs []core.Transaction, programID string) (int64, error) {
+	if len(txs) == 0 {
+		return 0, nil
+	}
+	sqlDB, err := g.DB.DB()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
 
-	// Associate transaction with the program (many-to-many)
-	if err := g.DB.WithContext(ctx).
-		Model(&core.Transaction{Signature: tx.Signature}).
-		Association("Programs").
-		Append(&prog); err != nil {
-		return fmt.Errorf("failed to associate transaction with program: %w", err)
+	var inserted int64
+	err = conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		for start := 0; start < len(txs); start += signatureBatch {
+			n, err := copySignatures(ctx, c.Conn(), txs[start:min(start+signatureBatch, len(txs))], programID)
+			if err != nil {
+				return err
+			}
+			inserted += n
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+// signatureBatch is the number of signatures merged per database transaction by SaveSignatures.
+const signatureBatch = 50_000
+
+// copySignatures merges one batch of SaveSignatures.
+func copySignatures(ctx context.Context, conn *pgx.Conn, txs []core.Transaction, programID string) (int64, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // a no-op after commit
+
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE signatures_staging (
+			signature  text,
+			slot       bigint,
+			block_time bigint,
+			finalized  boolean
+		) ON COMMIT DROP`); err != nil {
+		return 0, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	rows := make([][]any, len(txs))
+	for i, t := range txs {
+		rows[i] = []any{t.Signature, int64(t.Slot), t.BlockTime, t.Finalized}
+	}
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"signatures_staging"},
+		[]string{"signature", "slot", "block_time", "finalized"},
+		pgx.CopyFromRows(rows),
+	); err != nil {
+		return 0, fmt.Errorf("failed to copy signatures: %w", err)
+	}
+
+	// Signatures are claimed first, see claimSignatures
+	res, err := tx.Exec(ctx, `
+		WITH claimed AS (
+			INSERT INTO core.transaction_signatures (signature, slot)
+			SELECT DISTINCT ON (signature) signature, slot FROM signatures_staging ORDER BY signature
+			ON CONFLICT DO NOTHING
+			RETURNING signature, slot
+		)
+		INSERT INTO core.transactions (signature, slot, block_time, finalized, created_at)
+		SELECT s.signature, s.slot, s.block_time, s.finalized, now()
+		FROM signatures_staging s
+		JOIN claimed c ON c.signature = s.signature AND c.slot = s.slot
+		ON CONFLICT (signature, slot) DO NOTHING`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert transactions: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO core.program_transactions (transaction_signature, program_id)
+		SELECT signature, $1 FROM signatures_staging
+		ON CONFLICT DO NOTHING`, programID); err != nil {
+		return 0, fmt.Errorf("failed to link transactions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit signatures: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+// claimSignatures records the slots of transactions about to be inserted in core.transaction_signatures
+// and returns those whose signature wasn't stored yet, which the caller inserts in the same database
+// transaction. The database only keeps a signature unique per slot in core.transactions, since it is
+// partitioned by slot; a concurrent claim of the same signature waits for this transaction to end and
+// then skips it.
+func claimSignatures(db *gorm.DB, txs []core.Transaction) ([]core.Transaction, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	type claim struct {
+		Signature string `json:"signature"`
+		Slot      uint64 `json:"slot"`
+	}
+	claims := make([]claim, len(txs))
+	for i, tx := range txs {
+		claims[i] = claim{Signature: tx.Signature, Slot: tx.Slot}
+	}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signatures: %w", err)
+	}
+
+	var claimed []string
+	if err := db.Raw(`
+		INSERT INTO core.transaction_signatures (signature, slot)
+		SELECT signature, slot FROM jsonb_to_recordset(?::jsonb) AS s(signature text, slot bigint)
+		ON CONFLICT DO NOTHING
+		RETURNING signature`, string(data)).
+		Scan(&claimed).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim signatures: %w", err)
+	}
+	fresh := make(map[string]bool, len(claimed))
+	for _, signature := range claimed {
+		fresh[signature] = true
+	}
+	var result []core.Transaction
+	for _, tx := range txs {
+		if fresh[tx.Signature] {
+			result = append(result, tx)
+			delete(fresh, tx.Signature) // a signature listed twice is inserted once
+		}
+	}
+	return result, nil
+}
+
+// storedSlots returns the slots the transactions are stored at by signature, if they are.
+func storedSlots(db *gorm.DB, txs []core.Transaction) (map[string]uint64, error) {
+	signatures := make([]string, len(txs))
+	for i, tx := range txs {
+		signatures[i] = tx.Signature
+	}
+	var stored []core.TransactionSignature
+	if err := db.Where("signature IN ?", signatures).
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up stored transactions: %w", err)
 	}
+	slots := make(map[string]uint64, len(stored))
+	for _, tx := range stored {
+		slots[tx.Signature] = tx.Slot
+	}
+	return slots, nil
+}
 
+// whereSignature matches the transaction with a signature at the slot core.transaction_signatures
+// holds for it, so only the partition of that slot is scanned.
+func whereSignature(db *gorm.DB, signature string) *gorm.DB {
+	return db.Where("slot = (SELECT slot FROM core.transaction_signatures WHERE signature = ?) AND signature = ?", signature, signature)
+}
+
+// whereSignatures matches the transactions with the signatures like whereSignature.
+func whereSignatures(db *gorm.DB, signatures []string) *gorm.DB {
+	return db.Where("(signature, slot) IN (SELECT signature, slot FROM core.transaction_signatures WHERE signature IN ?)", signatures)
+}
+
+// moveSignature records the slot a transaction was moved to. It follows the update of the transaction,
+// which finds the row at its previous slot.
+func moveSignature(db *gorm.DB, signature string, slot uint64) error {
+	if err := db.Model(&core.TransactionSignature{}).
+		Where("signature = ?", signature).
+		Update("slot", slot).Error; err != nil {
+		return fmt.Errorf("failed to move transaction %s to slot %d: %w", signature, slot, err)
+	}
 	return nil
 }
 
+// linkPrograms links transactions to the programs listed for their signatures in one statement.
+// Existing links are left unchanged.
+func linkPrograms(db *gorm.DB, txs []core.Transaction, programs map[string][]string) error {
+	var links []map[string]interface{}
+	for _, tx := range txs {
+		for _, program := range programs[tx.Signature] {
+			links = append(links, map[string]interface{}{
+				"transaction_signature": tx.Signature,
+				"program_id":            program,
+			})
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return db.Table("core.program_transactions").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&links).Error
+}
+
 // AssociateTransactionWithProgram links a transaction to a program via the many-to-many relationship.
 func (g *Gorm) AssociateTransactionWithProgram(ctx context.Context, signature, programID string) error {
 	var prog core.Program
@@ -57,30 +260,62 @@ func (g *Gorm) AssociateTransactionWithProgram(ctx context.Context, signature, p
 	return nil
 }
 
-// UpdateTransactionRaw updates the `json_tx` field of a transaction by its signature.
-func (g *Gorm) UpdateTransactionRaw(ctx context.Context, signature string, raw []byte) error {
-	return g.DB.WithContext(ctx).
-		Model(&core.Transaction{}).
-		Where("signature = ?", signature).
-		Updates(map[string]interface{}{
-			"json_tx": datatypes.JSON(raw),
-		}).Error
+// UpdateTransactionRaw stores the fetched raw payload of a transaction by its signature,
+// along with the slot and block time it landed in.
+func (g *Gorm) UpdateTransactionRaw(ctx context.Context, signature string, slot uint64, blockTime int64, raw []byte) error {
+	columns, err := rawColumns(signature, raw)
+	if err != nil {
+		return err
+	}
+	columns["slot"] = slot
+	columns["block_time"] = blockTime
+	return g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := whereSignature(db.Model(&core.Transaction{}), signature).
+			Updates(columns).Error; err != nil {
+			return fmt.Errorf("failed to store raw transaction %s: %w", signature, err)
+		}
+		return moveSignature(db, signature, slot)
+	})
+}
+
+// FillTransactionRaw stores the raw payload of a transaction that has none yet and reports whether it did.
+func (g *Gorm) FillTransactionRaw(ctx context.Context, signature string, slot uint64, blockTime int64, raw []byte) (bool, error) {
+	columns, err := rawColumns(signature, raw)
+	if err != nil {
+		return false, err
+	}
+	columns["slot"] = slot
+	columns["block_time"] = blockTime
+	var filled bool
+	err = g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		res := whereSignature(db.Model(&core.Transaction{}), signature).
+			Where("NOT " + hasRaw).
+			Updates(columns)
+		if res.Error != nil {
+			return fmt.Errorf("failed to store raw transaction %s: %w", signature, res.Error)
+		}
+		if filled = res.RowsAffected > 0; !filled {
+			return nil
+		}
+		return moveSignature(db, signature, slot)
+	})
+	return filled, err
 }
 
-// MarkParsed sets the `parsed` flag of a transaction to true.
+// MarkParsed sets the `parsed` flag of a transaction to true, which also removes it from the real-time queue.
 func (g *Gorm) MarkParsed(ctx context.Context, signature string) error {
-	return g.DB.WithContext(ctx).
-		Model(&core.Transaction{}).
-		Where("signature = ?", signature).
-		Update("parsed", true).Error
+	return whereSignature(g.DB.WithContext(ctx).Model(&core.Transaction{}), signature).
+		Updates(map[string]interface{}{
+			"parsed":  true,
+			"pending": false,
+		}).Error
 }
 
 // IsParsed checks whether a transaction has already been parsed.
 func (g *Gorm) IsParsed(ctx context.Context, signature string) (bool, error) {
 	var count int64
-	if err := g.DB.WithContext(ctx).
-		Model(&core.Transaction{}).
-		Where("signature = ? AND parsed = true", signature).
+	if err := whereSignature(g.DB.WithContext(ctx).Model(&core.Transaction{}), signature).
+		Where("parsed = true").
 		Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check if transaction is parsed: %w", err)
 	}
@@ -90,9 +325,8 @@ func (g *Gorm) IsParsed(ctx context.Context, signature string) (bool, error) {
 // IsRawFetched checks whether a transaction has already been fetched in raw format.
 func (g *Gorm) IsRawFetched(ctx context.Context, signature string) (bool, error) {
 	var count int64
-	if err := g.DB.WithContext(ctx).
-		Model(&core.Transaction{}).
-		Where("signature = ? AND json_tx IS NOT NULL", signature).
+	if err := whereSignature(g.DB.WithContext(ctx).Model(&core.Transaction{}), signature).
+		Where(hasRaw).
 		Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check if transaction is fetched: %w", err)
 	}
@@ -111,28 +345,33 @@ func (g *Gorm) IsReady(ctx context.Context) (bool, error) {
 	return count == 0, nil
 }
 
+// ofActiveProgram matches transactions t linked to an active program.
+const ofActiveProgram = `EXISTS (
+			SELECT 1 FROM core.program_transactions pt
+			JOIN core.programs p ON p.id = pt.program_id
+			WHERE pt.transaction_signature = t.signature AND p.active
+		)`
+
 // GetOrderedNoParsedSignatures returns signatures of transactions (optionally only unparsed)
-// that are associated with configured programs, ordered by block_time.
+// that are associated with active programs, ordered by block_time.
 func (g *Gorm) GetOrderedNoParsedSignatures(ctx context.Context, resume bool) ([]string, error) {
-	addresses := config.App.Programs
 	var signatures []string
 
+	// EXISTS instead of a join keeps the scan of idx_transactions_parsed_block_time in order
 	query := `
 		SELECT t.signature
 		FROM core.transactions t
-		JOIN core.program_transactions pt ON pt.transaction_signature = t.signature
-		JOIN core.programs p ON p.id = pt.program_id
-		WHERE p.id IN ?`
+		WHERE ` + ofActiveProgram
 
-	args := []any{addresses}
+	var args []any
 
 	if resume {
 		query += " AND t.parsed = false"
 	}
 
+	// Transactions of one slot keep their block order where it is known
 	query += `
-		GROUP BY t.signature, t.block_time
-		ORDER BY t.block_time ASC`
+		ORDER BY t.block_time ASC, t.slot ASC, t.block_index ASC`
 
 	err := g.DB.WithContext(ctx).
 		Raw(query, args...).
@@ -147,20 +386,16 @@ func (g *Gorm) GetOrderedNoParsedSignatures(ctx context.Context, resume bool) ([
 
 // GetOrderedNoRawSignatures returns signatures of transactions that are missing raw JSON payloads.
 func (g *Gorm) GetOrderedNoRawSignatures(ctx context.Context) ([]string, error) {
-	addresses := config.App.Programs
 	var signatures []string
 
 	err := g.DB.WithContext(ctx).
 		Raw(`
 		SELECT t.signature
 		FROM core.transactions t
-		JOIN core.program_transactions pt ON pt.transaction_signature = t.signature
-		JOIN core.programs p ON p.id = pt.program_id
-		WHERE p.id IN ?
-		  AND t.json_tx IS NULL
-		GROUP BY t.signature, t.block_time
-		ORDER BY t.block_time ASC
-	`, addresses).
+		WHERE ` + ofActiveProgram + `
+		  AND ` + missingRaw + `
+		ORDER BY t.block_time ASC, t.slot ASC
+	`).
 		Scan(&signatures).Error
 
 	if err != nil {
@@ -190,16 +425,373 @@ func (g *Gorm) GetLatestSavedSignature(ctx context.Context, programID string) (s
 }
 
 // GetRawTransaction returns the raw JSON transaction bytes for a given signature.
-// Returns nil if the transaction is not found.
+// Returns nil if the transaction is not found or has no raw payload, e.g. a pruned one.
 func (g *Gorm) GetRawTransaction(ctx context.Context, signature string) ([]byte, error) {
 	var tx core.Transaction
-	err := g.DB.WithContext(ctx).
-		First(&tx, "signature = ?", signature).Error
+	err := whereSignature(g.DB.WithContext(ctx), signature).
+		First(&tx).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
 	}
-	return tx.JsonTx, nil
+	return decodeRaw(&tx)
+}
+
+// GetUnfinalizedTransactions returns up to limit confirmed transactions that are not yet known to be
+// finalized and whose slot is at or below the given finalized slot, ordered by slot and signature
+// after the cursor, if set.
+func (g *Gorm) GetUnfinalizedTransactions(ctx context.Context, finalizedSlot uint64, after *TransactionCursor, limit int) ([]core.Transaction, error) {
+	query := g.DB.WithContext(ctx).
+		Model(&core.Transaction{}).
+		Select("signature, slot, block_time, parsed").
+		Where("finalized = false AND slot <= ?", finalizedSlot)
+	if after != nil {
+		query = query.Where("(slot, signature) > (?, ?)", after.Slot, after.Signature)
+	}
+	var txs []core.Transaction
+	if err := query.Order("slot ASC, signature ASC").Limit(limit).Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch unfinalized transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// MarkFinalized sets the `finalized` flag for the given transactions.
+func (g *Gorm) MarkFinalized(ctx context.Context, signatures []string) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	return whereSignatures(g.DB.WithContext(ctx).Model(&core.Transaction{}), signatures).
+		Update("finalized", true).Error
+}
+
+// DeleteTransaction removes a transaction together with its events and program links.
+// It is used to revert transactions that were dropped by a fork.
+func (g *Gorm) DeleteTransaction(ctx context.Context, signature string) error {
+	return g.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transaction_signature = ?", signature).
+			Delete(&core.Event{}).Error; err != nil {
+			return fmt.Errorf("failed to delete events of %s: %w", signature, err)
+		}
+		if err := tx.Exec("DELETE FROM core.program_transactions WHERE transaction_signature = ?", signature).Error; err != nil {
+			return fmt.Errorf("failed to delete program links of %s: %w", signature, err)
+		}
+		if err := whereSignature(tx, signature).
+			Delete(&core.Transaction{}).Error; err != nil {
+			return fmt.Errorf("failed to delete transaction %s: %w", signature, err)
+		}
+		if err := tx.Where("signature = ?", signature).
+			Delete(&core.TransactionSignature{}).Error; err != nil {
+			return fmt.Errorf("failed to delete signature %s: %w", signature, err)
+		}
+		return nil
+	})
+}
+
+// ResetTransaction replaces the slot, block time and raw payload of a transaction that
+// landed in a different slot than first observed. Its events are removed and it is
+// marked unparsed and finalized, so it can be parsed again.
+func (g *Gorm) ResetTransaction(ctx context.Context, signature string, slot uint64, blockTime int64, raw []byte) error {
+	columns, err := rawColumns(signature, raw)
+	if err != nil {
+		return err
+	}
+	columns["slot"] = slot
+	columns["block_time"] = blockTime
+	columns["parsed"] = false
+	columns["finalized"] = true
+	return g.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transaction_signature = ?", signature).
+			Delete(&core.Event{}).Error; err != nil {
+			return fmt.Errorf("failed to delete events of %s: %w", signature, err)
+		}
+		if err := whereSignature(tx.Model(&core.Transaction{}), signature).
+			Updates(columns).Error; err != nil {
+			return fmt.Errorf("failed to reset transaction %s: %w", signature, err)
+		}
+		return moveSignature(tx, signature, slot)
+	})
+}
+
+// TransactionFilter narrows ListTransactions; zero values disable a condition.
+type TransactionFilter struct {
+	ProgramID string
+	FromSlot  *uint64
+	ToSlot    *uint64
+	Parsed    *bool
+	After     *TransactionCursor
+	Limit     int
+}
+
+// TransactionCursor is the (slot, signature) position of the last returned transaction.
+type TransactionCursor struct {
+	Slot      uint64
+	Signature string
+}
+
+// ListTransactions returns transactions without their raw JSON ordered by slot and signature,
+// starting after the cursor.
+func (g *Gorm) ListTransactions(ctx context.Context, filter TransactionFilter) ([]core.Transaction, error) {
+	q := g.DB.WithContext(ctx).
+		Model(&core.Transaction{}).
+		Omit("json_tx", "raw_zstd")
+
+	if filter.ProgramID != "" {
+		q = q.Where("signature IN (?)", g.DB.
+			Table("core.program_transactions").
+			Select("transaction_signature").
+			Where("program_id = ?", filter.ProgramID))
+	}
+	if filter.FromSlot != nil {
+		q = q.Where("slot >= ?", *filter.FromSlot)
+	}
+	if filter.ToSlot != nil {
+		q = q.Where("slot <= ?", *filter.ToSlot)
+	}
+	if filter.Parsed != nil {
+		q = q.Where("parsed = ?", *filter.Parsed)
+	}
+	if filter.After != nil {
+		q = q.Where("(slot, signature) > (?, ?)", filter.After.Slot, filter.After.Signature)
+	}
+
+	var txs []core.Transaction
+	if err := q.
+		Order("slot ASC, signature ASC").
+		Limit(filter.Limit).
+		Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// GetTransaction returns a transaction with its programs but without its raw JSON, or nil if unknown.
+func (g *Gorm) GetTransaction(ctx context.Context, signature string) (*core.Transaction, error) {
+	var tx core.Transaction
+	err := whereSignature(g.DB.WithContext(ctx), signature).
+		Omit("json_tx", "raw_zstd").
+		Preload("Programs").
+		First(&tx).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	return &tx, nil
+}
+
+// GetTransactionsInSlotRange returns transactions without their raw JSON whose slot lies in
+// [fromSlot, toSlot], in parsing order.
+func (g *Gorm) GetTransactionsInSlotRange(ctx context.Context, fromSlot, toSlot uint64) ([]core.Transaction, error) {
+	var txs []core.Transaction
+	if err := g.DB.WithContext(ctx).
+		Omit("json_tx", "raw_zstd").
+		Where("slot BETWEEN ? AND ?", fromSlot, toSlot).
+		Order("slot ASC, block_time ASC, signature ASC").
+		Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions in slots %d-%d: %w", fromSlot, toSlot, err)
+	}
+	return txs, nil
+}
+
+// ReplaceRawTransaction stores a re-fetched payload of a transaction. Its events are removed
+// and it is marked unparsed, so it can be parsed again.
+func (g *Gorm) ReplaceRawTransaction(ctx context.Context, signature string, slot uint64, blockTime int64, raw []byte) error {
+	columns, err := rawColumns(signature, raw)
+	if err != nil {
+		return err
+	}
+	columns["slot"] = slot
+	columns["block_time"] = blockTime
+	columns["parsed"] = false
+	return g.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transaction_signature = ?", signature).
+			Delete(&core.Event{}).Error; err != nil {
+			return fmt.Errorf("failed to delete events of %s: %w", signature, err)
+		}
+		if err := whereSignature(tx.Model(&core.Transaction{}), signature).
+			Updates(columns).Error; err != nil {
+			return fmt.Errorf("failed to replace transaction %s: %w", signature, err)
+		}
+		return moveSignature(tx, signature, slot)
+	})
+}
+
+// MarkUnparsed removes the events of the given transactions and marks them unparsed.
+func (g *Gorm) MarkUnparsed(ctx context.Context, signatures []string) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	return g.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transaction_signature IN ?", signatures).
+			Delete(&core.Event{}).Error; err != nil {
+			return fmt.Errorf("failed to delete events: %w", err)
+		}
+		if err := whereSignatures(tx.Model(&core.Transaction{}), signatures).
+			Update("parsed", false).Error; err != nil {
+			return fmt.Errorf("failed to mark transactions unparsed: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetParsedHead returns the highest slot of a parsed transaction, or 0 if nothing is parsed.
+func (g *Gorm) GetParsedHead(ctx context.Context) (uint64, error) {
+	var head uint64
+	if err := g.DB.WithContext(ctx).
+		Model(&core.Transaction{}).
+		Where("parsed").
+		Select("COALESCE(MAX(slot), 0)").
+		Scan(&head).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch parsed head: %w", err)
+	}
+	return head, nil
+}
+
+// GetMinUnparsedSlot returns the lowest slot of an unparsed transaction of an active program.
+// The second result is false when there is nothing to parse.
+func (g *Gorm) GetMinUnparsedSlot(ctx context.Context) (uint64, bool, error) {
+	var slot *uint64
+	err := g.DB.WithContext(ctx).
+		Raw(`
+		SELECT MIN(t.slot)
+		FROM core.transactions t
+		JOIN core.program_transactions pt ON pt.transaction_signature = t.signature
+		JOIN core.programs p ON p.id = pt.program_id
+		WHERE p.active AND NOT t.parsed
+	`).
+		Scan(&slot).Error
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch lowest unparsed slot: %w", err)
+	}
+	if slot == nil {
+		return 0, false, nil
+	}
+	return *slot, true, nil
+}
+
+// GetUnparsedTransactions returns the unparsed transactions of a program without their raw JSON,
+// in parsing order.
+func (g *Gorm) GetUnparsedTransactions(ctx context.Context, programID string) ([]core.Transaction, error) {
+	var txs []core.Transaction
+	if err := g.DB.WithContext(ctx).
+		Omit("json_tx", "raw_zstd").
+		Where("NOT parsed").
+		Where("signature IN (?)", g.DB.
+			Table("core.program_transactions").
+			Select("transaction_signature").
+			Where("program_id = ?", programID)).
+		Order("slot ASC, block_time ASC, signature ASC").
+		Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch unparsed transactions of %s: %w", programID, err)
+	}
+	return txs, nil
+}
+
+// GetTransactionPrograms returns the IDs of the programs each of the transactions is linked to.
+func (g *Gorm) GetTransactionPrograms(ctx context.Context, signatures []string) (map[string][]string, error) {
+	links := make(map[string][]string, len(signatures))
+	if len(signatures) == 0 {
+		return links, nil
+	}
+
+	var rows []struct {
+		TransactionSignature string
+		ProgramID            string
+	}
+	if err := g.DB.WithContext(ctx).
+		Table("core.program_transactions").
+		Select("transaction_signature, program_id").
+		Where("transaction_signature IN ?", signatures).
+		Order("transaction_signature, program_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load program links: %w", err)
+	}
+	for _, row := range rows {
+		links[row.TransactionSignature] = append(links[row.TransactionSignature], row.ProgramID)
+	}
+	return links, nil
+}
+
+// ImportTransactions inserts transactions with their program links in one database transaction
+// and returns how many were new. Transactions and links that already exist are left unchanged.
+func (g *Gorm) ImportTransactions(ctx context.Context, txs []core.Transaction, programs map[string][]string) (int64, error) {
+	for i := range txs {
+		if err := encodeRaw(&txs[i]); err != nil {
+			return 0, err
+		}
+	}
+	var inserted int64
+	err := g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		fresh, err := claimSignatures(db, txs)
+		if err != nil {
+			return err
+		}
+		if len(fresh) > 0 {
+			res := db.Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&fresh)
+			if res.Error != nil {
+				return fmt.Errorf("failed to insert transactions: %w", res.Error)
+			}
+			inserted = res.RowsAffected
+		}
+
+		if err := linkPrograms(db, txs, programs); err != nil {
+			return fmt.Errorf("failed to link transactions: %w", err)
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+// SaveBlockTransactions stores the transactions of a block with their program links in one database
+// transaction. Transactions already stored get their block index, and their raw payload if they had none.
+func (g *Gorm) SaveBlockTransactions(ctx context.Context, txs []core.Transaction, programs map[string][]string) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	for i := range txs {
+		if err := encodeRaw(&txs[i]); err != nil {
+			return err
+		}
+	}
+
+	// The stored payload is kept, in whatever format it is
+	keep := "transactions.json_tx IS NOT NULL OR transactions.raw_zstd IS NOT NULL OR transactions.raw_object IS NOT NULL"
+	assignments := map[string]interface{}{"block_index": gorm.Expr("EXCLUDED.block_index")}
+	for _, column := range []string{"json_tx", "raw_zstd", "raw_object", "raw_pruned_at"} {
+		assignments[column] = gorm.Expr(fmt.Sprintf("CASE WHEN %s THEN transactions.%s ELSE EXCLUDED.%s END", keep, column, column))
+	}
+	return g.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		// A transaction stored at another slot is left to the reconciler
+		if _, err := claimSignatures(db, txs); err != nil {
+			return err
+		}
+		slots, err := storedSlots(db, txs)
+		if err != nil {
+			return err
+		}
+		save := slices.DeleteFunc(slices.Clone(txs), func(tx core.Transaction) bool {
+			slot, ok := slots[tx.Signature]
+			return ok && slot != tx.Slot
+		})
+		if len(save) > 0 {
+			if err := db.Omit(clause.Associations).
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "signature"}, {Name: "slot"}},
+					DoUpdates: clause.Assignments(assignments),
+				}).
+				Create(&save).Error; err != nil {
+				return fmt.Errorf("failed to save block transactions: %w", err)
+			}
+		}
+
+		if err := linkPrograms(db, txs, programs); err != nil {
+			return fmt.Errorf("failed to link block transactions: %w", err)
+		}
+		return nil
+	})
 }
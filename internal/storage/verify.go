@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"gorm.io/gorm"
+)
+
+// Consistency counts the problems found by CheckConsistency.
+type Consistency struct {
+	MissingRaw       int64  `gorm:"column:missing_raw" json:"missing_raw"`
+	Unparsed         int64  `gorm:"column:unparsed" json:"unparsed"`
+	WithoutProgram   int64  `gorm:"column:without_program" json:"without_program"`
+	MisplacedEvents  int64  `gorm:"column:misplaced_events" json:"misplaced_events"`
+	IndexingError    string `gorm:"-" json:"indexing_error,omitempty"`
+	SubgraphSlot     uint64 `gorm:"-" json:"subgraph_slot"`
+	LatestParsedSlot uint64 `gorm:"column:latest_parsed_slot" json:"latest_parsed_slot"`
+}
+
+// OK reports whether no problems were found.
+func (c Consistency) OK() bool {
+	return c.MissingRaw == 0 && c.Unparsed == 0 && c.WithoutProgram == 0 &&
+		c.MisplacedEvents == 0 && c.IndexingError == "" && c.SubgraphSlot >= c.LatestParsedSlot
+}
+
+// CheckConsistency looks for stored transactions and events that were not fully processed
+// and compares the subgraph head with the latest parsed slot.
+func (g *Gorm) CheckConsistency(ctx context.Context) (Consistency, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM core.transactions WHERE ` + missingRaw + `) AS missing_raw,
+			(SELECT COUNT(*) FROM core.transactions WHERE NOT parsed) AS unparsed,
+			(SELECT COUNT(*) FROM core.transactions t
+				WHERE NOT EXISTS (
+					SELECT 1 FROM core.program_transactions pt WHERE pt.transaction_signature = t.signature
+				)) AS without_program,
+			(SELECT COUNT(*) FROM core.events e
+				JOIN core.transactions t ON t.signature = e.transaction_signature
+				WHERE e.slot <> t.slot) AS misplaced_events,
+			(SELECT COALESCE(MAX(slot), 0) FROM core.transactions WHERE parsed) AS latest_parsed_slot`
+
+	var c Consistency
+	if err := g.DB.WithContext(ctx).Raw(query).Scan(&c).Error; err != nil {
+		return c, fmt.Errorf("failed to check core tables: %w", err)
+	}
+
+	var meta subgraph.Meta
+	err := g.DB.WithContext(ctx).Preload("Block").First(&meta, 1).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c, fmt.Errorf("failed to load _meta: %w", err)
+	}
+	if meta.HasIndexingErrors {
+		c.IndexingError = meta.ErrorMessage
+		if c.IndexingError == "" {
+			c.IndexingError = "unknown error"
+		}
+	}
+	if meta.Block != nil {
+		c.SubgraphSlot = meta.Block.Number
+	}
+	return c, nil
+}
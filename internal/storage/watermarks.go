@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetWatermark returns the value of a named watermark, or 0 if it was never set.
+func (g *Gorm) GetWatermark(ctx context.Context, name string) (uint64, error) {
+	var wm core.Watermark
+	err := g.DB.WithContext(ctx).
+		First(&wm, "name = ?", name).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to fetch watermark %s: %w", name, err)
+	}
+	return wm.Value, nil
+}
+
+// SetWatermark upserts the value of a named watermark.
+func (g *Gorm) SetWatermark(ctx context.Context, name string, value uint64) error {
+	err := g.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).
+		Create(&core.Watermark{Name: name, Value: value}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save watermark %s: %w", name, err)
+	}
+	return nil
+}
@@ -4,33 +4,68 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/storage"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/Tsisar/solana-indexer/internal/utils"
-	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sync"
 	"time"
 )
 
-func Start(ctx context.Context, db *gorm.DB) error {
-	ticker := time.NewTicker(1 * time.Hour)
+// debounce is how long the aggregator waits after a notification so bursts of
+// new share token data are aggregated in a single run.
+const debounce = 5 * time.Second
 
-	if err := aggregateAndSaveSharePrice(ctx, db, "hour"); err != nil {
-		return fmt.Errorf("[aggregator] aggregation error: %v", err)
+// batchSize is the number of token stats upserted per statement.
+const batchSize = 500
+
+// supportedIntervals are the date_trunc units the aggregator can bucket by.
+var supportedIntervals = map[string]bool{
+	"minute": true,
+	"hour":   true,
+	"day":    true,
+	"week":   true,
+	"month":  true,
+}
+
+var (
+	mu     sync.Mutex
+	notify = make(chan struct{}, 1)
+)
+
+func Start(ctx context.Context, db *storage.Gorm) error {
+	for _, interval := range config.App.Aggregator.Intervals {
+		if !supportedIntervals[interval] {
+			return fmt.Errorf("[aggregator] unsupported interval: %s", interval)
+		}
 	}
-	if err := aggregateAndSaveSharePrice(ctx, db, "day"); err != nil {
-		return fmt.Errorf("[aggregator] aggregation error: %v", err)
+
+	ticker := time.NewTicker(config.App.Aggregator.Interval)
+
+	if err := Aggregate(ctx, db); err != nil {
+		return err
 	}
 
 	go func() {
+		var pending <-chan time.Time
 		for {
 			select {
-			case <-ticker.C:
-				log.Debug("[aggregator] Running hourly aggregation...")
-				if err := aggregateAndSaveSharePrice(ctx, db, "hour"); err != nil {
-					log.Errorf("[aggregator] aggregation error: %v", err)
+			case <-notify:
+				if pending == nil {
+					pending = time.After(debounce)
 				}
-				if err := aggregateAndSaveSharePrice(ctx, db, "day"); err != nil {
-					log.Errorf("[aggregator] aggregation error: %v", err)
+			case <-pending:
+				pending = nil
+				log.Debug("[aggregator] Running aggregation after new share token data...")
+				if err := Aggregate(ctx, db); err != nil {
+					log.Errorf("%v", err)
+				}
+			case <-ticker.C:
+				log.Debug("[aggregator] Running periodic aggregation...")
+				if err := Aggregate(ctx, db); err != nil {
+					log.Errorf("%v", err)
 				}
 			case <-ctx.Done():
 				ticker.Stop()
@@ -42,13 +77,69 @@ func Start(ctx context.Context, db *gorm.DB) error {
 	return nil
 }
 
-func aggregateAndSaveSharePrice(ctx context.Context, db *gorm.DB, interval string) error {
-	if interval != "hour" && interval != "day" {
+// Notify schedules an aggregation run; it never blocks and repeated calls are coalesced.
+func Notify() {
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
+
+// Aggregate runs a single incremental share price aggregation for all configured intervals.
+func Aggregate(ctx context.Context, db *storage.Gorm) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, interval := range config.App.Aggregator.Intervals {
+		if err := aggregateAndSaveSharePrice(ctx, db, interval); err != nil {
+			return fmt.Errorf("[aggregator] aggregation error: %v", err)
+		}
+	}
+	return nil
+}
+
+// Reset removes all token stats and watermarks, so the next run recomputes every bucket.
+func Reset(ctx context.Context, db *storage.Gorm) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := db.DB.WithContext(ctx).Where("1 = 1").Delete(&subgraph.TokenStats{}).Error; err != nil {
+		return fmt.Errorf("[aggregator] failed to delete token stats: %w", err)
+	}
+	for interval := range supportedIntervals {
+		if err := db.SetWatermark(ctx, watermarkName(interval), 0); err != nil {
+			return fmt.Errorf("[aggregator] failed to reset watermark: %w", err)
+		}
+	}
+	return nil
+}
+
+// aggregateAndSaveSharePrice recomputes the buckets of the share token data inserted after the
+// interval's watermark and moves the watermark to the latest inserted row. The watermark follows the
+// insert order rather than the timestamps, so rows inserted late with older timestamps, e.g. by a
+// backfill, still update their buckets.
+func aggregateAndSaveSharePrice(ctx context.Context, db *storage.Gorm, interval string) error {
+	if !supportedIntervals[interval] {
 		return fmt.Errorf("[aggregator] unsupported interval: %s", interval)
 	}
 
+	watermark, err := db.GetWatermark(ctx, watermarkName(interval))
+	if err != nil {
+		return fmt.Errorf("[aggregator] failed to load %s watermark: %w", interval, err)
+	}
+
+	var latest *int64
+	if err := db.DB.WithContext(ctx).
+		Raw(`SELECT MAX(seq) FROM share_token_data`).
+		Scan(&latest).Error; err != nil {
+		return fmt.Errorf("[aggregator] failed to load latest share token data: %w", err)
+	}
+	if latest == nil || uint64(*latest) <= watermark {
+		return nil
+	}
+
 	type rawRow struct {
-		VaultID    string            `gorm:"column:vault_id"`
+		VaultID    string           `gorm:"column:vault_id"`
 		Timestamp  int64            `gorm:"column:timestamp_sec"`
 		SharePrice types.BigDecimal `gorm:"column:share_price"`
 	}
@@ -56,16 +147,27 @@ func aggregateAndSaveSharePrice(ctx context.Context, db *gorm.DB, interval strin
 	var rows []rawRow
 
 	query := fmt.Sprintf(`
-		WITH aggregated AS (
-			SELECT
+		WITH dirty AS (
+			SELECT DISTINCT
 				vault_id,
-				EXTRACT(EPOCH FROM date_trunc('%s', to_timestamp(timestamp::numeric)))::BIGINT AS timestamp_sec,
-				LAST_VALUE(share_price) OVER (
-					PARTITION BY vault_id, date_trunc('%s', to_timestamp(timestamp::numeric))
-					ORDER BY timestamp::numeric
+				date_trunc('%s', to_timestamp(timestamp::numeric)) AS bucket
+			FROM share_token_data
+			WHERE seq > ? AND seq <= ?
+		),
+		aggregated AS (
+			SELECT
+				d.vault_id,
+				EXTRACT(EPOCH FROM d.bucket)::BIGINT AS timestamp_sec,
+				LAST_VALUE(s.share_price) OVER (
+					PARTITION BY d.vault_id, d.bucket
+					ORDER BY s.timestamp::numeric
 					ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING
 				) AS share_price
-			FROM share_token_data
+			FROM dirty d
+			JOIN share_token_data s
+			  ON s.vault_id = d.vault_id
+			 AND s.timestamp::numeric >= EXTRACT(EPOCH FROM d.bucket)
+			 AND s.timestamp::numeric < EXTRACT(EPOCH FROM d.bucket + interval '1 %s')
 		)
 		SELECT DISTINCT ON (vault_id, timestamp_sec)
 			   vault_id,
@@ -75,26 +177,40 @@ func aggregateAndSaveSharePrice(ctx context.Context, db *gorm.DB, interval strin
 		ORDER BY vault_id, timestamp_sec;
 	`, interval, interval)
 
-	if err := db.Raw(query).Scan(&rows).Error; err != nil {
+	if err := db.DB.WithContext(ctx).Raw(query, watermark, *latest).Scan(&rows).Error; err != nil {
 		return fmt.Errorf("[aggregator] %s aggregation query failed: %w", interval, err)
 	}
 
+	stats := make([]subgraph.TokenStats, 0, len(rows))
 	for _, r := range rows {
-		timestamp := *types.NewBigIntFromInt64(r.Timestamp)
 		timestampStr := fmt.Sprintf("%d", r.Timestamp)
-		id := utils.GenerateId(r.VaultID, timestampStr, interval)
-
-		stat := subgraph.TokenStats{
-			ID:         id,
+		stats = append(stats, subgraph.TokenStats{
+			ID:         utils.GenerateId(r.VaultID, timestampStr, interval),
 			VaultID:    r.VaultID,
-			Timestamp:  timestamp,
+			Timestamp:  *types.NewBigIntFromInt64(r.Timestamp),
 			SharePrice: r.SharePrice,
 			Interval:   interval,
-		}
-		if err := stat.Save(ctx, db); err != nil {
+		})
+	}
+
+	if len(stats) > 0 {
+		if err := db.DB.WithContext(ctx).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"share_price"}),
+			}).
+			CreateInBatches(&stats, batchSize).Error; err != nil {
 			return fmt.Errorf("[aggregator] failed to save %s token stats: %w", interval, err)
 		}
 	}
 
+	if err := db.SetWatermark(ctx, watermarkName(interval), uint64(*latest)); err != nil {
+		return fmt.Errorf("[aggregator] failed to save %s watermark: %w", interval, err)
+	}
+	log.Debugf("[aggregator] %s aggregation updated %d buckets", interval, len(stats))
 	return nil
 }
+
+func watermarkName(interval string) string {
+	return "aggregator:" + interval
+}
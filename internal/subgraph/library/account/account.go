@@ -3,11 +3,11 @@ package account
 import (
 	"context"
 	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
-	"gorm.io/gorm"
 )
 
-func UpdateAccount(ctx context.Context, db *gorm.DB, authorityId, tokenAccountId, shareAccountId string) error {
+func UpdateAccount(ctx context.Context, db generic.EntityStore, authorityId, tokenAccountId, shareAccountId string) error {
 	authorityAccount := subgraph.Account{ID: authorityId}
 	if _, err := authorityAccount.Load(ctx, db); err != nil {
 		return fmt.Errorf("[account] failed to load authority account: %w", err)
@@ -3,12 +3,12 @@ package accountant
 import (
 	"context"
 	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
-	"gorm.io/gorm"
 )
 
-func Init(ctx context.Context, db *gorm.DB, id string) (*subgraph.Accountant, error) {
+func Init(ctx context.Context, db generic.EntityStore, id string) (*subgraph.Accountant, error) {
 	accountant := subgraph.Accountant{ID: id}
 	ok, err := accountant.Load(ctx, db)
 	if err != nil {
@@ -22,7 +22,7 @@ func Init(ctx context.Context, db *gorm.DB, id string) (*subgraph.Accountant, er
 	return &accountant, nil
 }
 
-func SetRedemptionFee(ctx context.Context, db *gorm.DB, ev events.RedemptionFeeUpdatedEvent) error {
+func SetRedemptionFee(ctx context.Context, db generic.EntityStore, ev events.RedemptionFeeUpdatedEvent) error {
 	accountant := subgraph.Accountant{ID: ev.AccountantKey.String()}
 	if _, err := accountant.Load(ctx, db); err != nil {
 		return fmt.Errorf("[SetRedemptionFee] failed to load accountant: %w", err)
@@ -34,7 +34,7 @@ func SetRedemptionFee(ctx context.Context, db *gorm.DB, ev events.RedemptionFeeU
 	return nil
 }
 
-func SetPerformanceFee(ctx context.Context, db *gorm.DB, ev events.PerformanceFeeUpdatedEvent) error {
+func SetPerformanceFee(ctx context.Context, db generic.EntityStore, ev events.PerformanceFeeUpdatedEvent) error {
 	accountant := subgraph.Accountant{ID: ev.AccountantKey.String()}
 	if _, err := accountant.Load(ctx, db); err != nil {
 		return fmt.Errorf("[SetPerformanceFee] failed to load accountant: %w", err)
@@ -46,7 +46,7 @@ func SetPerformanceFee(ctx context.Context, db *gorm.DB, ev events.PerformanceFe
 	return nil
 }
 
-func SetEntryFee(ctx context.Context, db *gorm.DB, ev events.EntryFeeUpdatedEvent) error {
+func SetEntryFee(ctx context.Context, db generic.EntityStore, ev events.EntryFeeUpdatedEvent) error {
 	accountant := subgraph.Accountant{ID: ev.AccountantKey.String()}
 	if _, err := accountant.Load(ctx, db); err != nil {
 		return fmt.Errorf("[SetEntryFee] failed to load accountant: %w", err)
@@ -4,15 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/aggregator"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/Tsisar/solana-indexer/internal/utils"
-	"gorm.io/gorm"
 	"math/big"
 )
 
-func CreateReport(ctx context.Context, db *gorm.DB, ev events.StrategyReportedEvent, transaction events.Transaction) error {
+func CreateReport(ctx context.Context, db generic.EntityStore, ev events.StrategyReportedEvent, transaction events.Transaction) error {
 	log.Infof("[report] Creating report...")
 	strategy := subgraph.Strategy{ID: ev.StrategyKey.String()}
 	ok, err := strategy.Load(ctx, db)
@@ -75,7 +76,7 @@ func CreateReport(ctx context.Context, db *gorm.DB, ev events.StrategyReportedEv
 	return nil
 }
 
-func createReportResult(ctx context.Context, db *gorm.DB, previousReport subgraph.StrategyReport, currentReport subgraph.StrategyReport, transaction events.Transaction) error {
+func createReportResult(ctx context.Context, db generic.EntityStore, previousReport subgraph.StrategyReport, currentReport subgraph.StrategyReport, transaction events.Transaction) error {
 	log.Infof("[report] Creating report result (latest vs current report)...")
 	if currentReport.ID == previousReport.ID {
 		log.Warnf("[report] Report result NOT created. Current report is the same as latest report")
@@ -199,7 +200,7 @@ func createReportResult(ctx context.Context, db *gorm.DB, previousReport subgrap
 	return nil
 }
 
-func CreateReportEvent(ctx context.Context, db *gorm.DB, ev events.StrategyReportedEvent, transaction events.Transaction) error {
+func CreateReportEvent(ctx context.Context, db generic.EntityStore, ev events.StrategyReportedEvent, transaction events.Transaction) error {
 	log.Infof("[report] Creating report event...")
 
 	id := utils.GenerateId(transaction.Signature, ev.StrategyKey.String())
@@ -220,7 +221,7 @@ func CreateReportEvent(ctx context.Context, db *gorm.DB, ev events.StrategyRepor
 	return nil
 }
 
-func CreateShareTokenData(ctx context.Context, db *gorm.DB, ev events.StrategyReportedEvent, transaction events.Transaction) error {
+func CreateShareTokenData(ctx context.Context, db generic.EntityStore, ev events.StrategyReportedEvent, transaction events.Transaction) error {
 	log.Infof("[report] Creating share token data...")
 	strategy := subgraph.Strategy{ID: ev.StrategyKey.String()}
 	ok, err := strategy.Load(ctx, db)
@@ -244,6 +245,7 @@ func CreateShareTokenData(ctx context.Context, db *gorm.DB, ev events.StrategyRe
 	if err := shareTokenData.Save(ctx, db); err != nil {
 		return fmt.Errorf("[report] failed to save share token data: %w", err)
 	}
+	aggregator.Notify()
 
 	return nil
 }
@@ -4,14 +4,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/Tsisar/solana-indexer/internal/utils"
-	"gorm.io/gorm"
 )
 
-func InitializeAccount(ctx context.Context, db *gorm.DB, ev events.InitializeAccountInstruction) error {
+func InitializeAccount(ctx context.Context, db generic.EntityStore, ev events.InitializeAccountInstruction) error {
 	tokenAccount := subgraph.TokenAccount{ID: ev.Account}
 	if _, err := tokenAccount.Load(ctx, db); err != nil {
 		return fmt.Errorf("[shareToken] failed to load token account: %w", err)
@@ -25,7 +25,7 @@ func InitializeAccount(ctx context.Context, db *gorm.DB, ev events.InitializeAcc
 	return nil
 }
 
-func Mint(ctx context.Context, db *gorm.DB, ev events.MintToInstruction, transaction events.Transaction) error {
+func Mint(ctx context.Context, db generic.EntityStore, ev events.MintToInstruction, transaction events.Transaction) error {
 	// Get the list of share token mints for all vaults
 	mints, err := subgraph.GetShareTokenMints(ctx, db)
 	if err != nil {
@@ -62,7 +62,7 @@ func Mint(ctx context.Context, db *gorm.DB, ev events.MintToInstruction, transac
 	return nil
 }
 
-func Burn(ctx context.Context, db *gorm.DB, ev events.BurnInstruction, transaction events.Transaction) error {
+func Burn(ctx context.Context, db generic.EntityStore, ev events.BurnInstruction, transaction events.Transaction) error {
 	// Get the list of share token mints for all vaults
 	mints, err := subgraph.GetShareTokenMints(ctx, db)
 	if err != nil {
@@ -98,7 +98,7 @@ func Burn(ctx context.Context, db *gorm.DB, ev events.BurnInstruction, transacti
 	return nil
 }
 
-func Transfer(ctx context.Context, db *gorm.DB, ev events.TransferInstruction, transaction events.Transaction) error {
+func Transfer(ctx context.Context, db generic.EntityStore, ev events.TransferInstruction, transaction events.Transaction) error {
 	mint, err := getMint(ctx, db, ev.From, ev.To)
 	if err != nil {
 		return fmt.Errorf("[shareToken] failed to get mint: %w", err)
@@ -154,7 +154,7 @@ func Transfer(ctx context.Context, db *gorm.DB, ev events.TransferInstruction, t
 	return nil
 }
 
-func getCurrentPrice(ctx context.Context, db *gorm.DB, tokenId string) types.BigInt {
+func getCurrentPrice(ctx context.Context, db generic.EntityStore, tokenId string) types.BigInt {
 	log.Debugf("[shareToken] get current price fo token: %s", tokenId)
 	token := subgraph.Token{ID: tokenId}
 	ok, err := token.Load(ctx, db)
@@ -165,7 +165,7 @@ func getCurrentPrice(ctx context.Context, db *gorm.DB, tokenId string) types.Big
 	return token.CurrentPrice
 }
 
-func getMint(ctx context.Context, db *gorm.DB, from, to string) (string, error) {
+func getMint(ctx context.Context, db generic.EntityStore, from, to string) (string, error) {
 	tokenAccountFrom := subgraph.TokenAccount{ID: from}
 	ok, err := tokenAccountFrom.Load(ctx, db)
 	if err != nil {
@@ -0,0 +1,134 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"strconv"
+)
+
+const (
+	hourSeconds = int64(3600)
+	daySeconds  = int64(86400)
+)
+
+// VaultActivity describes the flows to add to the vault buckets of the current event.
+type VaultActivity struct {
+	Deposit  *types.BigInt // Deposited token amount, nil if the event is not a deposit
+	Withdraw *types.BigInt // Withdrawn token amount, nil if the event is not a withdrawal
+}
+
+type entity interface {
+	Load(ctx context.Context, db generic.EntityStore) (bool, error)
+	Save(ctx context.Context, db generic.EntityStore) error
+}
+
+// UpdateVault refreshes the hourly and daily snapshots of the vault with its current state and the given activity.
+func UpdateVault(ctx context.Context, db generic.EntityStore, vaultID string, timestamp types.BigInt, activity VaultActivity) error {
+	// generic.Load skips the heavy relation preloads of Vault.Load
+	vault := subgraph.Vault{ID: vaultID}
+	ok, err := generic.Load(ctx, db, &vault)
+	if err != nil {
+		return fmt.Errorf("[snapshot] failed to load vault: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	ts := timestamp.Int64()
+
+	hourStart := ts - ts%hourSeconds
+	hour := subgraph.VaultHourData{ID: bucketId(vaultID, hourStart, "hour")}
+	if err := updateVaultBucket(ctx, db, &hour, &hour.VaultSnapshot, vault, hourStart, hourSeconds, activity); err != nil {
+		return fmt.Errorf("[snapshot] failed to update vault hour data: %w", err)
+	}
+
+	dayStart := ts - ts%daySeconds
+	day := subgraph.VaultDayData{ID: bucketId(vaultID, dayStart, "day")}
+	if err := updateVaultBucket(ctx, db, &day, &day.VaultSnapshot, vault, dayStart, daySeconds, activity); err != nil {
+		return fmt.Errorf("[snapshot] failed to update vault day data: %w", err)
+	}
+	return nil
+}
+
+func updateVaultBucket(ctx context.Context, db generic.EntityStore, e entity, s *subgraph.VaultSnapshot, vault subgraph.Vault, start, length int64, activity VaultActivity) error {
+	if _, err := e.Load(ctx, db); err != nil {
+		return fmt.Errorf("failed to load: %w", err)
+	}
+
+	s.VaultID = vault.ID
+	s.Timestamp = *types.NewBigIntFromInt64(start)
+	s.TotalDebt = vault.TotalDebt
+	s.TotalIdle = vault.TotalIdle
+	s.TotalShare = vault.TotalShare
+	s.SharePrice = vault.CurrentSharePrice
+
+	one := types.NewBigIntFromInt64(1)
+	if activity.Deposit != nil {
+		s.DepositVolume = *s.DepositVolume.Plus(activity.Deposit)
+		s.DepositCount = *s.DepositCount.Plus(one)
+
+		depositors, err := db.CountEntities(ctx, &subgraph.Deposit{}, "account_id",
+			generic.Eq("vault_id", vault.ID),
+			generic.Gte("timestamp", start),
+			generic.Lt("timestamp", start+length),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to count unique depositors: %w", err)
+		}
+		s.UniqueDepositors = *types.NewBigIntFromInt64(depositors)
+	}
+	if activity.Withdraw != nil {
+		s.WithdrawVolume = *s.WithdrawVolume.Plus(activity.Withdraw)
+		s.WithdrawCount = *s.WithdrawCount.Plus(one)
+	}
+
+	if err := e.Save(ctx, db); err != nil {
+		return fmt.Errorf("failed to save: %w", err)
+	}
+	return nil
+}
+
+// UpdateStrategy refreshes the daily snapshot of the strategy and accumulates the reported gain and loss.
+func UpdateStrategy(ctx context.Context, db generic.EntityStore, strategyID string, timestamp types.BigInt, gain, loss *types.BigInt) error {
+	strategy := subgraph.Strategy{ID: strategyID}
+	ok, err := generic.Load(ctx, db, &strategy)
+	if err != nil {
+		return fmt.Errorf("[snapshot] failed to load strategy: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	ts := timestamp.Int64()
+	dayStart := ts - ts%daySeconds
+	day := subgraph.StrategyDayData{ID: bucketId(strategyID, dayStart, "day")}
+	if _, err := day.Load(ctx, db); err != nil {
+		return fmt.Errorf("[snapshot] failed to load strategy day data: %w", err)
+	}
+
+	day.StrategyID = strategy.ID
+	day.VaultID = strategy.VaultID
+	day.Timestamp = *types.NewBigIntFromInt64(dayStart)
+	day.CurrentDebt = strategy.CurrentDebt
+	day.TotalAssets = strategy.TotalAssets
+	day.Apr = strategy.Apr
+	if gain != nil {
+		day.Gain = *day.Gain.Plus(gain)
+	}
+	if loss != nil {
+		day.Loss = *day.Loss.Plus(loss)
+	}
+
+	if err := day.Save(ctx, db); err != nil {
+		return fmt.Errorf("[snapshot] failed to save strategy day data: %w", err)
+	}
+	return nil
+}
+
+func bucketId(entityID string, start int64, interval string) string {
+	return utils.GenerateId(entityID, strconv.FormatInt(start, 10), interval)
+}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/library/snapshot"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/Tsisar/solana-indexer/internal/utils"
-	"gorm.io/gorm"
 )
 
-func Init(ctx context.Context, db *gorm.DB, ev events.StrategyInitEvent) error {
+func Init(ctx context.Context, db generic.EntityStore, ev events.StrategyInitEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -33,7 +34,7 @@ func Init(ctx context.Context, db *gorm.DB, ev events.StrategyInitEvent) error {
 	return nil
 }
 
-func Deposit(ctx context.Context, db *gorm.DB, ev events.StrategyDepositEvent) error {
+func Deposit(ctx context.Context, db generic.EntityStore, ev events.StrategyDepositEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -46,7 +47,7 @@ func Deposit(ctx context.Context, db *gorm.DB, ev events.StrategyDepositEvent) e
 	return nil
 }
 
-func Withdraw(ctx context.Context, db *gorm.DB, ev events.StrategyWithdrawEvent) error {
+func Withdraw(ctx context.Context, db generic.EntityStore, ev events.StrategyWithdrawEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -59,7 +60,7 @@ func Withdraw(ctx context.Context, db *gorm.DB, ev events.StrategyWithdrawEvent)
 	return nil
 }
 
-func UpdateCurrentDebt(ctx context.Context, db *gorm.DB, ev events.UpdatedCurrentDebtForStrategyEvent) error {
+func UpdateCurrentDebt(ctx context.Context, db generic.EntityStore, ev events.UpdatedCurrentDebtForStrategyEvent, transaction events.Transaction) error {
 	strategy := subgraph.Strategy{ID: ev.StrategyKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -79,10 +80,18 @@ func UpdateCurrentDebt(ctx context.Context, db *gorm.DB, ev events.UpdatedCurren
 		return fmt.Errorf("[strategy] failed to save vault: %w", err)
 	}
 
+	if err := snapshot.UpdateStrategy(ctx, db, strategy.ID, transaction.Timestamp, nil, nil); err != nil {
+		return fmt.Errorf("[strategy] failed to update strategy snapshot: %w", err)
+	}
+
+	if err := snapshot.UpdateVault(ctx, db, vault.ID, transaction.Timestamp, snapshot.VaultActivity{}); err != nil {
+		return fmt.Errorf("[strategy] failed to update vault snapshot: %w", err)
+	}
+
 	return nil
 }
 
-func UpdatePerformanceFee(ctx context.Context, db *gorm.DB, ev events.SetPerformanceFeeEvent) error {
+func UpdatePerformanceFee(ctx context.Context, db generic.EntityStore, ev events.SetPerformanceFeeEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -95,7 +104,7 @@ func UpdatePerformanceFee(ctx context.Context, db *gorm.DB, ev events.SetPerform
 	return nil
 }
 
-func UpdateDTFReport(ctx context.Context, db *gorm.DB, ev events.HarvestAndReportDTFEvent) error {
+func UpdateDTFReport(ctx context.Context, db generic.EntityStore, ev events.HarvestAndReportDTFEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	if _, err := strategy.Load(ctx, db); err != nil {
 		return fmt.Errorf("[strategy] failed to load strategy: %w", err)
@@ -121,7 +130,7 @@ func UpdateDTFReport(ctx context.Context, db *gorm.DB, ev events.HarvestAndRepor
 	return nil
 }
 
-func DeployFunds(ctx context.Context, db *gorm.DB, ev events.StrategyDeployFundsEvent) error {
+func DeployFunds(ctx context.Context, db generic.EntityStore, ev events.StrategyDeployFundsEvent) error {
 	id := utils.GenerateId(ev.AccountKey.String(), ev.Timestamp.String())
 	deployFunds := subgraph.DeployFunds{ID: id}
 	if _, err := deployFunds.Load(ctx, db); err != nil {
@@ -137,7 +146,7 @@ func DeployFunds(ctx context.Context, db *gorm.DB, ev events.StrategyDeployFunds
 	return nil
 }
 
-func FreeFunds(ctx context.Context, db *gorm.DB, ev events.StrategyFreeFundsEvent) error {
+func FreeFunds(ctx context.Context, db generic.EntityStore, ev events.StrategyFreeFundsEvent) error {
 	id := utils.GenerateId(ev.AccountKey.String(), ev.Timestamp.String())
 	freeFunds := subgraph.FreeFunds{ID: id}
 	if _, err := freeFunds.Load(ctx, db); err != nil {
@@ -153,7 +162,7 @@ func FreeFunds(ctx context.Context, db *gorm.DB, ev events.StrategyFreeFundsEven
 	return nil
 }
 
-func AfterOrcaSwap(ctx context.Context, db *gorm.DB, ev events.OrcaAfterSwapEvent) error {
+func AfterOrcaSwap(ctx context.Context, db generic.EntityStore, ev events.OrcaAfterSwapEvent) error {
 	totalAssets := ev.IdleUnderlying.Plus(&ev.TotalInvested)
 	vaultTotalAllocation, err := getTotalAllocationAfterAfterOrcaSwap(ctx, db, ev, totalAssets)
 	if err != nil {
@@ -209,7 +218,7 @@ func AfterOrcaSwap(ctx context.Context, db *gorm.DB, ev events.OrcaAfterSwapEven
 	return nil
 }
 
-func getTotalAllocationAfterAfterOrcaSwap(ctx context.Context, db *gorm.DB, ev events.OrcaAfterSwapEvent, totalAssets *types.BigInt) (*types.BigDecimal, error) {
+func getTotalAllocationAfterAfterOrcaSwap(ctx context.Context, db generic.EntityStore, ev events.OrcaAfterSwapEvent, totalAssets *types.BigInt) (*types.BigDecimal, error) {
 	zero := types.ZeroBigDecimal()
 	vault := subgraph.Vault{ID: ev.Vault.String()}
 	ok, err := vault.Load(ctx, db)
@@ -237,7 +246,7 @@ func getTotalAllocationAfterAfterOrcaSwap(ctx context.Context, db *gorm.DB, ev e
 	return &vault.TotalAllocation, nil
 }
 
-func InitOrca(ctx context.Context, db *gorm.DB, ev events.OrcaInitEvent) error {
+func InitOrca(ctx context.Context, db generic.EntityStore, ev events.OrcaInitEvent) error {
 	strategy := subgraph.Strategy{ID: ev.AccountKey.String()}
 	ok, err := strategy.Load(ctx, db)
 	if err != nil {
@@ -265,7 +274,7 @@ func InitOrca(ctx context.Context, db *gorm.DB, ev events.OrcaInitEvent) error {
 	return nil
 }
 
-func Remove(ctx context.Context, db *gorm.DB, ev events.VaultRemoveStrategyEvent) error {
+func Remove(ctx context.Context, db generic.EntityStore, ev events.VaultRemoveStrategyEvent) error {
 	strategy := subgraph.Strategy{ID: ev.StrategyKey.String()}
 	ok, err := strategy.Load(ctx, db)
 	if err != nil {
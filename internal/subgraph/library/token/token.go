@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
-	"gorm.io/gorm"
 )
 
-func UpsertUnderlyingToken(ctx context.Context, db *gorm.DB, event events.VaultInitEvent) (*subgraph.Token, error) {
+func UpsertUnderlyingToken(ctx context.Context, db generic.EntityStore, event events.VaultInitEvent) (*subgraph.Token, error) {
 	token := subgraph.Token{
 		ID: event.UnderlyingToken.Mint.String(),
 	}
@@ -29,7 +29,7 @@ func UpsertUnderlyingToken(ctx context.Context, db *gorm.DB, event events.VaultI
 	return &token, nil
 }
 
-func UpsertShareToken(ctx context.Context, db *gorm.DB, event events.VaultInitEvent) (*subgraph.Token, error) {
+func UpsertShareToken(ctx context.Context, db generic.EntityStore, event events.VaultInitEvent) (*subgraph.Token, error) {
 	token := subgraph.Token{
 		ID: event.ShareToken.Mint.String(),
 	}
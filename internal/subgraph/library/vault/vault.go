@@ -5,20 +5,20 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/monitoring"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/account"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/accountant"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/report"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/library/snapshot"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/token"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/Tsisar/solana-indexer/internal/utils"
 	"math/big"
-
-	"gorm.io/gorm"
 )
 
-func Init(ctx context.Context, db *gorm.DB, ev events.VaultInitEvent, transaction events.Transaction) error {
+func Init(ctx context.Context, db generic.EntityStore, ev events.VaultInitEvent, transaction events.Transaction) error {
 	var err error
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	if _, err = vault.Load(ctx, db); err != nil {
@@ -61,7 +61,7 @@ func Init(ctx context.Context, db *gorm.DB, ev events.VaultInitEvent, transactio
 	return nil
 }
 
-func AddStrategy(ctx context.Context, db *gorm.DB, ev events.VaultAddStrategyEvent, transaction events.Transaction) error {
+func AddStrategy(ctx context.Context, db generic.EntityStore, ev events.VaultAddStrategyEvent, transaction events.Transaction) error {
 	strategy := subgraph.Strategy{
 		ID: ev.StrategyKey.String(),
 	}
@@ -81,7 +81,7 @@ func AddStrategy(ctx context.Context, db *gorm.DB, ev events.VaultAddStrategyEve
 	return nil
 }
 
-func Deposit(ctx context.Context, db *gorm.DB, ev events.VaultDepositEvent, transaction events.Transaction) error {
+func Deposit(ctx context.Context, db generic.EntityStore, ev events.VaultDepositEvent, transaction events.Transaction) error {
 	// return ev.Authority.String()
 	if err := account.UpdateAccount(ctx, db, ev.Authority.String(), ev.TokenAccount.String(), ev.ShareAccount.String()); err != nil {
 		return fmt.Errorf("[vault] failed to update account: %w", err)
@@ -128,10 +128,14 @@ func Deposit(ctx context.Context, db *gorm.DB, ev events.VaultDepositEvent, tran
 		return fmt.Errorf("[vault] failed to update current share price: %w", err)
 	}
 
+	if err := snapshot.UpdateVault(ctx, db, vault.ID, transaction.Timestamp, snapshot.VaultActivity{Deposit: &ev.Amount}); err != nil {
+		return fmt.Errorf("[vault] failed to update vault snapshot: %w", err)
+	}
+
 	return nil
 }
 
-func StrategyReported(ctx context.Context, db *gorm.DB, ev events.StrategyReportedEvent, transaction events.Transaction) error {
+func StrategyReported(ctx context.Context, db generic.EntityStore, ev events.StrategyReportedEvent, transaction events.Transaction) error {
 	if err := report.CreateReport(ctx, db, ev, transaction); err != nil {
 		return fmt.Errorf("[vault] failed to create report: %w", err)
 	}
@@ -147,10 +151,18 @@ func StrategyReported(ctx context.Context, db *gorm.DB, ev events.StrategyReport
 	if err := UpdateCurrentSharePrice(ctx, db, ev.VaultKey.String(), ev.SharePrice); err != nil {
 		return fmt.Errorf("[vault] failed to update current share price: %w", err)
 	}
+
+	if err := snapshot.UpdateStrategy(ctx, db, ev.StrategyKey.String(), transaction.Timestamp, &ev.Gain, &ev.Loss); err != nil {
+		return fmt.Errorf("[vault] failed to update strategy snapshot: %w", err)
+	}
+
+	if err := snapshot.UpdateVault(ctx, db, ev.VaultKey.String(), transaction.Timestamp, snapshot.VaultActivity{}); err != nil {
+		return fmt.Errorf("[vault] failed to update vault snapshot: %w", err)
+	}
 	return nil
 }
 
-func vaultPositionDeposit(ctx context.Context, db *gorm.DB, ev events.VaultDepositEvent) error {
+func vaultPositionDeposit(ctx context.Context, db generic.EntityStore, ev events.VaultDepositEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	if _, err := vault.Load(ctx, db); err != nil {
 		return fmt.Errorf("[vault] failed to load vault: %w", err)
@@ -201,7 +213,7 @@ func vaultPositionDeposit(ctx context.Context, db *gorm.DB, ev events.VaultDepos
 	return nil
 }
 
-func Withdraw(ctx context.Context, db *gorm.DB, ev events.VaultWithdrawlEvent, transaction events.Transaction) error {
+func Withdraw(ctx context.Context, db generic.EntityStore, ev events.VaultWithdrawlEvent, transaction events.Transaction) error {
 	if err := account.UpdateAccount(ctx, db, ev.Authority.String(), ev.TokenAccount.String(), ev.ShareAccount.String()); err != nil {
 		return fmt.Errorf("[vault] failed to update account: %w", err)
 	}
@@ -248,10 +260,14 @@ func Withdraw(ctx context.Context, db *gorm.DB, ev events.VaultWithdrawlEvent, t
 		return fmt.Errorf("[vault] failed to update current share price: %w", err)
 	}
 
+	if err := snapshot.UpdateVault(ctx, db, vault.ID, transaction.Timestamp, snapshot.VaultActivity{Withdraw: &ev.AssetsToTransfer}); err != nil {
+		return fmt.Errorf("[vault] failed to update vault snapshot: %w", err)
+	}
+
 	return nil
 }
 
-func vaultPositionWithdraw(ctx context.Context, db *gorm.DB, ev events.VaultWithdrawlEvent) error {
+func vaultPositionWithdraw(ctx context.Context, db generic.EntityStore, ev events.VaultWithdrawlEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	if _, err := vault.Load(ctx, db); err != nil {
 		return fmt.Errorf("[vault] failed to load vault: %w", err)
@@ -352,7 +368,7 @@ func GetBalanceTokens(current, withdraw *types.BigInt) *types.BigInt {
 	return current.Sub(withdraw)
 }
 
-func UpdateCurrentSharePrice(ctx context.Context, db *gorm.DB, vaultId string, sharePrice types.BigInt) error {
+func UpdateCurrentSharePrice(ctx context.Context, db generic.EntityStore, vaultId string, sharePrice types.BigInt) error {
 	log.Infof("[vault] Updating current share price...")
 	vault := subgraph.Vault{ID: vaultId}
 	ok, err := vault.Load(ctx, db)
@@ -378,7 +394,7 @@ func UpdateCurrentSharePrice(ctx context.Context, db *gorm.DB, vaultId string, s
 	return nil
 }
 
-func UpdateDepositLimit(ctx context.Context, db *gorm.DB, ev events.VaultUpdateDepositLimitEvent) error {
+func UpdateDepositLimit(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateDepositLimitEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	if _, err := vault.Load(ctx, db); err != nil {
 		return fmt.Errorf("[vault] failed to load vault: %w", err)
@@ -392,7 +408,7 @@ func UpdateDepositLimit(ctx context.Context, db *gorm.DB, ev events.VaultUpdateD
 	return nil
 }
 
-func ShutDown(ctx context.Context, db *gorm.DB, ev events.VaultShutDownEvent) error {
+func ShutDown(ctx context.Context, db generic.EntityStore, ev events.VaultShutDownEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	if _, err := vault.Load(ctx, db); err != nil {
 		return fmt.Errorf("[vault] failed to load vault: %w", err)
@@ -405,7 +421,7 @@ func ShutDown(ctx context.Context, db *gorm.DB, ev events.VaultShutDownEvent) er
 	return nil
 }
 
-func WithdrawalRequested(ctx context.Context, db *gorm.DB, ev events.WithdrawalRequestedEvent) error {
+func WithdrawalRequested(ctx context.Context, db generic.EntityStore, ev events.WithdrawalRequestedEvent) error {
 	id := utils.GenerateId(ev.User.String(), ev.Vault.String(), ev.Index.String())
 
 	withdrawalRequest := subgraph.WithdrawalRequest{ID: id}
@@ -437,7 +453,7 @@ func WithdrawalRequested(ctx context.Context, db *gorm.DB, ev events.WithdrawalR
 	return nil
 }
 
-func WithdrawalRequestFulfilled(ctx context.Context, db *gorm.DB, ev events.WithdrawalRequestFulfilledEvent) error {
+func WithdrawalRequestFulfilled(ctx context.Context, db generic.EntityStore, ev events.WithdrawalRequestFulfilledEvent) error {
 	id := utils.GenerateId(ev.User.String(), ev.Vault.String(), ev.Index.String())
 
 	withdrawalRequest := subgraph.WithdrawalRequest{ID: id}
@@ -466,7 +482,7 @@ func WithdrawalRequestFulfilled(ctx context.Context, db *gorm.DB, ev events.With
 	return nil
 }
 
-func WithdrawalRequestCanceled(ctx context.Context, db *gorm.DB, ev events.WithdrawalRequestCanceledEvent) error {
+func WithdrawalRequestCanceled(ctx context.Context, db generic.EntityStore, ev events.WithdrawalRequestCanceledEvent) error {
 	id := utils.GenerateId(ev.User.String(), ev.Vault.String(), ev.Index.String())
 
 	withdrawalRequest := subgraph.WithdrawalRequest{ID: id}
@@ -494,7 +510,7 @@ func WithdrawalRequestCanceled(ctx context.Context, db *gorm.DB, ev events.Withd
 	return nil
 }
 
-func updatePriorityFeeOnVault(ctx context.Context, db *gorm.DB, vaultID string, fee *types.BigInt, status string) error {
+func updatePriorityFeeOnVault(ctx context.Context, db generic.EntityStore, vaultID string, fee *types.BigInt, status string) error {
 	vault := subgraph.Vault{ID: vaultID}
 	if _, err := vault.Load(ctx, db); err != nil {
 		return fmt.Errorf("[vault] failed to load vault: %w", err)
@@ -508,7 +524,7 @@ func updatePriorityFeeOnVault(ctx context.Context, db *gorm.DB, vaultID string,
 	return nil
 }
 
-func UpdateWhiteListOnly(ctx context.Context, db *gorm.DB, ev events.VaultUpdateWhitelistedOnlyEvent) error {
+func UpdateWhiteListOnly(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateWhitelistedOnlyEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -529,7 +545,7 @@ func UpdateWhiteListOnly(ctx context.Context, db *gorm.DB, ev events.VaultUpdate
 	return nil
 }
 
-func UpdateAccountant(ctx context.Context, db *gorm.DB, ev events.VaultUpdateAccountantEvent) error {
+func UpdateAccountant(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateAccountantEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -550,7 +566,7 @@ func UpdateAccountant(ctx context.Context, db *gorm.DB, ev events.VaultUpdateAcc
 	return nil
 }
 
-func UpdateUserDepositLimit(ctx context.Context, db *gorm.DB, ev events.VaultUpdateUserDepositLimitEvent) error {
+func UpdateUserDepositLimit(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateUserDepositLimitEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -571,7 +587,7 @@ func UpdateUserDepositLimit(ctx context.Context, db *gorm.DB, ev events.VaultUpd
 	return nil
 }
 
-func UpdateDirectWithdrawEnabled(ctx context.Context, db *gorm.DB, ev events.VaultUpdateDirectWithdrawEnabledEvent) error {
+func UpdateDirectWithdrawEnabled(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateDirectWithdrawEnabledEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -592,7 +608,7 @@ func UpdateDirectWithdrawEnabled(ctx context.Context, db *gorm.DB, ev events.Vau
 	return nil
 }
 
-func UpdateMinTotalIdle(ctx context.Context, db *gorm.DB, ev events.VaultUpdateMinTotalIdleEvent) error {
+func UpdateMinTotalIdle(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateMinTotalIdleEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -613,7 +629,7 @@ func UpdateMinTotalIdle(ctx context.Context, db *gorm.DB, ev events.VaultUpdateM
 	return nil
 }
 
-func UpdateProfitMaxUnlockTime(ctx context.Context, db *gorm.DB, ev events.VaultUpdateProfitMaxUnlockTimeEvent) error {
+func UpdateProfitMaxUnlockTime(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateProfitMaxUnlockTimeEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -634,7 +650,7 @@ func UpdateProfitMaxUnlockTime(ctx context.Context, db *gorm.DB, ev events.Vault
 	return nil
 }
 
-func UpdateMinUserDeposit(ctx context.Context, db *gorm.DB, ev events.VaultUpdateMinUserDepositEvent) error {
+func UpdateMinUserDeposit(ctx context.Context, db generic.EntityStore, ev events.VaultUpdateMinUserDepositEvent) error {
 	vault := subgraph.Vault{ID: ev.VaultKey.String()}
 	ok, err := vault.Load(ctx, db)
 	if err != nil {
@@ -0,0 +1,291 @@
+package vault
+
+import (
+	"context"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/library/strategy"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"testing"
+)
+
+func bigInt(v int64) types.BigInt {
+	return *types.NewBigIntFromInt64(v)
+}
+
+func transaction(signature string, slot, timestamp, index int64) events.Transaction {
+	return events.Transaction{
+		Signature:  signature,
+		Slot:       bigInt(slot),
+		Timestamp:  bigInt(timestamp),
+		EventIndex: bigInt(index),
+	}
+}
+
+// fixture is a vault with one strategy in a memory store.
+type fixture struct {
+	ctx        context.Context
+	db         *memory.Store
+	vault      solana.PublicKey
+	strategy   solana.PublicKey
+	token      solana.PublicKey
+	shareToken solana.PublicKey
+	authority  solana.PublicKey
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		ctx:        context.Background(),
+		db:         memory.New(),
+		vault:      solana.NewWallet().PublicKey(),
+		strategy:   solana.NewWallet().PublicKey(),
+		token:      solana.NewWallet().PublicKey(),
+		shareToken: solana.NewWallet().PublicKey(),
+		authority:  solana.NewWallet().PublicKey(),
+	}
+	init := events.VaultInitEvent{
+		VaultKey: f.vault,
+		UnderlyingToken: events.TokenData{
+			Mint:     f.token,
+			Account:  solana.NewWallet().PublicKey(),
+			Decimals: bigInt(6),
+			Metadata: events.TokenMetaData{Name: "USD Coin", Symbol: "USDC"},
+		},
+		Accountant: solana.NewWallet().PublicKey(),
+		ShareToken: events.TokenData{
+			Mint:     f.shareToken,
+			Account:  solana.NewWallet().PublicKey(),
+			Decimals: bigInt(6),
+			Metadata: events.TokenMetaData{Name: "Vault Share", Symbol: "vUSDC"},
+		},
+		DepositLimit:        bigInt(1_000_000_000),
+		UserDepositLimit:    bigInt(0),
+		MinUserDeposit:      bigInt(0),
+		MinimumTotalIdle:    bigInt(0),
+		ProfitMaxUnlockTime: bigInt(0),
+	}
+	if err := Init(f.ctx, f.db, init, transaction("init", 1, 1_700_000_000, 2000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := strategy.Init(f.ctx, f.db, events.StrategyInitEvent{
+		AccountKey:         f.strategy,
+		StrategyType:       "simple",
+		Vault:              f.vault,
+		UnderlyingMint:     f.token,
+		UnderlyingTokenAcc: solana.NewWallet().PublicKey(),
+		UnderlyingDecimals: bigInt(6),
+		DepositLimit:       bigInt(0),
+		DepositPeriodEnds:  bigInt(0),
+		LockPeriodEnds:     bigInt(0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddStrategy(f.ctx, f.db, events.VaultAddStrategyEvent{
+		VaultKey:    f.vault,
+		StrategyKey: f.strategy,
+		CurrentDebt: bigInt(0),
+		MaxDebt:     bigInt(1_000_000_000),
+		LastUpdate:  bigInt(1_700_000_001),
+		IsActive:    true,
+	}, transaction("add", 2, 1_700_000_001, 2000)); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *fixture) deposit(t *testing.T, tx events.Transaction, amount, totalShare int64) {
+	t.Helper()
+	if err := Deposit(f.ctx, f.db, events.VaultDepositEvent{
+		VaultKey:     f.vault,
+		TotalDebt:    bigInt(0),
+		TotalIdle:    bigInt(totalShare),
+		TotalShare:   bigInt(totalShare),
+		Amount:       bigInt(amount),
+		Share:        bigInt(amount),
+		TokenAccount: solana.NewWallet().PublicKey(),
+		ShareAccount: solana.NewWallet().PublicKey(),
+		TokenMint:    f.token,
+		ShareMint:    f.shareToken,
+		Authority:    f.authority,
+		SharePrice:   bigInt(1_000_000),
+		Timestamp:    tx.Timestamp,
+	}, tx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) position(t *testing.T) subgraph.AccountVaultPosition {
+	t.Helper()
+	position := subgraph.AccountVaultPosition{ID: utils.GenerateId(f.vault.String(), f.authority.String())}
+	ok, err := position.Load(f.ctx, f.db)
+	if err != nil || !ok {
+		t.Fatalf("failed to load the vault position: %v", err)
+	}
+	return position
+}
+
+func TestDeposit(t *testing.T) {
+	f := newFixture(t)
+	first := transaction("deposit-1", 10, 1_700_000_010, 2001)
+	f.deposit(t, first, 1_000_000, 1_000_000)
+	f.deposit(t, transaction("deposit-2", 11, 1_700_000_011, 2000), 500_000, 1_500_000)
+
+	deposit := subgraph.Deposit{ID: utils.GenerateId("deposit-1", "2001")}
+	ok, err := deposit.Load(f.ctx, f.db)
+	if err != nil || !ok {
+		t.Fatalf("failed to load the deposit: %v", err)
+	}
+	if deposit.TokenAmount.String() != "1000000" || deposit.VaultID != f.vault.String() ||
+		deposit.AccountID != f.authority.String() || deposit.BlockNumber.String() != "10" {
+		t.Errorf("unexpected deposit %+v", deposit)
+	}
+
+	vault := subgraph.Vault{ID: f.vault.String()}
+	if ok, err := vault.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the vault: %v", err)
+	}
+	if vault.TotalIdle.String() != "1500000" || vault.TotalShare.String() != "1500000" ||
+		vault.CurrentSharePrice.String() != "1000000" {
+		t.Errorf("unexpected vault totals idle %s, shares %s, price %s", vault.TotalIdle, vault.TotalShare, vault.CurrentSharePrice)
+	}
+
+	shareToken := subgraph.Token{ID: f.shareToken.String()}
+	if ok, err := shareToken.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the share token: %v", err)
+	}
+	if shareToken.CurrentPrice.String() != "1000000" {
+		t.Errorf("expected share token price 1000000, got %s", shareToken.CurrentPrice)
+	}
+
+	position := f.position(t)
+	if position.BalanceTokens.String() != "1500000" || position.BalanceShares.String() != "1500000" ||
+		position.AccountID != f.authority.String() || position.ShareTokenID != f.shareToken.String() {
+		t.Errorf("unexpected position %+v", position)
+	}
+
+	deposits, err := f.db.CountEntities(f.ctx, &subgraph.Deposit{}, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposits != 2 {
+		t.Errorf("expected 2 deposits, got %d", deposits)
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	f := newFixture(t)
+	f.deposit(t, transaction("deposit", 10, 1_700_000_010, 2000), 1_000_000, 1_000_000)
+
+	tx := transaction("withdraw", 12, 1_700_000_012, 2003)
+	if err := Withdraw(f.ctx, f.db, events.VaultWithdrawlEvent{
+		VaultKey:         f.vault,
+		TotalIdle:        bigInt(0),
+		TotalShare:       bigInt(0),
+		AssetsToTransfer: bigInt(1_200_000),
+		SharesToBurn:     bigInt(1_000_000),
+		TokenAccount:     solana.NewWallet().PublicKey(),
+		ShareAccount:     solana.NewWallet().PublicKey(),
+		TokenMint:        f.token,
+		ShareMint:        f.shareToken,
+		Authority:        f.authority,
+		SharePrice:       bigInt(1_200_000),
+		Timestamp:        tx.Timestamp,
+	}, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	withdrawal := subgraph.Withdrawal{ID: utils.GenerateId("withdraw", "2003")}
+	ok, err := withdrawal.Load(f.ctx, f.db)
+	if err != nil || !ok {
+		t.Fatalf("failed to load the withdrawal: %v", err)
+	}
+	if withdrawal.TokenAmount.String() != "1200000" || withdrawal.SharesBurnt.String() != "1000000" {
+		t.Errorf("unexpected withdrawal %+v", withdrawal)
+	}
+
+	// All shares are withdrawn. The token balance is cleared before the profit is computed,
+	// so the whole withdrawal counts as profit.
+	position := f.position(t)
+	if position.BalanceShares.String() != "0" || position.BalanceTokens.String() != "0" ||
+		position.BalanceProfit.String() != "1200000" {
+		t.Errorf("unexpected position shares %s, tokens %s, profit %s",
+			position.BalanceShares, position.BalanceTokens, position.BalanceProfit)
+	}
+
+	vault := subgraph.Vault{ID: f.vault.String()}
+	if ok, err := vault.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the vault: %v", err)
+	}
+	if vault.TotalShare.String() != "0" || vault.CurrentSharePrice.String() != "1200000" {
+		t.Errorf("unexpected vault shares %s, price %s", vault.TotalShare, vault.CurrentSharePrice)
+	}
+}
+
+func TestStrategyReported(t *testing.T) {
+	f := newFixture(t)
+	f.deposit(t, transaction("deposit", 10, 1_700_000_010, 2000), 1_000_000, 1_000_000)
+
+	report := func(signature string, timestamp, gain int64) events.Transaction {
+		tx := transaction(signature, timestamp-1_700_000_000, timestamp, 2000)
+		if err := StrategyReported(f.ctx, f.db, events.StrategyReportedEvent{
+			VaultKey:     f.vault,
+			StrategyKey:  f.strategy,
+			Gain:         bigInt(gain),
+			Loss:         bigInt(0),
+			CurrentDebt:  bigInt(1_000_000),
+			ProtocolFees: bigInt(0),
+			TotalFees:    bigInt(0),
+			TotalShares:  bigInt(1_000_000),
+			SharePrice:   bigInt(1_000_000 + gain),
+			Timestamp:    bigInt(timestamp),
+		}, tx); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	report("report-1", 1_700_000_100, 1_000)
+	report("report-2", 1_700_086_500, 2_000)
+
+	first := utils.GenerateId("report-1", f.strategy.String())
+	second := utils.GenerateId("report-2", f.strategy.String())
+	for _, id := range []string{first, second} {
+		r := subgraph.StrategyReport{ID: id}
+		if ok, err := r.Load(f.ctx, f.db); err != nil || !ok {
+			t.Fatalf("failed to load report %s: %v", id, err)
+		}
+		if r.StrategyID != f.strategy.String() || r.VaultKey != f.vault.String() {
+			t.Errorf("unexpected report %+v", r)
+		}
+	}
+
+	s := subgraph.Strategy{ID: f.strategy.String()}
+	if ok, err := s.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the strategy: %v", err)
+	}
+	if s.LatestReportID == nil || *s.LatestReportID != second {
+		t.Errorf("expected the latest report %s, got %v", second, s.LatestReportID)
+	}
+	if s.ReportsCount.String() != "1" {
+		t.Errorf("expected 1 report result, got %s", s.ReportsCount)
+	}
+
+	result := subgraph.StrategyReportResult{ID: utils.GenerateId("report-2", first, second)}
+	if ok, err := result.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the report result: %v", err)
+	}
+	if result.PreviousReportID != first || result.CurrentReportID != second {
+		t.Errorf("unexpected report result %+v", result)
+	}
+
+	vault := subgraph.Vault{ID: f.vault.String()}
+	if ok, err := vault.Load(f.ctx, f.db); err != nil || !ok {
+		t.Fatalf("failed to load the vault: %v", err)
+	}
+	if vault.CurrentSharePrice.String() != "1002000" {
+		t.Errorf("expected share price 1002000, got %s", vault.CurrentSharePrice)
+	}
+}
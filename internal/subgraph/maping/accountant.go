@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/accountant"
-	"gorm.io/gorm"
 )
 
-func mapEntryFeeUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapEntryFeeUpdatedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] EntryFeeUpdatedEvent: %s", event.TransactionSignature)
 	var ev events.EntryFeeUpdatedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -23,7 +23,7 @@ func mapEntryFeeUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapPerformanceFeeUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapPerformanceFeeUpdatedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] PerformanceFeeUpdatedEvent: %s", event.TransactionSignature)
 	var ev events.PerformanceFeeUpdatedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -35,7 +35,7 @@ func mapPerformanceFeeUpdatedEvent(ctx context.Context, db *gorm.DB, event core.
 	return nil
 }
 
-func mapRedemptionFeeUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapRedemptionFeeUpdatedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] RedemptionFeeUpdatedEvent: %s", event.TransactionSignature)
 	var ev events.RedemptionFeeUpdatedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
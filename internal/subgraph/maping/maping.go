@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
-	"gorm.io/gorm"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 )
 
-func Event(ctx context.Context, db *gorm.DB, event core.Event) error {
+func Event(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	pretty, _ := json.MarshalIndent(event.JsonEv, "", "  ")
 	log.Debugf(`[Event] Parsed event:
 ────────────────────────────────────────────────────────────────────
@@ -26,7 +26,7 @@ Signature: %s
 	return nil
 }
 
-func Instruction(ctx context.Context, db *gorm.DB, event core.Event) error {
+func Instruction(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	pretty, _ := json.MarshalIndent(event.JsonEv, "", "  ")
 	log.Debugf(`[Instruction] Parsed instruction:
 ────────────────────────────────────────────────────────────────────
@@ -43,7 +43,7 @@ Signature: %s
 	return nil
 }
 
-func Metadata(ctx context.Context, db *gorm.DB, signature string, slot uint64, blockTime int64) error {
+func Metadata(ctx context.Context, db generic.EntityStore, signature string, slot uint64, blockTime int64) error {
 	if err := updateMeta(ctx, db, signature, slot, blockTime); err != nil {
 		return fmt.Errorf("failed to update meta: %w", err)
 	}
@@ -0,0 +1,240 @@
+package maping
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"github.com/Tsisar/solana-indexer/internal/utils"
+	"github.com/gagliardetto/solana-go"
+	"testing"
+)
+
+func bigInt(v int64) types.BigInt {
+	return *types.NewBigIntFromInt64(v)
+}
+
+// event returns the core event of a decoded event, as the parser stores it.
+func event(t *testing.T, name, signature string, slot uint64, logIndex int, ev any) core.Event {
+	t.Helper()
+	data, err := json.Marshal(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return core.Event{
+		TransactionSignature: signature,
+		LogIndex:             logIndex,
+		BlockTime:            1_700_000_000 + int64(slot),
+		Slot:                 slot,
+		Name:                 name,
+		JsonEv:               data,
+	}
+}
+
+func TestEventMapsVaultLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	vault := solana.NewWallet().PublicKey()
+	strategy := solana.NewWallet().PublicKey()
+	token := solana.NewWallet().PublicKey()
+	shareToken := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	shareAccount := solana.NewWallet().PublicKey()
+
+	deposit := events.VaultDepositEvent{
+		VaultKey:     vault,
+		TotalDebt:    bigInt(0),
+		TotalIdle:    bigInt(1_000_000),
+		TotalShare:   bigInt(1_000_000),
+		Amount:       bigInt(1_000_000),
+		Share:        bigInt(1_000_000),
+		TokenAccount: solana.NewWallet().PublicKey(),
+		ShareAccount: shareAccount,
+		TokenMint:    token,
+		ShareMint:    shareToken,
+		Authority:    authority,
+		SharePrice:   bigInt(1_000_000),
+		Timestamp:    bigInt(1_700_000_003),
+	}
+	report := events.StrategyReportedEvent{
+		VaultKey:     vault,
+		StrategyKey:  strategy,
+		Gain:         bigInt(10_000),
+		Loss:         bigInt(0),
+		CurrentDebt:  bigInt(500_000),
+		ProtocolFees: bigInt(0),
+		TotalFees:    bigInt(0),
+		TotalShares:  bigInt(1_000_000),
+		SharePrice:   bigInt(1_010_000),
+		Timestamp:    bigInt(1_700_000_004),
+	}
+	withdrawal := events.VaultWithdrawlEvent{
+		VaultKey:         vault,
+		TotalIdle:        bigInt(500_000),
+		TotalShare:       bigInt(500_000),
+		AssetsToTransfer: bigInt(505_000),
+		SharesToBurn:     bigInt(500_000),
+		TokenAccount:     deposit.TokenAccount,
+		ShareAccount:     shareAccount,
+		TokenMint:        token,
+		ShareMint:        shareToken,
+		Authority:        authority,
+		SharePrice:       bigInt(1_010_000),
+		Timestamp:        bigInt(1_700_000_005),
+	}
+	for _, e := range []core.Event{
+		event(t, "VaultInitEvent", "init", 1, 2000, events.VaultInitEvent{
+			VaultKey: vault,
+			UnderlyingToken: events.TokenData{
+				Mint: token, Account: solana.NewWallet().PublicKey(), Decimals: bigInt(6),
+				Metadata: events.TokenMetaData{Name: "USD Coin", Symbol: "USDC"},
+			},
+			Accountant: solana.NewWallet().PublicKey(),
+			ShareToken: events.TokenData{
+				Mint: shareToken, Account: solana.NewWallet().PublicKey(), Decimals: bigInt(6),
+				Metadata: events.TokenMetaData{Name: "Vault Share", Symbol: "vUSDC"},
+			},
+			DepositLimit:        bigInt(1_000_000_000),
+			UserDepositLimit:    bigInt(0),
+			MinUserDeposit:      bigInt(0),
+			MinimumTotalIdle:    bigInt(0),
+			ProfitMaxUnlockTime: bigInt(0),
+		}),
+		event(t, "StrategyInitEvent", "strategy", 2, 2000, events.StrategyInitEvent{
+			AccountKey:         strategy,
+			StrategyType:       "simple",
+			Vault:              vault,
+			UnderlyingMint:     token,
+			UnderlyingTokenAcc: solana.NewWallet().PublicKey(),
+			UnderlyingDecimals: bigInt(6),
+			DepositLimit:       bigInt(0),
+			DepositPeriodEnds:  bigInt(0),
+			LockPeriodEnds:     bigInt(0),
+		}),
+		event(t, "VaultAddStrategyEvent", "add", 2, 2001, events.VaultAddStrategyEvent{
+			VaultKey:    vault,
+			StrategyKey: strategy,
+			CurrentDebt: bigInt(0),
+			MaxDebt:     bigInt(1_000_000_000),
+			LastUpdate:  bigInt(1_700_000_002),
+			IsActive:    true,
+		}),
+		event(t, "VaultDepositEvent", "deposit", 3, 2000, deposit),
+		event(t, "StrategyReportedEvent", "report", 4, 2000, report),
+		event(t, "VaultWithdrawlEvent", "withdraw", 5, 2000, withdrawal),
+	} {
+		if err := Event(ctx, db, e); err != nil {
+			t.Fatalf("failed to map %s: %v", e.Name, err)
+		}
+	}
+
+	v := subgraph.Vault{ID: vault.String()}
+	if ok, err := v.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the vault: %v", err)
+	}
+	if v.TokenID != token.String() || v.ShareTokenID != shareToken.String() {
+		t.Errorf("expected tokens %s and %s, got %s and %s", token, shareToken, v.TokenID, v.ShareTokenID)
+	}
+	if len(v.Strategies) != 1 || v.Strategies[0].ID != strategy.String() {
+		t.Errorf("expected strategy %s, got %v", strategy, v.Strategies)
+	}
+	if len(v.Deposits) != 1 || v.Deposits[0].ID != utils.GenerateId("deposit", "2000") {
+		t.Errorf("expected one deposit, got %v", v.Deposits)
+	}
+	if len(v.Withdrawals) != 1 || v.Withdrawals[0].TokenAmount.String() != "505000" {
+		t.Errorf("expected one withdrawal of 505000, got %v", v.Withdrawals)
+	}
+	if v.TotalShare.String() != "500000" || v.CurrentSharePrice.String() != "1010000" {
+		t.Errorf("unexpected vault shares %s, price %s", v.TotalShare, v.CurrentSharePrice)
+	}
+
+	r := subgraph.StrategyReport{ID: utils.GenerateId("report", strategy.String())}
+	if ok, err := r.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the report: %v", err)
+	}
+	if r.Gain.String() != "10000" || r.BlockNumber.String() != "4" {
+		t.Errorf("unexpected report gain %s in slot %s", r.Gain, r.BlockNumber)
+	}
+
+	position := subgraph.AccountVaultPosition{ID: utils.GenerateId(vault.String(), authority.String())}
+	if ok, err := position.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the position: %v", err)
+	}
+	if position.BalanceShares.String() != "500000" || position.BalanceTokens.String() != "495000" {
+		t.Errorf("unexpected position shares %s, tokens %s", position.BalanceShares, position.BalanceTokens)
+	}
+}
+
+func TestInstructionMapsShareTokenMint(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	shareToken := subgraph.Token{ID: "share-mint"}
+	if err := shareToken.Save(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	vault := subgraph.Vault{ID: "vault", ShareToken: &shareToken}
+	if err := vault.Save(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	mint := func(signature, mint string) {
+		t.Helper()
+		e := event(t, "MintToInstruction", signature, 7, 0, events.MintToInstruction{
+			To:     "holder",
+			Mint:   mint,
+			Amount: types.NewBigDecimalFromFloat(250),
+		})
+		if err := Instruction(ctx, db, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mint("first", "share-mint")
+	mint("second", "share-mint")
+	mint("other", "other-mint")
+
+	holder := subgraph.ShareToken{ID: "holder"}
+	if ok, err := holder.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the share token: %v", err)
+	}
+	if holder.TotalMinted.String() != "500" {
+		t.Errorf("expected 500 minted, got %s", holder.TotalMinted)
+	}
+	if count, _ := db.CountEntities(ctx, &subgraph.TokenMint{}, "id"); count != 2 {
+		t.Errorf("expected 2 token mints, got %d", count)
+	}
+}
+
+func TestEventWithoutMapping(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	if err := Event(ctx, db, event(t, "UnknownEvent", "sig", 1, 2000, map[string]string{})); err != nil {
+		t.Fatal(err)
+	}
+	bad := event(t, "VaultDepositEvent", "sig", 1, 2000, nil)
+	bad.JsonEv = []byte("{")
+	if err := Event(ctx, db, bad); err == nil {
+		t.Error("expected an error for an undecodable event")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	if err := Metadata(ctx, db, "sig", 42, 1_700_000_042); err != nil {
+		t.Fatal(err)
+	}
+	var blocks []subgraph.BlockInfo
+	if err := db.FindEntities(ctx, &blocks); err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("expected one block, got %v", blocks)
+	}
+	block := blocks[0]
+	if block.Number != 42 || block.Hash != "sig" || block.Timestamp != 1_700_000_042 {
+		t.Errorf("unexpected block %+v", block)
+	}
+}
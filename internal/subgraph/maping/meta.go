@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/Tsisar/solana-indexer/internal/config"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
 	"gorm.io/gorm"
 )
 
-func updateMeta(ctx context.Context, db *gorm.DB, signature string, slot uint64, blockTime int64) error {
+func updateMeta(ctx context.Context, db generic.EntityStore, signature string, slot uint64, blockTime int64) error {
 	meta := subgraph.Meta{
 		ID:                1,
 		Deployment:        fmt.Sprintf("solana-indexer %s", config.App.Version),
@@ -33,7 +34,7 @@ func updateMeta(ctx context.Context, db *gorm.DB, signature string, slot uint64,
 	return nil
 }
 
-func Error(ctx context.Context, db *gorm.DB, err error) error {
+func Error(ctx context.Context, db generic.EntityStore, err error) error {
 	meta := subgraph.Meta{
 		ID:                1,
 		Deployment:        "solana-indexer",
@@ -53,3 +54,17 @@ func Error(ctx context.Context, db *gorm.DB, err error) error {
 	}
 	return nil
 }
+
+// ClearError resets the indexing error flag of _meta, e.g. after a manual fix.
+func ClearError(ctx context.Context, db *gorm.DB) error {
+	if err := db.WithContext(ctx).
+		Model(&subgraph.Meta{}).
+		Where("id = ?", 1).
+		Updates(map[string]interface{}{
+			"has_indexing_errors": false,
+			"error_message":       "",
+		}).Error; err != nil {
+		return fmt.Errorf("failed to clear meta error: %w", err)
+	}
+	return nil
+}
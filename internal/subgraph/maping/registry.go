@@ -4,10 +4,10 @@ import (
 	"context"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
-	"gorm.io/gorm"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 )
 
-type EventMapper func(ctx context.Context, db *gorm.DB, event core.Event) error
+type EventMapper func(ctx context.Context, db generic.EntityStore, event core.Event) error
 
 var registry = map[string]EventMapper{
 	"EntryFeeUpdatedEvent":                  mapEntryFeeUpdatedEvent,       //Done
@@ -60,7 +60,7 @@ var registry = map[string]EventMapper{
 	"InitializeAccountInstruction":          mapInitializeAccount3Instruction,   //Done
 }
 
-func mapEvents(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapEvents(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	if handler, ok := registry[event.Name]; ok {
 		return handler(ctx, db, event)
 	}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/strategy"
-	"gorm.io/gorm"
 )
 
-func mapDepositLimitSetEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapDepositLimitSetEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] DepositLimitSetEvent: %s", event.TransactionSignature)
 	var ev events.DepositLimitSetEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -21,7 +21,7 @@ func mapDepositLimitSetEvent(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapEmergencyWithdrawEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] EmergencyWithdrawEvent: %s", event.TransactionSignature)
 	var ev events.EmergencyWithdrawEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -31,7 +31,7 @@ func mapEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Even
 	return nil
 }
 
-func mapFundManagerDeployFundsEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapFundManagerDeployFundsEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] FundManagerDeployFundsEvent: %s", event.TransactionSignature)
 	var ev events.FundManagerDeployFundsEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -41,7 +41,7 @@ func mapFundManagerDeployFundsEvent(ctx context.Context, db *gorm.DB, event core
 	return nil
 }
 
-func mapFundManagerEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapFundManagerEmergencyWithdrawEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] FundManagerEmergencyWithdrawEvent: %s", event.TransactionSignature)
 	var ev events.FundManagerEmergencyWithdrawEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -51,7 +51,7 @@ func mapFundManagerEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, even
 	return nil
 }
 
-func mapFundManagerFreeFundsEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapFundManagerFreeFundsEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] FundManagerFreeFundsEvent: %s", event.TransactionSignature)
 	var ev events.FundManagerFreeFundsEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -61,7 +61,7 @@ func mapFundManagerFreeFundsEvent(ctx context.Context, db *gorm.DB, event core.E
 	return nil
 }
 
-func mapFundManagerHarvestAndReportEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapFundManagerHarvestAndReportEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] FundManagerHarvestAndReportEvent: %s", event.TransactionSignature)
 	var ev events.FundManagerHarvestAndReportEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -71,7 +71,7 @@ func mapFundManagerHarvestAndReportEvent(ctx context.Context, db *gorm.DB, event
 	return nil
 }
 
-func mapFundManagerStrategyStateUpdateEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapFundManagerStrategyStateUpdateEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] FundManagerStrategyStateUpdateEvent: %s", event.TransactionSignature)
 	var ev events.FundManagerStrategyStateUpdateEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -81,7 +81,7 @@ func mapFundManagerStrategyStateUpdateEvent(ctx context.Context, db *gorm.DB, ev
 	return nil
 }
 
-func mapHarvestAndReportDTFEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapHarvestAndReportDTFEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] HarvestAndReportDTFEvent: %s", event.TransactionSignature)
 	var ev events.HarvestAndReportDTFEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -93,7 +93,7 @@ func mapHarvestAndReportDTFEvent(ctx context.Context, db *gorm.DB, event core.Ev
 	return nil
 }
 
-func mapMinDeployAmountSetEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapMinDeployAmountSetEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] MinDeployAmountSetEvent: %s", event.TransactionSignature)
 	var ev events.MinDeployAmountSetEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -103,7 +103,7 @@ func mapMinDeployAmountSetEvent(ctx context.Context, db *gorm.DB, event core.Eve
 	return nil
 }
 
-func mapOrcaAfterSwapEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapOrcaAfterSwapEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] OrcaAfterSwapEvent: %s", event.TransactionSignature)
 	var ev events.OrcaAfterSwapEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -115,7 +115,7 @@ func mapOrcaAfterSwapEvent(ctx context.Context, db *gorm.DB, event core.Event) e
 	return nil
 }
 
-func mapOrcaInitEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapOrcaInitEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] OrcaInitEvent: %s", event.TransactionSignature)
 	var ev events.OrcaInitEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -127,7 +127,7 @@ func mapOrcaInitEvent(ctx context.Context, db *gorm.DB, event core.Event) error
 	return nil
 }
 
-func mapSetPerformanceFeeEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapSetPerformanceFeeEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] SetPerformanceFeeEvent: %s", event.TransactionSignature)
 	var ev events.SetPerformanceFeeEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -139,7 +139,7 @@ func mapSetPerformanceFeeEvent(ctx context.Context, db *gorm.DB, event core.Even
 	return nil
 }
 
-func mapStrategyDeployFundsEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyDeployFundsEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyDeployFundsEvent: %s", event.TransactionSignature)
 	var ev events.StrategyDeployFundsEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -152,7 +152,7 @@ func mapStrategyDeployFundsEvent(ctx context.Context, db *gorm.DB, event core.Ev
 	return nil
 }
 
-func mapStrategyDepositEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyDepositEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyDepositEvent: %s", event.TransactionSignature)
 	var ev events.StrategyDepositEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -165,7 +165,7 @@ func mapStrategyDepositEvent(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapStrategyFreeFundsEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyFreeFundsEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyFreeFundsEvent: %s", event.TransactionSignature)
 	var ev events.StrategyFreeFundsEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -177,7 +177,7 @@ func mapStrategyFreeFundsEvent(ctx context.Context, db *gorm.DB, event core.Even
 	return nil
 }
 
-func mapStrategyInitEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyInitEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyInitEvent: %s", event.TransactionSignature)
 	var ev events.StrategyInitEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -189,7 +189,7 @@ func mapStrategyInitEvent(ctx context.Context, db *gorm.DB, event core.Event) er
 	return nil
 }
 
-func mapStrategyReallocEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyReallocEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyReallocEvent: %s", event.TransactionSignature)
 	var ev events.StrategyReallocEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -199,7 +199,7 @@ func mapStrategyReallocEvent(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapStrategyShutdownEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyShutdownEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyShutdownEvent: %s", event.TransactionSignature)
 	var ev events.StrategyShutdownEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -209,7 +209,7 @@ func mapStrategyShutdownEvent(ctx context.Context, db *gorm.DB, event core.Event
 	return nil
 }
 
-func mapStrategyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyWithdrawEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] StrategyWithdrawEvent: %s", event.TransactionSignature)
 	var ev events.StrategyWithdrawEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -222,7 +222,7 @@ func mapStrategyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Event
 	return nil
 }
 
-func mapTotalInvestedUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapTotalInvestedUpdatedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[mapping] TotalInvestedUpdatedEvent: %s", event.TransactionSignature)
 	var ev events.TotalInvestedUpdatedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
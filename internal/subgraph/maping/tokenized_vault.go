@@ -6,14 +6,14 @@ import (
 	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/shareToken"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/strategy"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/library/vault"
-	"gorm.io/gorm"
 )
 
-func mapStrategyReportedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapStrategyReportedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] StrategyReportedEvent: %s", event.TransactionSignature)
 	var ev events.StrategyReportedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -30,20 +30,20 @@ func mapStrategyReportedEvent(ctx context.Context, db *gorm.DB, event core.Event
 	return nil
 }
 
-func mapUpdatedCurrentDebtForStrategyEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapUpdatedCurrentDebtForStrategyEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] UpdatedCurrentDebtForStrategyEvent: %s", event.TransactionSignature)
 	var ev events.UpdatedCurrentDebtForStrategyEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
 		return fmt.Errorf("[maping] failed to decode UpdatedCurrentDebtForStrategyEvent: %w", err)
 	}
 
-	if err := strategy.UpdateCurrentDebt(ctx, db, ev); err != nil {
+	if err := strategy.UpdateCurrentDebt(ctx, db, ev, events.NewTransaction(event)); err != nil {
 		return fmt.Errorf("[maping] failed to update current debt: %w", err)
 	}
 	return nil
 }
 
-func mapVaultAddStrategyEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultAddStrategyEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultAddStrategyEvent: %s", event.TransactionSignature)
 	var ev events.VaultAddStrategyEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -58,7 +58,7 @@ func mapVaultAddStrategyEvent(ctx context.Context, db *gorm.DB, event core.Event
 	return nil
 }
 
-func mapVaultDepositEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultDepositEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultDepositEvent: %s", event.TransactionSignature)
 	var ev events.VaultDepositEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -73,7 +73,7 @@ func mapVaultDepositEvent(ctx context.Context, db *gorm.DB, event core.Event) er
 	return nil
 }
 
-func mapVaultEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultEmergencyWithdrawEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultEmergencyWithdrawEvent: %s", event.TransactionSignature)
 	var ev events.VaultEmergencyWithdrawEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -83,7 +83,7 @@ func mapVaultEmergencyWithdrawEvent(ctx context.Context, db *gorm.DB, event core
 	return nil
 }
 
-func mapVaultInitEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultInitEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultInitEvent: %s", event.TransactionSignature)
 	var ev events.VaultInitEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -99,7 +99,7 @@ func mapVaultInitEvent(ctx context.Context, db *gorm.DB, event core.Event) error
 	return nil
 }
 
-func mapVaultRemoveStrategyEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultRemoveStrategyEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultRemoveStrategyEvent: %s", event.TransactionSignature)
 	var ev events.VaultRemoveStrategyEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -111,7 +111,7 @@ func mapVaultRemoveStrategyEvent(ctx context.Context, db *gorm.DB, event core.Ev
 	return nil
 }
 
-func mapVaultShutDownEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultShutDownEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultShutDownEvent: %s", event.TransactionSignature)
 	var ev events.VaultShutDownEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -124,7 +124,7 @@ func mapVaultShutDownEvent(ctx context.Context, db *gorm.DB, event core.Event) e
 	return nil
 }
 
-func mapVaultUpdateAccountantEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateAccountantEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateAccountantEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateAccountantEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -136,7 +136,7 @@ func mapVaultUpdateAccountantEvent(ctx context.Context, db *gorm.DB, event core.
 	return nil
 }
 
-func mapVaultUpdateDepositLimitEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateDepositLimitEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateDepositLimitEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateDepositLimitEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -149,7 +149,7 @@ func mapVaultUpdateDepositLimitEvent(ctx context.Context, db *gorm.DB, event cor
 	return nil
 }
 
-func mapVaultUpdateDirectWithdrawEnabledEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateDirectWithdrawEnabledEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateDirectWithdrawEnabledEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateDirectWithdrawEnabledEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -161,7 +161,7 @@ func mapVaultUpdateDirectWithdrawEnabledEvent(ctx context.Context, db *gorm.DB,
 	return nil
 }
 
-func mapVaultUpdateMinTotalIdleEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateMinTotalIdleEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateMinTotalIdleEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateMinTotalIdleEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -173,7 +173,7 @@ func mapVaultUpdateMinTotalIdleEvent(ctx context.Context, db *gorm.DB, event cor
 	return nil
 }
 
-func mapVaultUpdateMinUserDepositEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateMinUserDepositEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateMinUserDepositEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateMinUserDepositEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -185,7 +185,7 @@ func mapVaultUpdateMinUserDepositEvent(ctx context.Context, db *gorm.DB, event c
 	return nil
 }
 
-func mapVaultUpdateProfitMaxUnlockTimeEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateProfitMaxUnlockTimeEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateProfitMaxUnlockTimeEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateProfitMaxUnlockTimeEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -197,7 +197,7 @@ func mapVaultUpdateProfitMaxUnlockTimeEvent(ctx context.Context, db *gorm.DB, ev
 	return nil
 }
 
-func mapVaultUpdateUserDepositLimitEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateUserDepositLimitEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateUserDepositLimitEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateUserDepositLimitEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -209,7 +209,7 @@ func mapVaultUpdateUserDepositLimitEvent(ctx context.Context, db *gorm.DB, event
 	return nil
 }
 
-func mapVaultUpdateWhitelistedOnlyEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultUpdateWhitelistedOnlyEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultUpdateWhitelistedOnlyEvent: %s", event.TransactionSignature)
 	var ev events.VaultUpdateWhitelistedOnlyEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -221,7 +221,7 @@ func mapVaultUpdateWhitelistedOnlyEvent(ctx context.Context, db *gorm.DB, event
 	return nil
 }
 
-func mapVaultWithdrawlEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapVaultWithdrawlEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] VaultWithdrawlEvent: %s", event.TransactionSignature)
 	var ev events.VaultWithdrawlEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -236,7 +236,7 @@ func mapVaultWithdrawlEvent(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapWhitelistUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapWhitelistUpdatedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] WhitelistUpdatedEvent: %s", event.TransactionSignature)
 	var ev events.WhitelistUpdatedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -246,7 +246,7 @@ func mapWhitelistUpdatedEvent(ctx context.Context, db *gorm.DB, event core.Event
 	return nil
 }
 
-func mapWithdrawalRequestCanceledEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapWithdrawalRequestCanceledEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] WithdrawalRequestCanceledEvent: %s", event.TransactionSignature)
 	var ev events.WithdrawalRequestCanceledEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -258,7 +258,7 @@ func mapWithdrawalRequestCanceledEvent(ctx context.Context, db *gorm.DB, event c
 	return nil
 }
 
-func mapWithdrawalRequestFulfilledEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapWithdrawalRequestFulfilledEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] WithdrawalRequestFulfilledEvent: %s", event.TransactionSignature)
 	var ev events.WithdrawalRequestFulfilledEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -270,7 +270,7 @@ func mapWithdrawalRequestFulfilledEvent(ctx context.Context, db *gorm.DB, event
 	return nil
 }
 
-func mapWithdrawalRequestedEvent(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapWithdrawalRequestedEvent(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] WithdrawalRequestedEvent: %s", event.TransactionSignature)
 	var ev events.WithdrawalRequestedEvent
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -282,7 +282,7 @@ func mapWithdrawalRequestedEvent(ctx context.Context, db *gorm.DB, event core.Ev
 	return nil
 }
 
-func mapMintToInstruction(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapMintToInstruction(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] MintToInstruction: %s", event.TransactionSignature)
 	var ev events.MintToInstruction
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -296,7 +296,7 @@ func mapMintToInstruction(ctx context.Context, db *gorm.DB, event core.Event) er
 	return nil
 }
 
-func mapBurnInstruction(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapBurnInstruction(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] BurnInstruction: %s", event.TransactionSignature)
 	var ev events.BurnInstruction
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -310,7 +310,7 @@ func mapBurnInstruction(ctx context.Context, db *gorm.DB, event core.Event) erro
 	return nil
 }
 
-func mapTransferInstruction(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapTransferInstruction(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] TransferInstruction: %s", event.TransactionSignature)
 	var ev events.TransferInstruction
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -324,7 +324,7 @@ func mapTransferInstruction(ctx context.Context, db *gorm.DB, event core.Event)
 	return nil
 }
 
-func mapInitializeAccount3Instruction(ctx context.Context, db *gorm.DB, event core.Event) error {
+func mapInitializeAccount3Instruction(ctx context.Context, db generic.EntityStore, event core.Event) error {
 	log.Infof("[maping] InitializeAccountInstruction: %s", event.TransactionSignature)
 	var ev events.InitializeAccountInstruction
 	if err := json.Unmarshal(event.JsonEv, &ev); err != nil {
@@ -2,52 +2,157 @@ package subgraph
 
 import (
 	"context"
+	"fmt"
 	"github.com/Tsisar/extended-log-go/log"
 	"github.com/Tsisar/solana-indexer/internal/storage"
 	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/generic"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/aggregator"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/maping"
 )
 
-func MapEvent(ctx context.Context, db *storage.Gorm, event core.Event) {
-	if err := maping.Event(ctx, db.DB, event); err != nil {
-		if err := maping.Error(ctx, db.DB, err); err != nil {
-			log.Errorf("Failed to map error: %v", err)
-		}
-		log.Fatalf("Failed to map event: %v", err)
+// MapEvent maps a program event into the subgraph. A failure is reported with MapError.
+func MapEvent(ctx context.Context, db storage.EntityStore, event core.Event) error {
+	if err := maping.Event(ctx, db, event); err != nil {
+		return fmt.Errorf("event %s of %s: %w", event.Name, event.TransactionSignature, err)
 	}
+	return nil
 }
 
-func MapInstruction(ctx context.Context, db *storage.Gorm, event core.Event) {
-	if err := maping.Instruction(ctx, db.DB, event); err != nil {
-		if err := maping.Error(ctx, db.DB, err); err != nil {
-			log.Errorf("Failed to map error: %v", err)
-		}
-		log.Fatalf("Failed to map instruction: %v", err)
+// MapInstruction maps a token instruction into the subgraph. A failure is reported with MapError.
+func MapInstruction(ctx context.Context, db storage.EntityStore, event core.Event) error {
+	if err := maping.Instruction(ctx, db, event); err != nil {
+		return fmt.Errorf("instruction %s of %s: %w", event.Name, event.TransactionSignature, err)
 	}
+	return nil
 }
 
-func MapMetadata(ctx context.Context, db *storage.Gorm, signature string, slot uint64, blockTime int64) {
-	if err := maping.Metadata(ctx, db.DB, signature, slot, blockTime); err != nil {
-		if err := maping.Error(ctx, db.DB, err); err != nil {
-			log.Errorf("Failed to map error: %v", err)
-		}
-		log.Fatalf("Failed to map metadata: %v", err)
+// Map maps a stored event into the subgraph according to its kind. A failure is reported with MapError.
+func Map(ctx context.Context, db storage.EntityStore, event core.Event) error {
+	switch event.Kind {
+	case core.EventKindProgram:
+		return MapEvent(ctx, db, event)
+	case core.EventKindInstruction:
+		return MapInstruction(ctx, db, event)
+	default:
+		return fmt.Errorf("%s of %s has unknown kind %q", event.Name, event.TransactionSignature, event.Kind)
 	}
+}
 
+// MapMetadata records a mapped transaction in the subgraph metadata. A failure is reported with MapError.
+func MapMetadata(ctx context.Context, db storage.EntityStore, signature string, slot uint64, blockTime int64) error {
+	if err := maping.Metadata(ctx, db, signature, slot, blockTime); err != nil {
+		return fmt.Errorf("metadata of %s: %w", signature, err)
+	}
+	return nil
 }
 
 func RunAggregator(ctx context.Context, db *storage.Gorm) {
-	if err := aggregator.Start(ctx, db.DB); err != nil {
-		if err := maping.Error(ctx, db.DB, err); err != nil {
+	if err := aggregator.Start(ctx, db); err != nil {
+		if err := maping.Error(ctx, db, err); err != nil {
 			log.Errorf("Failed to map error: %v", err)
 		}
 		log.Errorf("Failed to run aggregator: %v", err)
 	}
 }
 
-func MapError(ctx context.Context, db *storage.Gorm, err error) {
-	if err := maping.Error(ctx, db.DB, err); err != nil {
+func MapError(ctx context.Context, db storage.EntityStore, err error) {
+	if err := maping.Error(ctx, db, err); err != nil {
 		log.Fatalf("Failed to map error: %v", err)
 	}
 }
+
+// ClearError resets the _meta indexing error flag.
+func ClearError(ctx context.Context, db *storage.Gorm) error {
+	return maping.ClearError(ctx, db.DB)
+}
+
+// rebuildSlotBatch is the number of slots loaded per query while replaying events.
+const rebuildSlotBatch = 100
+
+// Rebuild truncates all subgraph tables and re-maps every stored event in the order the
+// parser applies them, then re-runs the aggregation.
+func Rebuild(ctx context.Context, db *storage.Gorm) error {
+	log.Info("[subgraph] Rebuilding subgraph from stored events...")
+	if err := storage.TruncateSubgraphTables(ctx, db); err != nil {
+		return fmt.Errorf("[subgraph] failed to truncate subgraph tables: %w", err)
+	}
+
+	return replayFrom(ctx, db, 0)
+}
+
+// RebuildFrom reverts the subgraph to its state before the given slot and replays
+// the stored events from that slot on. Without versioned storage there is no entity
+// history to revert to, so the whole subgraph is rebuilt instead.
+func RebuildFrom(ctx context.Context, db *storage.Gorm, slot uint64) error {
+	if !generic.VersioningEnabled() || slot == 0 {
+		return Rebuild(ctx, db)
+	}
+	return RevertFrom(ctx, db, slot)
+}
+
+// RevertFrom reverts the subgraph to its state before the given slot through the entity versions
+// and replays the stored events from that slot on. Unlike RebuildFrom it never rebuilds the whole
+// subgraph, so it fails without versioned storage.
+func RevertFrom(ctx context.Context, db *storage.Gorm, slot uint64) error {
+	if !generic.VersioningEnabled() {
+		return fmt.Errorf("[subgraph] reverting to slot %d requires versioned entities", slot)
+	}
+	if slot == 0 {
+		return Rebuild(ctx, db)
+	}
+
+	log.Infof("[subgraph] Rolling back subgraph to slot %d...", slot-1)
+	if err := generic.Rollback(ctx, db.DB, slot-1); err != nil {
+		return fmt.Errorf("[subgraph] failed to roll back to slot %d: %w", slot-1, err)
+	}
+	return replayFrom(ctx, db, slot-1)
+}
+
+// replayFrom maps all stored events after the given slot and re-runs the aggregation
+// from scratch.
+func replayFrom(ctx context.Context, db *storage.Gorm, cursor uint64) error {
+	if err := aggregator.Reset(ctx, db); err != nil {
+		return fmt.Errorf("[subgraph] failed to reset aggregator: %w", err)
+	}
+
+	var replayed int
+	for {
+		events, err := db.LoadEventsBySlotCursor(ctx, cursor, rebuildSlotBatch)
+		if err != nil {
+			return fmt.Errorf("[subgraph] failed to load events after slot %d: %w", cursor, err)
+		}
+		if len(events) == 0 {
+			break
+		}
+		if err := replay(ctx, db, events); err != nil {
+			return err
+		}
+		replayed += len(events)
+		cursor = events[len(events)-1].Slot
+	}
+
+	if err := aggregator.Aggregate(ctx, db); err != nil {
+		return fmt.Errorf("[subgraph] failed to aggregate after rebuild: %w", err)
+	}
+	log.Infof("[subgraph] Rebuild complete: %d events replayed up to slot %d", replayed, cursor)
+	return nil
+}
+
+// replay maps already stored events and updates the metadata after each transaction.
+func replay(ctx context.Context, db storage.EntityStore, events []core.Event) error {
+	for i, event := range events {
+		ctx := generic.WithSlot(ctx, event.Slot)
+
+		if err := Map(ctx, db, event); err != nil {
+			return fmt.Errorf("[subgraph] failed to replay: %w", err)
+		}
+
+		if i == len(events)-1 || events[i+1].TransactionSignature != event.TransactionSignature {
+			if err := maping.Metadata(ctx, db, event.TransactionSignature, event.Slot, event.BlockTime); err != nil {
+				return fmt.Errorf("[subgraph] failed to replay metadata of %s: %w", event.TransactionSignature, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package subgraph
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Tsisar/solana-indexer/internal/storage/memory"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/core"
+	"github.com/Tsisar/solana-indexer/internal/storage/model/subgraph"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/events"
+	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
+	"testing"
+)
+
+func TestReplayMapsByKind(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	shareToken := subgraph.Token{ID: "share-mint"}
+	if err := shareToken.Save(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	vault := subgraph.Vault{ID: "vault", ShareToken: &shareToken}
+	if err := vault.Save(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(events.MintToInstruction{To: "holder", Mint: "share-mint", Amount: types.NewBigDecimalFromFloat(250)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The log index doesn't tell the kind
+	mint := core.Event{
+		TransactionSignature: "sig",
+		LogIndex:             2100,
+		Slot:                 7,
+		BlockTime:            1_700_000_007,
+		Name:                 "MintToInstruction",
+		Kind:                 core.EventKindInstruction,
+		JsonEv:               data,
+	}
+	if err := replay(ctx, db, []core.Event{mint}); err != nil {
+		t.Fatal(err)
+	}
+	holder := subgraph.ShareToken{ID: "holder"}
+	if ok, err := holder.Load(ctx, db); err != nil || !ok {
+		t.Fatalf("failed to load the share token: %v", err)
+	}
+	if holder.TotalMinted.String() != "250" {
+		t.Errorf("expected 250 minted, got %s", holder.TotalMinted)
+	}
+
+	mint.Kind = ""
+	if err := replay(ctx, db, []core.Event{mint}); err == nil {
+		t.Error("expected an error for an event without a kind")
+	}
+}
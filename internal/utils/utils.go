@@ -1,9 +1,11 @@
 package utils
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/Tsisar/solana-indexer/internal/config"
 	"github.com/Tsisar/solana-indexer/internal/subgraph/types"
 	"github.com/gagliardetto/solana-go"
 	"math/big"
@@ -12,22 +14,41 @@ import (
 
 var msPerDay = big.NewInt(86_400_000) // 24*60*60*1000
 var daysPerYear = big.NewFloat(365.0)
-var attempts = 5 // TODO: make this configurable from application settings
 
-// Retry executes the provided function up to `attempts` times until it succeeds.
-// If all attempts fail, it returns an error.
+// Retry executes the provided function up to the configured number of attempts until it succeeds.
+// If all attempts fail, it returns the last error.
 // Generic version that works for any return type.
 func Retry[T any](fn func() (T, error)) (T, error) {
 	var zero T
+	var err error
 
+	attempts := config.App.Retry.Attempts
 	for i := 0; i < attempts; i++ {
-		result, err := fn()
-		if err == nil {
+		var result T
+		if result, err = fn(); err == nil {
 			return result, nil
 		}
-		time.Sleep(time.Second)
+		time.Sleep(config.App.Retry.Delay)
 	}
-	return zero, fmt.Errorf("[utils] retry failed after %d attempts", attempts)
+	return zero, fmt.Errorf("[utils] retry failed after %d attempts: %w", attempts, err)
+}
+
+// Graceful returns a context for in-flight work that ends the grace period after ctx does,
+// so work started before a shutdown can finish instead of being cut off mid-write.
+func Graceful(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-time.After(grace):
+			case <-work.Done():
+			}
+		case <-work.Done():
+		}
+		cancel()
+	}()
+	return work, cancel
 }
 
 // Ptr returns a pointer to the given value of any type.